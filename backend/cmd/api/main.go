@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -12,6 +13,8 @@ import (
 	"syscall"
 	"time"
 
+	"controle-de-estoque/backend/internal/graph"
+	"controle-de-estoque/backend/internal/grpcserver"
 	"controle-de-estoque/backend/internal/handler"
 	"controle-de-estoque/backend/internal/repository"
 	"controle-de-estoque/backend/internal/service"
@@ -26,26 +29,40 @@ import (
 
 // Config representa a configuração da aplicação.
 type Config struct {
-	ServerAddress string
-	DBURL         string
-	CORSOrigins   []string
-	JWTSecret     string
-	Env           string
+	ServerAddress      string
+	GRPCAddress        string // Porta do servidor gRPC das integrações dos armazéns
+	DBURL              string
+	CORSOrigins        []string
+	JWTSecret          string
+	Env                string
+	IdempotencyTTL     time.Duration // Tempo durante o qual as chaves de idempotência são lembradas
+	LegacyRoutesSunset time.Time     // Data anunciada para a remoção das rotas sem prefixo de versão
 }
 
 // Services agrupa todos os serviços da aplicação para fácil injeção.
 type Services struct {
-	TokenService   service.TokenGenerator
-	UserService    *service.UserService
-	ProductService *service.ProductService
-	ClientService  *service.ClientService
+	TokenService       service.TokenGenerator
+	UserService        *service.UserService
+	ProductService     *service.ProductService
+	ClientService      *service.ClientService
+	CategoryService    *service.CategoryService
+	IdempotencyService *service.IdempotencyService
+	StockEventService  *service.StockEventService
+	ImportService      *service.ProductImportService
+	ReportService      *service.ReportService
+	PriceListService   *service.PriceListService
 }
 
 // Handlers agrupa todos os handlers da aplicação.
 type Handlers struct {
-	ProductHandler *handler.ProductHandler
-	UserHandler    *handler.UserHandler
-	ClientHandler  *handler.ClientHandler
+	ProductHandler   *handler.ProductHandler
+	UserHandler      *handler.UserHandler
+	ClientHandler    *handler.ClientHandler
+	CategoryHandler  *handler.CategoryHandler
+	GraphQLHandler   *handler.GraphQLHandler
+	ImportHandler    *handler.ProductImportHandler
+	ReportHandler    *handler.ReportHandler
+	PriceListHandler *handler.PriceListHandler
 }
 
 func main() {
@@ -77,6 +94,24 @@ func main() {
 	services := initServices(dbpool, cfg)
 	handlers := initHandlers(services)
 
+	backgroundCtx, stopBackground := context.WithCancel(context.Background())
+	defer stopBackground()
+	go purgeIdempotencyKeys(backgroundCtx, services.IdempotencyService, logger)
+	go listenStockEvents(backgroundCtx, services.StockEventService, logger)
+	go runImportJobs(backgroundCtx, services.ImportService, logger)
+
+	grpcServer := grpcserver.NewServer(services.ProductService, services.ClientService, services.StockEventService, services.TokenService)
+	grpcListener, err := net.Listen("tcp", cfg.GRPCAddress)
+	if err != nil {
+		logger.Fatal("Falha ao abrir a porta do servidor gRPC", zap.Error(err), zap.String("address", cfg.GRPCAddress))
+	}
+	go func() {
+		logger.Info("Starting gRPC server", zap.String("address", cfg.GRPCAddress))
+		if err := grpcServer.Serve(grpcListener); err != nil {
+			logger.Fatal("gRPC server failed", zap.Error(err))
+		}
+	}()
+
 	server := &http.Server{
 		Addr:         cfg.ServerAddress,
 		Handler:      setupRouter(handlers, services.TokenService, cfg),
@@ -85,7 +120,11 @@ func main() {
 		IdleTimeout:  120 * time.Second,
 	}
 
-	runServer(server, logger)
+	runServer(server, logger, func() {
+		// Os fluxos de eventos abertos só terminam quando as assinaturas são encerradas.
+		services.StockEventService.Close()
+		grpcServer.GracefulStop()
+	})
 }
 
 func loadConfig() (*Config, error) {
@@ -101,12 +140,25 @@ func loadConfig() (*Config, error) {
 	if jwtSecret == "" {
 		return nil, errors.New("JWT_SECRET é obrigatório")
 	}
+	idempotencyTTL, err := time.ParseDuration(getEnv("IDEMPOTENCY_TTL", service.DefaultIdempotencyTTL.String()))
+	if err != nil || idempotencyTTL <= 0 {
+		return nil, errors.New("IDEMPOTENCY_TTL inválido: deve ser uma duração positiva, como 24h")
+	}
+	sunset := legacyRoutesDeprecatedAt.AddDate(0, 6, 0)
+	if value := getEnv("LEGACY_ROUTES_SUNSET", ""); value != "" {
+		if sunset, err = time.Parse(time.DateOnly, value); err != nil {
+			return nil, errors.New("LEGACY_ROUTES_SUNSET inválido: use o formato AAAA-MM-DD")
+		}
+	}
 	return &Config{
-		ServerAddress: getEnv("SERVER_ADDRESS", ":8080"),
-		DBURL:         dbURL,
-		CORSOrigins:   strings.Split(getEnv("CORS_ALLOWED_ORIGINS", "http://localhost:5173"), ","),
-		JWTSecret:     jwtSecret,
-		Env:           getEnv("ENV", "development"),
+		ServerAddress:      getEnv("SERVER_ADDRESS", ":8080"),
+		GRPCAddress:        getEnv("GRPC_ADDRESS", ":9090"),
+		DBURL:              dbURL,
+		CORSOrigins:        strings.Split(getEnv("CORS_ALLOWED_ORIGINS", "http://localhost:5173"), ","),
+		JWTSecret:          jwtSecret,
+		Env:                getEnv("ENV", "development"),
+		IdempotencyTTL:     idempotencyTTL,
+		LegacyRoutesSunset: sunset,
 	}, nil
 }
 
@@ -115,80 +167,195 @@ func initServices(dbpool *pgxpool.Pool, cfg *Config) *Services {
 	userRepo := repository.NewUserRepository(dbpool)
 	clientRepo := repository.NewClientRepository(dbpool)
 	clientStockRepo := repository.NewClientStockRepository(dbpool) // ✅ corrigido para passar dbpool
+	packagingRepo := repository.NewPackagingRepository(dbpool)
+	movementRepo := repository.NewStockMovementRepository(dbpool)
+	categoryRepo := repository.NewCategoryRepository(dbpool)
+	lotRepo := repository.NewLotRepository(dbpool)
+	serialRepo := repository.NewSerialRepository(dbpool)
+	idempotencyRepo := repository.NewIdempotencyRepository(dbpool)
+	stockEventListener := repository.NewStockEventListener(dbpool)
+	importJobRepo := repository.NewImportJobRepository(dbpool)
+	reportRepo := repository.NewReportRepository(dbpool)
+	costLayerRepo := repository.NewCostLayerRepository(dbpool)
+	priceListRepo := repository.NewPriceListRepository(dbpool)
 
 	passwordService := service.NewPasswordService()
 	tokenService := service.NewTokenService(cfg.JWTSecret)
 
-	productService := service.NewProductService(dbpool, productRepo, clientStockRepo)
+	idempotencyService := service.NewIdempotencyService(idempotencyRepo, cfg.IdempotencyTTL)
+	productService := service.NewProductService(dbpool, productRepo, clientRepo, clientStockRepo, packagingRepo, movementRepo, categoryRepo, lotRepo, serialRepo, costLayerRepo, idempotencyService)
 	userService := service.NewUserService(userRepo, passwordService, tokenService)
-	clientService := service.NewClientService(clientRepo, clientStockRepo) // ✅ recebe estoque
+	clientService := service.NewClientService(dbpool, clientRepo, clientStockRepo, idempotencyService) // ✅ recebe estoque
+	categoryService := service.NewCategoryService(categoryRepo)
+	stockEventService := service.NewStockEventService(stockEventListener)
+	importService := service.NewProductImportService(productService, categoryRepo, importJobRepo)
+	reportService := service.NewReportService(reportRepo)
+	priceListService := service.NewPriceListService(priceListRepo)
 
 	return &Services{
-		TokenService:   tokenService,
-		UserService:    userService,
-		ProductService: productService,
-		ClientService:  clientService,
+		TokenService:       tokenService,
+		UserService:        userService,
+		ProductService:     productService,
+		ClientService:      clientService,
+		CategoryService:    categoryService,
+		IdempotencyService: idempotencyService,
+		StockEventService:  stockEventService,
+		ImportService:      importService,
+		ReportService:      reportService,
+		PriceListService:   priceListService,
 	}
 }
 
 func initHandlers(s *Services) *Handlers {
 	return &Handlers{
-		ProductHandler: handler.NewProductHandler(s.ProductService),
-		UserHandler:    handler.NewUserHandler(s.UserService, zap.L()),
-		ClientHandler:  handler.NewClientHandler(s.ClientService),
+		ProductHandler:   handler.NewProductHandler(s.ProductService, s.IdempotencyService),
+		UserHandler:      handler.NewUserHandler(s.UserService, zap.L()),
+		ClientHandler:    handler.NewClientHandler(s.ClientService, s.IdempotencyService),
+		CategoryHandler:  handler.NewCategoryHandler(s.CategoryService),
+		GraphQLHandler:   handler.NewGraphQLHandler(graph.NewSchema(s.ProductService, s.ClientService, s.CategoryService)),
+		ImportHandler:    handler.NewProductImportHandler(s.ImportService),
+		ReportHandler:    handler.NewReportHandler(s.ReportService),
+		PriceListHandler: handler.NewPriceListHandler(s.PriceListService),
 	}
 }
 
+// legacyRoutesDeprecatedAt é a data em que as rotas sem prefixo de versão foram descontinuadas.
+var legacyRoutesDeprecatedAt = time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)
+
 func setupRouter(h *Handlers, tokenService service.TokenGenerator, cfg *Config) *chi.Mux {
 	r := chi.NewRouter()
 
 	// Middlewares globais
 	r.Use(middleware.RequestID, middleware.RealIP, middleware.Recoverer, middleware.Logger)
-	r.Use(middleware.Timeout(60 * time.Second))
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   cfg.CORSOrigins,
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type"},
+		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "Idempotency-Key"},
+		ExposedHeaders:   []string{middleware.RequestIDHeader, "Idempotent-Replayed", "Deprecation", "Sunset", "Link"},
 		AllowCredentials: true,
 		MaxAge:           300,
 	}))
 
-	// Rotas públicas
+	r.NotFound(handler.NotFound)
+	r.MethodNotAllowed(handler.MethodNotAllowed)
+
+	// Verificação de saúde, fora do versionamento da API
 	r.Get("/healthcheck", healthCheckHandler)
-	r.Post("/register", h.UserHandler.Register)
-	r.Post("/login", h.UserHandler.Login)
 
-	// Rotas protegidas
+	r.Route(handler.APIV1Prefix, apiV1(h, tokenService))
+
+	// Rotas legadas, sem prefixo de versão: mesmas rotas da v1, marcadas como descontinuadas
 	r.Group(func(r chi.Router) {
-		r.Use(handler.AuthMiddleware(tokenService))
+		r.Use(handler.Deprecated(legacyRoutesDeprecatedAt, cfg.LegacyRoutesSunset, handler.APIV1Prefix))
+		apiV1(h, tokenService)(r)
+	})
 
-		r.Get("/me", h.UserHandler.GetMe)
+	return r
+}
 
-		r.Route("/products", func(r chi.Router) {
-			r.Post("/", h.ProductHandler.CreateProduct)
-			r.Get("/", h.ProductHandler.ListProducts)
-			r.Get("/{productID}", h.ProductHandler.GetProductByID)
-			r.Put("/{productID}", h.ProductHandler.UpdateProduct)
-			r.Delete("/{productID}", h.ProductHandler.DeleteProduct)
-			r.Post("/{productID}/transfer", h.ProductHandler.TransferStock)
+// requestTimeout limita a duração das requisições comuns da API.
+var requestTimeout = middleware.Timeout(60 * time.Second)
+
+// importTimeout limita o envio de importações, que inclui o upload do arquivo.
+var importTimeout = middleware.Timeout(handler.ImportUploadTimeout + time.Minute)
+
+// apiV1 registra as rotas da versão 1 da API. Uma nova versão é montada da mesma forma
+// (ex.: r.Route("/api/v2", apiV2(h, tokenService))), reutilizando os handlers e
+// serviços de h e registrando handlers próprios apenas para as rotas que mudarem.
+func apiV1(h *Handlers, tokenService service.TokenGenerator) func(chi.Router) {
+	return func(r chi.Router) {
+		// Rotas públicas
+		r.Get("/openapi.json", handler.OpenAPISpec)
+		r.Get("/docs", handler.SwaggerUI)
+		r.With(requestTimeout).Post("/register", h.UserHandler.Register)
+		r.With(requestTimeout).Post("/login", h.UserHandler.Login)
+
+		// Exportações: sem o limite de tempo das demais rotas, pois percorrem a tabela inteira
+		r.Group(func(r chi.Router) {
+			r.Use(handler.AuthMiddleware(tokenService))
+
+			r.Get("/products/export", h.ProductHandler.ExportProducts)
+			r.Get("/clients/export", h.ClientHandler.ExportClients)
+			r.Get("/clients/stock/export", h.ClientHandler.ExportHoldings)
 		})
 
-		r.Route("/clients", func(r chi.Router) {
-			r.Post("/", h.ClientHandler.CreateClient)
-			r.Get("/", h.ClientHandler.ListClients)
-			r.Get("/{clientID}", h.ClientHandler.GetClientByID)
-			r.Put("/{clientID}", h.ClientHandler.UpdateClient)
-			r.Delete("/{clientID}", h.ClientHandler.DeleteClient)
-
-			// ✅ Nova rota de estoque do cliente
-			r.Get("/{clientID}/stock", h.ClientHandler.ListStockByClientID)
+		// Envio de importações: o upload do arquivo tem um prazo maior que o das demais rotas
+		r.With(importTimeout, handler.AuthMiddleware(tokenService)).Post("/products/import", h.ImportHandler.SubmitImport)
+
+		// Rotas protegidas
+		r.Group(func(r chi.Router) {
+			r.Use(requestTimeout, handler.AuthMiddleware(tokenService))
+
+			r.Get("/me", h.UserHandler.GetMe)
+			r.Post("/graphql", h.GraphQLHandler.Query)
+
+			r.Route("/products", func(r chi.Router) {
+				r.Post("/", h.ProductHandler.CreateProduct)
+				r.Get("/", h.ProductHandler.ListProducts)
+				r.Get("/lookup", h.ProductHandler.LookupProduct)
+				r.Get("/import/{jobID}", h.ImportHandler.GetImport)
+				r.Get("/import/{jobID}/rows", h.ImportHandler.ListImportRows)
+				r.Get("/import/{jobID}/errors", h.ImportHandler.DownloadImportErrors)
+				r.Get("/{productID}", h.ProductHandler.GetProductByID)
+				r.Put("/{productID}", h.ProductHandler.UpdateProduct)
+				r.Patch("/{productID}", h.ProductHandler.PatchProduct)
+				r.Delete("/{productID}", h.ProductHandler.DeleteProduct)
+				r.Post("/{productID}/transfer", h.ProductHandler.TransferStock)
+				r.Post("/{productID}/receipts", h.ProductHandler.ReceiveStock)
+				r.Post("/{productID}/adjustments", h.ProductHandler.AdjustStock)
+				r.Post("/{productID}/returns", h.ProductHandler.ReturnStock)
+				r.Get("/{productID}/packagings", h.ProductHandler.ListPackagings)
+				r.Put("/{productID}/packagings", h.ProductHandler.ReplacePackagings)
+				r.Get("/{productID}/variants", h.ProductHandler.ListVariants)
+				r.Post("/{productID}/variants", h.ProductHandler.GenerateVariants)
+				r.Get("/{productID}/lots", h.ProductHandler.ListLots)
+				r.Get("/{productID}/serials", h.ProductHandler.ListSerials)
+			})
+
+			r.Get("/lots/expiring", h.ProductHandler.ListExpiringLots)
+			r.Get("/serials/{serial}", h.ProductHandler.GetSerial)
+
+			r.Get("/reports/valuation", h.ReportHandler.Valuation)
+			r.Get("/reports/margin", h.ReportHandler.Margin)
+
+			r.Route("/categories", func(r chi.Router) {
+				r.Post("/", h.CategoryHandler.CreateCategory)
+				r.Get("/", h.CategoryHandler.ListCategories)
+				r.Get("/{categoryID}", h.CategoryHandler.GetCategoryByID)
+				r.Put("/{categoryID}", h.CategoryHandler.UpdateCategory)
+				r.Delete("/{categoryID}", h.CategoryHandler.DeleteCategory)
+				r.Get("/{categoryID}/attributes", h.CategoryHandler.ListAttributes)
+				r.Put("/{categoryID}/attributes", h.CategoryHandler.ReplaceAttributes)
+			})
+
+			r.Route("/price-lists", func(r chi.Router) {
+				r.Post("/", h.PriceListHandler.CreatePriceList)
+				r.Get("/", h.PriceListHandler.ListPriceLists)
+				r.Get("/{priceListID}", h.PriceListHandler.GetPriceList)
+				r.Put("/{priceListID}", h.PriceListHandler.UpdatePriceList)
+				r.Delete("/{priceListID}", h.PriceListHandler.DeletePriceList)
+			})
+
+			r.Route("/clients", func(r chi.Router) {
+				r.Post("/", h.ClientHandler.CreateClient)
+				r.Get("/", h.ClientHandler.ListClients)
+				r.Get("/{clientID}", h.ClientHandler.GetClientByID)
+				r.Put("/{clientID}", h.ClientHandler.UpdateClient)
+				r.Patch("/{clientID}", h.ClientHandler.PatchClient)
+				r.Delete("/{clientID}", h.ClientHandler.DeleteClient)
+
+				// ✅ Nova rota de estoque do cliente
+				r.Get("/{clientID}/stock", h.ClientHandler.ListStockByClientID)
+				r.Get("/{clientID}/price-lists", h.PriceListHandler.ListClientPriceLists)
+				r.Put("/{clientID}/price-lists", h.PriceListHandler.AssignClientPriceLists)
+			})
 		})
-	})
-
-	return r
+	}
 }
 
-func runServer(server *http.Server, logger *zap.Logger) {
+// runServer atende as requisições HTTP até receber um sinal de término. Depois de parar
+// o servidor HTTP, chama shutdown para encerrar os demais servidores.
+func runServer(server *http.Server, logger *zap.Logger, shutdown func()) {
 	serverCtx, serverStopCtx := context.WithCancel(context.Background())
 	sig := make(chan os.Signal, 1)
 	signal.Notify(sig, syscall.SIGHUP, syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT)
@@ -201,6 +368,7 @@ func runServer(server *http.Server, logger *zap.Logger) {
 		if err := server.Shutdown(shutdownCtx); err != nil {
 			logger.Fatal("HTTP server shutdown error", zap.Error(err))
 		}
+		shutdown()
 		serverStopCtx()
 	}()
 
@@ -213,6 +381,76 @@ func runServer(server *http.Server, logger *zap.Logger) {
 	logger.Info("Server stopped gracefully")
 }
 
+// purgeIdempotencyKeys remove periodicamente as chaves de idempotência expiradas até ctx ser cancelado.
+func purgeIdempotencyKeys(ctx context.Context, idempotency *service.IdempotencyService, logger *zap.Logger) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			removed, err := idempotency.PurgeExpired(ctx)
+			if err != nil {
+				logger.Error("Falha ao remover chaves de idempotência expiradas", zap.Error(err))
+				continue
+			}
+			if removed > 0 {
+				logger.Info("Chaves de idempotência expiradas removidas", zap.Int64("removed", removed))
+			}
+		}
+	}
+}
+
+// listenStockEvents mantém a escuta das movimentações confirmadas para o fluxo de
+// eventos do gRPC, reconectando com espera crescente (até 30s) em caso de falha.
+func listenStockEvents(ctx context.Context, events *service.StockEventService, logger *zap.Logger) {
+	const maxBackoff = 30 * time.Second
+	backoff := time.Second
+	for {
+		started := time.Now()
+		err := events.Listen(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		logger.Error("Escuta de movimentações de estoque interrompida", zap.Error(err), zap.Duration("retryIn", backoff))
+		if time.Since(started) > maxBackoff {
+			backoff = time.Second
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxBackoff)
+	}
+}
+
+// runImportJobs processa a fila de importações de produtos até ctx ser cancelado. A
+// fila é verificada a cada 10s, ou imediatamente quando um job é enviado nesta instância.
+func runImportJobs(ctx context.Context, imports *service.ProductImportService, logger *zap.Logger) {
+	ticker := time.NewTicker(10 * time.Second)
+	defer ticker.Stop()
+	for {
+		processed, err := imports.ProcessNext(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			logger.Error("Falha ao processar importação de produtos", zap.Error(err))
+		}
+		if processed {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-imports.Submitted():
+		}
+	}
+}
+
 func healthCheckHandler(w http.ResponseWriter, r *http.Request) {
 	response := struct {
 		Status string `json:"status"`
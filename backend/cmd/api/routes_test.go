@@ -0,0 +1,122 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"controle-de-estoque/backend/internal/graph"
+	"controle-de-estoque/backend/internal/handler"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+// unversionedRoutes são as rotas registradas fora do prefixo de versão da API.
+var unversionedRoutes = map[string]bool{"/healthcheck": true}
+
+func newTestRouter() *chi.Mux {
+	return setupRouter(&Handlers{
+		ProductHandler:   handler.NewProductHandler(nil, nil),
+		UserHandler:      handler.NewUserHandler(nil, zap.NewNop()),
+		ClientHandler:    handler.NewClientHandler(nil, nil),
+		CategoryHandler:  handler.NewCategoryHandler(nil),
+		GraphQLHandler:   handler.NewGraphQLHandler(graph.NewSchema(nil, nil, nil)),
+		ImportHandler:    handler.NewProductImportHandler(nil),
+		ReportHandler:    handler.NewReportHandler(nil),
+		PriceListHandler: handler.NewPriceListHandler(nil),
+	}, nil, &Config{LegacyRoutesSunset: time.Date(2027, time.April, 18, 0, 0, 0, 0, time.UTC)})
+}
+
+// TestOpenAPICoversRoutes garante que o documento servido em /api/v1/openapi.json
+// descreve exatamente as rotas registradas em setupRouter, e que cada rota da v1 tem
+// o alias legado correspondente.
+func TestOpenAPICoversRoutes(t *testing.T) {
+	router := newTestRouter()
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, handler.APIV1Prefix+"/openapi.json", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET %s/openapi.json: status %d", handler.APIV1Prefix, rec.Code)
+	}
+	var spec struct {
+		OpenAPI string                                `json:"openapi"`
+		Paths   map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &spec); err != nil {
+		t.Fatalf("documento OpenAPI inválido: %v", err)
+	}
+	if !strings.HasPrefix(spec.OpenAPI, "3.1") {
+		t.Errorf("versão OpenAPI %q, esperado 3.1.x", spec.OpenAPI)
+	}
+
+	versioned := make(map[string]bool)
+	legacy := make(map[string]bool)
+	err := chi.Walk(router, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		if len(route) > 1 {
+			route = strings.TrimSuffix(route, "/")
+		}
+		path, isV1 := strings.CutPrefix(route, handler.APIV1Prefix)
+		operation := strings.ToLower(method) + " " + path
+		switch {
+		case isV1, unversionedRoutes[path]:
+			versioned[operation] = true
+		default:
+			legacy[operation] = true
+		}
+		if _, ok := spec.Paths[path][strings.ToLower(method)]; !ok {
+			t.Errorf("rota %s %s ausente do documento OpenAPI", method, route)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("chi.Walk: %v", err)
+	}
+
+	for path, item := range spec.Paths {
+		for method := range item {
+			if method == "parameters" || method == "servers" {
+				continue
+			}
+			operation := method + " " + path
+			if !versioned[operation] {
+				t.Errorf("operação %s %s do documento OpenAPI não está registrada no roteador", strings.ToUpper(method), path)
+			}
+			if !unversionedRoutes[path] && !legacy[operation] {
+				t.Errorf("operação %s %s sem alias legado", strings.ToUpper(method), path)
+			}
+		}
+	}
+}
+
+// TestLegacyRoutesAreDeprecated garante que apenas as rotas sem prefixo de versão
+// anunciam a descontinuação.
+func TestLegacyRoutesAreDeprecated(t *testing.T) {
+	router := newTestRouter()
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /openapi.json: status %d", rec.Code)
+	}
+	if got := rec.Header().Get("Deprecation"); got != "@1792281600" {
+		t.Errorf("Deprecation = %q", got)
+	}
+	if got := rec.Header().Get("Sunset"); got != "Sun, 18 Apr 2027 00:00:00 GMT" {
+		t.Errorf("Sunset = %q", got)
+	}
+	if got := rec.Header().Get("Link"); got != `</api/v1/openapi.json>; rel="successor-version"` {
+		t.Errorf("Link = %q", got)
+	}
+
+	for _, path := range []string{handler.APIV1Prefix + "/openapi.json", "/healthcheck"} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if got := rec.Header().Get("Deprecation"); got != "" {
+			t.Errorf("GET %s: Deprecation = %q, esperado ausente", path, got)
+		}
+	}
+}
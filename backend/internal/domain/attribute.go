@@ -0,0 +1,34 @@
+package domain
+
+import "github.com/google/uuid"
+
+// AttributeType define o tipo de valor aceito por um atributo personalizado.
+type AttributeType string
+
+// Tipos de atributo suportados.
+const (
+	AttributeString  AttributeType = "string"
+	AttributeNumber  AttributeType = "number"
+	AttributeBoolean AttributeType = "boolean"
+	AttributeEnum    AttributeType = "enum" // Texto restrito a Options
+)
+
+// Valid informa se o tipo de atributo é um dos suportados.
+func (t AttributeType) Valid() bool {
+	switch t {
+	case AttributeString, AttributeNumber, AttributeBoolean, AttributeEnum:
+		return true
+	}
+	return false
+}
+
+// AttributeDefinition descreve um atributo personalizado disponível para os produtos
+// de uma categoria e de todas as suas subcategorias.
+type AttributeDefinition struct {
+	CategoryID uuid.UUID     `json:"category_id" db:"category_id"`
+	Key        string        `json:"key" db:"key"`
+	Label      string        `json:"label" db:"label"`
+	Type       AttributeType `json:"type" db:"type"`
+	Required   bool          `json:"required" db:"required"`
+	Options    []string      `json:"options,omitempty" db:"options"`
+}
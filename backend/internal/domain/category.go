@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Category representa um nó da árvore de categorias de produtos.
+// Path é o caminho materializado com os IDs dos ancestrais (ex.: "/<raiz>/<filha>/")
+// e é mantido pelo repositório; não faz parte do contrato da API.
+type Category struct {
+	ID        uuid.UUID  `json:"id" db:"id"`
+	ParentID  *uuid.UUID `json:"parent_id" db:"parent_id"`
+	Name      string     `json:"name" db:"name"`
+	Path      string     `json:"-" db:"path"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at" db:"updated_at"`
+}
@@ -8,10 +8,99 @@ import (
 
 // Client representa a entidade de cliente no nosso sistema.
 type Client struct {
-	ID        uuid.UUID `json:"id" db:"id"`
-	Name      string    `json:"name" db:"name"`
-	Email     string    `json:"email" db:"email"`
-	Phone     string    `json:"phone" db:"phone"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+	ID        uuid.UUID    `json:"id" db:"id"`
+	Name      string       `json:"name" db:"name"`
+	Email     string       `json:"email" db:"email"`
+	Phone     string       `json:"phone" db:"phone"`
+	Document  string       `json:"document" db:"document"` // CPF (11 dígitos) ou CNPJ (14 dígitos), apenas números
+	Status    ClientStatus `json:"status" db:"status"`
+	Addresses []Address    `json:"addresses,omitempty" db:"-"`
+	Contacts  []Contact    `json:"contacts,omitempty" db:"-"`
+	CreatedAt time.Time    `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at" db:"updated_at"`
+}
+
+// ClientStatus indica se o cliente pode receber transferências de estoque.
+type ClientStatus string
+
+// Situações possíveis de um cliente.
+const (
+	ClientActive  ClientStatus = "active"
+	ClientBlocked ClientStatus = "blocked" // Não recebe novas transferências
+)
+
+// Valid informa se a situação é uma das suportadas.
+func (s ClientStatus) Valid() bool {
+	return s == ClientActive || s == ClientBlocked
+}
+
+// AddressType identifica a finalidade de um endereço do cliente.
+type AddressType string
+
+// Tipos de endereço suportados.
+const (
+	AddressBilling  AddressType = "billing"  // Cobrança
+	AddressDelivery AddressType = "delivery" // Entrega
+)
+
+// Address é um endereço do cliente. O CEP é armazenado apenas com os 8 dígitos.
+type Address struct {
+	ID         uuid.UUID   `json:"id" db:"id"`
+	Type       AddressType `json:"type" db:"type"`
+	Street     string      `json:"street" db:"street"`
+	Number     string      `json:"number" db:"number"`
+	Complement string      `json:"complement" db:"complement"`
+	District   string      `json:"district" db:"district"`
+	City       string      `json:"city" db:"city"`
+	State      string      `json:"state" db:"state"` // UF, ex.: SP
+	CEP        string      `json:"cep" db:"cep"`
+}
+
+// Contact é uma pessoa de contato do cliente.
+type Contact struct {
+	ID    uuid.UUID `json:"id" db:"id"`
+	Name  string    `json:"name" db:"name"`
+	Email string    `json:"email" db:"email"`
+	Phone string    `json:"phone" db:"phone"`
+	Role  string    `json:"role" db:"role"` // Cargo ou função, ex.: Compras
+}
+
+// ClientFilter reúne os critérios de filtragem da listagem de clientes.
+type ClientFilter struct {
+	Search           string       // Trecho do nome, email, telefone ou documento (case-insensitive)
+	Status           ClientStatus // Apenas clientes nesta situação
+	HoldingProductID *uuid.UUID   // Apenas clientes com saldo deste produto
+	WithStock        bool         // Apenas clientes com saldo de qualquer produto
+
+	// Sort define a ordenação; vazia ordena por nome.
+	Sort []SortField
+}
+
+// ClientSortFields lista os campos aceitos na ordenação de clientes.
+var ClientSortFields = []string{"name", "email", "created_at", "updated_at"}
+
+// Applied descreve os filtros efetivamente aplicados, com os mesmos nomes dos
+// parâmetros de consulta, para devolução nos metadados da listagem.
+func (f ClientFilter) Applied() map[string]any {
+	applied := make(map[string]any)
+	if f.Search != "" {
+		applied["search"] = f.Search
+	}
+	if f.Status != "" {
+		applied["status"] = f.Status
+	}
+	if f.HoldingProductID != nil {
+		applied["product"] = *f.HoldingProductID
+	}
+	if f.WithStock {
+		applied["has_stock"] = true
+	}
+	if len(f.Sort) > 0 {
+		sort := make([]string, len(f.Sort))
+		for i, field := range f.Sort {
+			sort[i] = field.String()
+		}
+		applied["sort"] = sort
+	}
+	return applied
 }
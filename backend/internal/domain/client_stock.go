@@ -15,10 +15,38 @@ type ClientStock struct {
 	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
 }
 
-// ClientStockDetails é um DTO para a resposta da API, incluindo o nome do produto.
+// ClientStockDetails é um DTO para a resposta da API, incluindo o nome do produto e o
+// preço efetivo para o cliente: o da tabela de preços vigente atribuída a ele que lista o
+// produto (PriceListID) ou, se não houver, o de cadastro. TotalInCents é a quantidade
+// vezes o preço, na mesma moeda.
 type ClientStockDetails struct {
-	ClientID    uuid.UUID `json:"clientId"`
-	ProductID   uuid.UUID `json:"productId"`
-	ProductName string    `json:"productName"`
-	Quantity    int       `json:"quantity"`
+	ClientID         uuid.UUID       `json:"clientId"`
+	ProductID        uuid.UUID       `json:"productId"`
+	ProductName      string          `json:"productName"`
+	Quantity         int             `json:"quantity"`
+	UnitPriceInCents int64           `json:"unitPriceInCents"`
+	Currency         Currency        `json:"currency"`
+	TotalInCents     int64           `json:"totalInCents"`
+	PriceListID      *uuid.UUID      `json:"priceListId,omitempty"`
+	Lots             []LotAllocation `json:"lots,omitempty"` // Lotes recebidos, para produtos com controle de lote
+}
+
+// ClientHolding é o saldo de um produto com um cliente, com os dados de ambos, usado
+// na exportação do estoque dos clientes.
+type ClientHolding struct {
+	ClientID       uuid.UUID     `json:"client_id"`
+	ClientName     string        `json:"client_name"`
+	ClientDocument string        `json:"client_document"`
+	ProductID      uuid.UUID     `json:"product_id"`
+	SKU            string        `json:"sku"`
+	ProductName    string        `json:"product_name"`
+	Unit           UnitOfMeasure `json:"unit"`
+	Quantity       int           `json:"quantity"`
+}
+
+// HoldingFilter reúne os critérios da exportação do estoque dos clientes.
+type HoldingFilter struct {
+	ClientID  *uuid.UUID // Apenas o estoque deste cliente
+	ProductID *uuid.UUID // Apenas o saldo deste produto
+	WithStock bool       // Apenas saldos positivos
 }
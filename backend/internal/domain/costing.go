@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CostingMethod é o método de custeio das saídas de estoque de um produto.
+type CostingMethod string
+
+// Métodos de custeio suportados.
+const (
+	CostingAverage CostingMethod = "average" // Custo médio ponderado (padrão)
+	CostingFIFO    CostingMethod = "fifo"    // PEPS: as saídas consomem as entradas mais antigas
+)
+
+// Valid informa se o método de custeio é conhecido.
+func (m CostingMethod) Valid() bool {
+	return m == CostingAverage || m == CostingFIFO
+}
+
+// MarginGroup indica como o relatório de margem é agrupado.
+type MarginGroup string
+
+// Agrupamentos do relatório de margem.
+const (
+	MarginByProduct MarginGroup = "product"
+	MarginByClient  MarginGroup = "client"
+)
+
+// MarginFilter reúne os critérios do relatório de margem. O período é [From, To).
+type MarginFilter struct {
+	From      time.Time
+	To        time.Time
+	GroupBy   MarginGroup
+	ClientID  *uuid.UUID // Apenas as movimentações deste cliente
+	ProductID *uuid.UUID // Apenas as movimentações deste produto
+}
+
+// MarginFigures são as quantidades e os valores de um grupo do relatório de margem:
+// as transferências do período menos as devoluções. A receita usa o preço de venda
+// vigente em cada transferência e o CMV (custo da mercadoria vendida), o custo das
+// saídas. Sem conversão de câmbio, a margem só é calculada quando a receita e o CMV
+// estão na mesma moeda; do contrário, MarginInCents e MarginPercent são omitidos.
+// MarginPercent também é omitido quando não há receita.
+type MarginFigures struct {
+	QuantitySold     int64    `json:"quantity_sold"`
+	QuantityReturned int64    `json:"quantity_returned"`
+	RevenueInCents   int64    `json:"revenue_in_cents"`
+	COGSInCents      int64    `json:"cogs_in_cents"`
+	MarginInCents    *int64   `json:"margin_in_cents,omitempty"`
+	MarginPercent    *float64 `json:"margin_percent,omitempty"`
+}
+
+// MarginLine é uma linha do relatório de margem, de um produto ou de um cliente,
+// conforme o agrupamento, em um par de moedas: Currency é a da receita (a do preço
+// gravado nas movimentações) e CostCurrency, a do CMV (a do produto).
+type MarginLine struct {
+	ProductID    *uuid.UUID `json:"product_id,omitempty"`
+	SKU          string     `json:"sku,omitempty"`
+	ProductName  string     `json:"product_name,omitempty"`
+	ClientID     *uuid.UUID `json:"client_id,omitempty"`
+	ClientName   string     `json:"client_name,omitempty"`
+	Currency     Currency   `json:"currency"`
+	CostCurrency Currency   `json:"cost_currency"`
+	MarginFigures
+}
+
+// MarginTotal é o total do relatório de margem em um par de moedas da receita e do CMV.
+type MarginTotal struct {
+	Currency     Currency `json:"currency"`
+	CostCurrency Currency `json:"cost_currency"`
+	MarginFigures
+}
+
+// MarginReport é o relatório de margem e CMV de um período, com um total por par de moedas.
+type MarginReport struct {
+	From    time.Time     `json:"from"`
+	To      time.Time     `json:"to"`
+	GroupBy MarginGroup   `json:"group_by"`
+	Lines   []MarginLine  `json:"lines"`
+	Totals  []MarginTotal `json:"totals"`
+}
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Currency é o código ISO 4217 de uma moeda (ex.: "BRL"). Os valores monetários são
+// sempre inteiros na menor unidade da moeda: centavos no real, ienes no iene. Os campos
+// terminados em _in_cents seguem essa regra, qualquer que seja a moeda.
+type Currency string
+
+// DefaultCurrency é a moeda dos produtos cadastrados sem moeda e dos dados anteriores
+// ao suporte a várias moedas.
+const DefaultCurrency Currency = "BRL"
+
+// iso4217 lista os códigos ISO 4217 em vigor.
+const iso4217 = `AED AFN ALL AMD ANG AOA ARS AUD AWG AZN BAM BBD BDT BGN BHD BIF BMD BND BOB BOV
+BRL BSD BTN BWP BYN BZD CAD CDF CHE CHF CHW CLF CLP CNY COP COU CRC CUP CVE CZK DJF DKK DOP
+DZD EGP ERN ETB EUR FJD FKP GBP GEL GHS GIP GMD GNF GTQ GYD HKD HNL HTG HUF IDR ILS INR IQD
+IRR ISK JMD JOD JPY KES KGS KHR KMF KPW KRW KWD KYD KZT LAK LBP LKR LRD LSL LYD MAD MDL MGA
+MKD MMK MNT MOP MRU MUR MVR MWK MXN MXV MYR MZN NAD NGN NIO NOK NPR NZD OMR PAB PEN PGK PHP
+PKR PLN PYG QAR RON RSD RUB RWF SAR SBD SCR SDG SEK SGD SHP SLE SOS SRD SSP STN SVC SYP SZL
+THB TJS TMT TND TOP TRY TTD TWD TZS UAH UGX USD USN UYI UYU UYW UZS VED VES VND VUV WST XAF
+XCD XCG XOF XPF YER ZAR ZMW ZWG`
+
+// currencyMinorUnits registra as moedas cuja menor unidade não é o centésimo.
+var currencyMinorUnits = map[Currency]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0, "PYG": 0,
+	"RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	"CLF": 4, "UYW": 4,
+}
+
+var currencies = func() map[Currency]struct{} {
+	set := make(map[Currency]struct{})
+	for _, code := range strings.Fields(iso4217) {
+		set[Currency(code)] = struct{}{}
+	}
+	return set
+}()
+
+// Valid informa se o código é uma moeda ISO 4217 em vigor.
+func (c Currency) Valid() bool {
+	_, ok := currencies[c]
+	return ok
+}
+
+// MinorUnits retorna o número de casas decimais da moeda (2 para BRL, 0 para JPY).
+func (c Currency) MinorUnits() int {
+	if digits, ok := currencyMinorUnits[c]; ok {
+		return digits
+	}
+	return 2
+}
+
+// Amount é um valor em uma moeda, na menor unidade dela.
+type Amount struct {
+	Currency     Currency `json:"currency"`
+	ValueInCents int64    `json:"value_in_cents"`
+}
+
+// PriceList é uma tabela de preços em uma moeda, vigente em [ValidFrom, ValidTo); limites
+// nulos deixam o período aberto. Atribuída a clientes, substitui o preço de cadastro dos
+// produtos que lista; um item de um produto pai vale para todas as suas variantes que não
+// tenham item próprio.
+type PriceList struct {
+	ID        uuid.UUID       `json:"id" db:"id"`
+	Name      string          `json:"name" db:"name"`
+	Currency  Currency        `json:"currency" db:"currency"`
+	ValidFrom *time.Time      `json:"valid_from" db:"valid_from"`
+	ValidTo   *time.Time      `json:"valid_to" db:"valid_to"`
+	Items     []PriceListItem `json:"items,omitempty" db:"-"` // Apenas na consulta de uma tabela
+	CreatedAt time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+// PriceListItem é o preço de um produto em uma tabela, na moeda da tabela.
+type PriceListItem struct {
+	ProductID    uuid.UUID `json:"product_id" db:"product_id"`
+	PriceInCents int64     `json:"price_in_cents" db:"price_in_cents"`
+}
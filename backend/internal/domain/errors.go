@@ -1,14 +1,82 @@
 package domain
 
-import "errors"
+// ErrorKind classifica os erros de domínio, permitindo que a camada HTTP escolha o
+// status da resposta sem conhecer cada erro individualmente.
+type ErrorKind string
+
+// Categorias de erro de domínio.
+const (
+	KindValidation        ErrorKind = "validation"         // Dados de entrada inválidos
+	KindBadRequest        ErrorKind = "bad_request"        // Requisição malformada (filtros, cursor, patch)
+	KindNotFound          ErrorKind = "not_found"          // Recurso inexistente
+	KindConflict          ErrorKind = "conflict"           // Conflito com o estado atual (duplicidade, bloqueio)
+	KindInsufficientStock ErrorKind = "insufficient_stock" // Saldo insuficiente para a movimentação
+	KindUnauthorized      ErrorKind = "unauthorized"       // Credenciais ausentes ou inválidas
+	KindInternal          ErrorKind = "internal"           // Falha inesperada
+)
+
+// Error é um erro de domínio com categoria e código estável (ex.: "product_not_found"),
+// que os clientes da API podem usar para tratar cada caso. Os erros são comparados por
+// identidade com errors.Is, inclusive quando envolvidos com fmt.Errorf("%w: ...").
+type Error struct {
+	Kind    ErrorKind
+	Code    string
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// NewError cria um erro de domínio. Use-o apenas na declaração de variáveis de erro.
+func NewError(kind ErrorKind, code, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
 
 // Erros comuns do domínio
 var (
-	ErrUserNotFound        = errors.New("usuário não encontrado")
-	ErrEmailAlreadyExists  = errors.New("email já está em uso")
-	ErrInvalidUserData     = errors.New("dados do usuário inválidos")
-	ErrProductNotFound     = errors.New("produto não encontrado")
-	ErrInvalidCredentials  = errors.New("credenciais inválidas")
-	ErrUnauthorized        = errors.New("não autorizado")
-	ErrInternalServerError = errors.New("erro interno do servidor")
+	ErrUserNotFound        = NewError(KindNotFound, "user_not_found", "usuário não encontrado")
+	ErrEmailAlreadyExists  = NewError(KindConflict, "email_in_use", "email já está em uso")
+	ErrInvalidUserData     = NewError(KindValidation, "invalid_user_data", "dados do usuário inválidos")
+	ErrProductNotFound     = NewError(KindNotFound, "product_not_found", "produto não encontrado")
+	ErrInvalidProductData  = NewError(KindValidation, "invalid_product_data", "dados do produto inválidos")
+	ErrSKUAlreadyExists    = NewError(KindConflict, "sku_in_use", "SKU já está em uso")
+	ErrBarcodeInUse        = NewError(KindConflict, "barcode_in_use", "código de barras já está em uso")
+	ErrInvalidBarcode      = NewError(KindValidation, "invalid_barcode", "código de barras inválido")
+	ErrPackagingNotFound   = NewError(KindNotFound, "packaging_not_found", "embalagem não encontrada para o produto")
+	ErrInvalidPackaging    = NewError(KindValidation, "invalid_packaging", "embalagem inválida")
+	ErrInvalidQuantity     = NewError(KindValidation, "invalid_quantity", "quantidade inválida")
+	ErrInsufficientStock   = NewError(KindInsufficientStock, "insufficient_stock", "estoque insuficiente")
+	ErrCategoryNotFound    = NewError(KindNotFound, "category_not_found", "categoria não encontrada")
+	ErrInvalidCategoryData = NewError(KindValidation, "invalid_category_data", "dados da categoria inválidos")
+	ErrCategoryExists      = NewError(KindConflict, "category_exists", "já existe uma categoria com este nome no mesmo nível")
+	ErrCategoryHasChildren = NewError(KindConflict, "category_has_children", "a categoria possui subcategorias")
+	ErrInvalidAttribute    = NewError(KindValidation, "invalid_attribute", "atributo inválido")
+	ErrProductHasVariants  = NewError(KindConflict, "product_has_variants", "o produto possui variantes; informe a variante desejada")
+	ErrInvalidVariants     = NewError(KindValidation, "invalid_variants", "variantes inválidas")
+	ErrLotNotFound         = NewError(KindNotFound, "lot_not_found", "lote não encontrado")
+	ErrInvalidLot          = NewError(KindValidation, "invalid_lot", "lote inválido")
+	ErrSerialNotFound      = NewError(KindNotFound, "serial_not_found", "número de série não encontrado")
+	ErrInvalidSerials      = NewError(KindValidation, "invalid_serials", "números de série inválidos")
+	ErrSerialAlreadyExists = NewError(KindConflict, "serial_in_use", "número de série já cadastrado")
+	ErrInvalidFilter       = NewError(KindBadRequest, "invalid_filter", "filtro ou ordenação inválidos")
+	ErrInvalidCursor       = NewError(KindBadRequest, "invalid_cursor", "cursor de paginação inválido")
+	ErrInvalidRequestBody  = NewError(KindBadRequest, "invalid_request_body", "corpo da requisição inválido")
+	ErrIdempotencyKeyReuse = NewError(KindValidation, "idempotency_key_reused", "a chave de idempotência já foi usada com outra requisição")
+	ErrClientBlocked       = NewError(KindConflict, "client_blocked", "cliente bloqueado")
+	ErrDocumentInUse       = NewError(KindConflict, "document_in_use", "CPF/CNPJ já cadastrado para outro cliente")
+	ErrClientNotFound      = NewError(KindNotFound, "client_not_found", "cliente não encontrado")
+	ErrInvalidClientData   = NewError(KindValidation, "invalid_client_data", "dados do cliente inválidos")
+	ErrInvalidMergePatch   = NewError(KindBadRequest, "invalid_merge_patch", "merge patch inválido")
+	ErrInvalidCredentials  = NewError(KindUnauthorized, "invalid_credentials", "credenciais inválidas")
+	ErrUnauthorized        = NewError(KindUnauthorized, "unauthorized", "não autorizado")
+	ErrInternalServerError = NewError(KindInternal, "internal_error", "erro interno do servidor")
+	ErrImportJobNotFound   = NewError(KindNotFound, "import_job_not_found", "importação não encontrada")
+	ErrInvalidImportFile   = NewError(KindValidation, "invalid_import_file", "arquivo de importação inválido")
+	ErrPriceListNotFound   = NewError(KindNotFound, "price_list_not_found", "tabela de preços não encontrada")
+	ErrInvalidPriceList    = NewError(KindValidation, "invalid_price_list", "tabela de preços inválida")
+	ErrPriceListExists     = NewError(KindConflict, "price_list_exists", "já existe uma tabela de preços com este nome")
+
+	ErrStockEventsLagging     = NewError(KindConflict, "stock_events_lagging", "o assinante não acompanhou o ritmo das movimentações")
+	ErrStockEventsInterrupted = NewError(KindInternal, "stock_events_interrupted", "a escuta das movimentações foi interrompida")
 )
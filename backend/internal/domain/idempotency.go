@@ -0,0 +1,26 @@
+package domain
+
+import "time"
+
+// IdempotencyRecord é a resposta gravada para uma chave de idempotência, repetida
+// quando a mesma requisição é reenviada com a mesma chave.
+type IdempotencyRecord struct {
+	Scope        string
+	Key          string
+	RequestHash  string
+	StatusCode   int
+	ResponseBody []byte
+	CreatedAt    time.Time
+	ExpiresAt    time.Time
+}
+
+// IdempotentReplayError indica que a requisição já foi concluída com a mesma chave
+// (ex.: uma tentativa concorrente que terminou primeiro). A resposta gravada deve ser
+// repetida em vez de executar a operação novamente.
+type IdempotentReplayError struct {
+	Record *IdempotencyRecord
+}
+
+func (e *IdempotentReplayError) Error() string {
+	return "requisição já processada com esta chave de idempotência"
+}
@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ImportStatus é a situação de um job de importação.
+type ImportStatus string
+
+// Situações de um job de importação.
+const (
+	ImportPending   ImportStatus = "pending"   // Aguardando na fila
+	ImportRunning   ImportStatus = "running"   // Em processamento
+	ImportCompleted ImportStatus = "completed" // Todas as linhas processadas (com ou sem erros)
+	ImportFailed    ImportStatus = "failed"    // Interrompido por uma falha do arquivo ou do servidor
+)
+
+// ImportFormat identifica o formato do arquivo importado.
+type ImportFormat string
+
+// Formatos de arquivo aceitos na importação.
+const (
+	ImportCSV  ImportFormat = "csv"
+	ImportXLSX ImportFormat = "xlsx"
+)
+
+// ImportJob é uma importação de produtos em lote, processada em segundo plano. No
+// dry-run, os contadores indicam o que aconteceria, sem nada ser gravado.
+type ImportJob struct {
+	ID        uuid.UUID         `json:"id"`
+	CreatedBy uuid.UUID         `json:"created_by"`
+	Status    ImportStatus      `json:"status"`
+	DryRun    bool              `json:"dry_run"`
+	FileName  string            `json:"file_name"`
+	Format    ImportFormat      `json:"format"`
+	Mapping   map[string]string `json:"mapping"` // Campo do produto -> coluna do arquivo
+	File      []byte            `json:"-"`
+
+	TotalRows     int    `json:"total_rows"`
+	ProcessedRows int    `json:"processed_rows"`
+	CreatedRows   int    `json:"created_rows"`
+	UpdatedRows   int    `json:"updated_rows"`
+	UnchangedRows int    `json:"unchanged_rows"`
+	ErrorRows     int    `json:"error_rows"`
+	Failure       string `json:"failure,omitempty"`
+
+	CreatedAt  time.Time  `json:"created_at"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+// Count contabiliza o resultado de uma linha processada.
+func (j *ImportJob) Count(action ImportAction) {
+	j.ProcessedRows++
+	switch action {
+	case ImportCreate:
+		j.CreatedRows++
+	case ImportUpdate:
+		j.UpdatedRows++
+	case ImportUnchanged:
+		j.UnchangedRows++
+	case ImportError:
+		j.ErrorRows++
+	}
+}
+
+// ImportAction é o resultado de uma linha da importação.
+type ImportAction string
+
+// Resultados possíveis de uma linha.
+const (
+	ImportCreate    ImportAction = "create"    // Produto novo
+	ImportUpdate    ImportAction = "update"    // Produto existente com o mesmo SKU, alterado
+	ImportUnchanged ImportAction = "unchanged" // Produto existente sem alterações
+	ImportError     ImportAction = "error"     // Linha rejeitada; ver Errors
+)
+
+// ImportRow é o resultado de uma linha do arquivo. RowNumber é a linha no arquivo,
+// contando o cabeçalho, para que o usuário a localize na planilha.
+type ImportRow struct {
+	RowNumber int            `json:"row"`
+	SKU       string         `json:"sku"`
+	Action    ImportAction   `json:"action"`
+	ProductID *uuid.UUID     `json:"product_id,omitempty"`
+	Changes   []ImportChange `json:"changes,omitempty"`
+	Errors    []FieldError   `json:"errors,omitempty"`
+}
+
+// ImportChange descreve a alteração de um campo do produto. From é omitido nos
+// produtos novos.
+type ImportChange struct {
+	Field string `json:"field"`
+	From  any    `json:"from,omitempty"`
+	To    any    `json:"to"`
+}
@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Lot representa um lote de um produto recebido no estoque global.
+// Quantity é o saldo ainda disponível; ReceivedQuantity é o total já recebido no lote.
+type Lot struct {
+	ID               uuid.UUID  `json:"id" db:"id"`
+	ProductID        uuid.UUID  `json:"product_id" db:"product_id"`
+	BatchNumber      string     `json:"batch_number" db:"batch_number"`
+	ExpiryDate       *time.Time `json:"expiry_date" db:"expiry_date"`
+	Quantity         int        `json:"quantity" db:"quantity"`
+	ReceivedQuantity int        `json:"received_quantity" db:"received_quantity"`
+	CreatedAt        time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// LotAllocation indica quanto de um lote foi consumido (ou recebido) em uma movimentação.
+type LotAllocation struct {
+	LotID       uuid.UUID  `json:"lot_id"`
+	BatchNumber string     `json:"batch_number"`
+	ExpiryDate  *time.Time `json:"expiry_date"`
+	Quantity    int        `json:"quantity"`
+}
+
+// ExpiringLot é uma linha do relatório de lotes próximos do vencimento.
+type ExpiringLot struct {
+	Lot
+	ProductName     string `json:"product_name"`
+	ProductSKU      string `json:"product_sku"`
+	DaysUntilExpiry int    `json:"days_until_expiry"`
+}
@@ -0,0 +1,12 @@
+package domain
+
+import "github.com/google/uuid"
+
+// Packaging representa um nível de embalagem de um produto, como uma caixa ou um palete.
+// Factor indica quantas unidades base (domain.Produto.Unit) cabem na embalagem.
+type Packaging struct {
+	ProductID uuid.UUID `json:"product_id" db:"product_id"`
+	Code      string    `json:"code" db:"code"`
+	Name      string    `json:"name" db:"name"`
+	Factor    int       `json:"factor" db:"factor"`
+}
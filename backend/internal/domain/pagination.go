@@ -1,11 +1,53 @@
 package domain
 
-// Metadata contém as informações de paginação.
+// Limites de paginação aplicados a todas as listagens.
+const (
+	DefaultPageSize = 10
+	MaxPageSize     = 100
+)
+
+// PageRequest descreve a página solicitada em uma listagem. Com Cursor, a paginação é
+// por keyset (estável e sem custo crescente); sem ele, Page mantém a paginação por
+// deslocamento usada pelas telas existentes.
+type PageRequest struct {
+	Limit        int    // Itens por página, entre 1 e MaxPageSize
+	Page         int    // Página (a partir de 1); ignorada quando Cursor é informado
+	Cursor       string // Cursor opaco devolvido em next_cursor da página anterior
+	IncludeTotal bool   // Calcula total_records e total_pages (exige um COUNT extra)
+}
+
+// Offset retorna o deslocamento da paginação por página; com cursor é sempre zero.
+func (p PageRequest) Offset() int {
+	if p.Cursor != "" || p.Page < 1 {
+		return 0
+	}
+	return (p.Page - 1) * p.Limit
+}
+
+// Metadata contém as informações de paginação. Os totais só são preenchidos quando
+// solicitados; next_cursor fica vazio na última página.
 type Metadata struct {
-	TotalRecords int `json:"total_records"`
-	CurrentPage  int `json:"current_page"`
-	PageSize     int `json:"page_size"`
-	TotalPages   int `json:"total_pages"`
+	TotalRecords *int   `json:"total_records,omitempty"`
+	CurrentPage  int    `json:"current_page,omitempty"`
+	PageSize     int    `json:"page_size"`
+	TotalPages   *int   `json:"total_pages,omitempty"`
+	NextCursor   string `json:"next_cursor,omitempty"`
+
+	// AppliedFilters ecoa os filtros e a ordenação usados na consulta.
+	AppliedFilters map[string]any `json:"applied_filters,omitempty"`
+}
+
+// SortField é um critério de ordenação de uma listagem, no formato "campo:asc" ou "campo:desc".
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+func (f SortField) String() string {
+	if f.Desc {
+		return f.Field + ":desc"
+	}
+	return f.Field + ":asc"
 }
 
 // PaginatedResponse é a estrutura genérica para respostas paginadas.
@@ -10,13 +10,151 @@ import (
 // As tags `json` controlam como os campos são nomeados quando convertidos para JSON.
 // As tags `db` serão usadas futuramente pela camada do banco de dados para mapear colunas.
 type Produto struct {
-	ID           uuid.UUID `json:"id" db:"id"`
-	Name         string    `json:"name" db:"name"`
-	Description  string    `json:"description" db:"description"`
-	PriceInCents int64     `json:"price_in_cents" db:"price_in_cents"`
-	Quantity     int       `json:"quantity" db:"quantity"`
-	CreatedAt    time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+	ID           uuid.UUID      `json:"id" db:"id"`
+	SKU          string         `json:"sku" db:"sku"`
+	CategoryID   *uuid.UUID     `json:"category_id" db:"category_id"`
+	Name         string         `json:"name" db:"name"`
+	Description  string         `json:"description" db:"description"`
+	PriceInCents int64          `json:"price_in_cents" db:"price_in_cents"`
+	Currency     Currency       `json:"currency" db:"currency"` // Moeda do preço e do custo; variantes seguem a do pai
+	Quantity     int            `json:"quantity" db:"quantity"`
+	Unit         UnitOfMeasure  `json:"unit" db:"unit"`
+	Barcodes     []string       `json:"barcodes" db:"-"`
+	Attributes   map[string]any `json:"attributes" db:"attributes"`
+	Tags         []string       `json:"tags" db:"tags"`
+	TrackLots    bool           `json:"track_lots" db:"track_lots"`
+	Serialized   bool           `json:"serialized" db:"serialized"`
+
+	// Custeio: o método é escolhido no cadastro; o custo do estoque global e o custo
+	// médio unitário são mantidos pelas movimentações e ignorados na entrada.
+	CostingMethod      CostingMethod `json:"costing_method" db:"costing_method"`
+	StockValueInCents  int64         `json:"stock_value_in_cents" db:"stock_value_in_cents"`
+	AverageCostInCents int64         `json:"average_cost_in_cents" db:"average_cost_in_cents"`
+
+	// Variantes: ParentID é preenchido nas variantes; VariantAxes apenas no produto pai.
+	ParentID             *uuid.UUID        `json:"parent_id,omitempty" db:"parent_id"`
+	VariantAxes          []VariantAxis     `json:"variant_axes,omitempty" db:"variant_axes"`
+	VariantOptions       map[string]string `json:"variant_options,omitempty" db:"variant_options"`
+	PriceOverrideInCents *int64            `json:"price_override_in_cents,omitempty" db:"price_override_in_cents"`
+	Variants             []Produto         `json:"variants,omitempty" db:"-"`
+
+	// Preenchido apenas nas listagens com busca textual.
+	Search *SearchMatch `json:"search,omitempty" db:"-"`
+
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// VariantAxis é um eixo de variação de um produto pai, como tamanho ou cor.
+type VariantAxis struct {
+	Name   string   `json:"name"`
+	Values []string `json:"values"`
+}
+
+// HasVariants informa se o produto é um pai de variantes. Nesse caso o estoque
+// exibido é a soma das variantes e as movimentações devem ocorrer nelas.
+func (p *Produto) HasVariants() bool {
+	return len(p.VariantAxes) > 0
+}
+
+// SearchMatch descreve por que um produto apareceu na busca textual. Os trechos
+// destacados são HTML: o texto do produto vem escapado e a única marcação é o
+// <mark>...</mark> em volta dos termos encontrados.
+type SearchMatch struct {
+	Rank        float64 `json:"rank"`
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+}
+
+// ProductFilter reúne os critérios de filtragem da listagem de produtos.
+type ProductFilter struct {
+	Search     string            // Busca textual em SKU, nome e descrição, tolerante a acentos e erros de digitação
+	CategoryID *uuid.UUID        // Categoria, incluindo todas as suas descendentes
+	Attributes map[string]string // Atributos personalizados (attr.<chave>=<valor>)
+	Tags       []string          // Tags que o produto deve possuir (todas)
+
+	// Faixas (inclusivas) sobre o preço efetivo e a quantidade exibida do produto.
+	MinPriceInCents *int64
+	MaxPriceInCents *int64
+	MinQuantity     *int
+	MaxQuantity     *int
+	UpdatedSince    *time.Time // Alterados a partir deste instante
+
+	IncludeVariants bool // Lista também as variantes, além dos produtos pai e simples
+
+	// Sort define a ordenação; vazia ordena por relevância (com Search) ou pelos mais recentes.
+	Sort []SortField
+}
+
+// ProductSortFields lista os campos aceitos na ordenação de produtos.
+var ProductSortFields = []string{"name", "price", "quantity", "updated_at", "created_at"}
+
+// Applied descreve os filtros efetivamente aplicados, com os mesmos nomes dos
+// parâmetros de consulta, para devolução nos metadados da listagem.
+func (f ProductFilter) Applied() map[string]any {
+	applied := make(map[string]any)
+	if f.Search != "" {
+		applied["search"] = f.Search
+	}
+	if f.CategoryID != nil {
+		applied["category"] = *f.CategoryID
+	}
+	for key, value := range f.Attributes {
+		applied["attr."+key] = value
+	}
+	if len(f.Tags) > 0 {
+		applied["tag"] = f.Tags
+	}
+	if f.MinPriceInCents != nil {
+		applied["min_price"] = *f.MinPriceInCents
+	}
+	if f.MaxPriceInCents != nil {
+		applied["max_price"] = *f.MaxPriceInCents
+	}
+	if f.MinQuantity != nil {
+		applied["min_qty"] = *f.MinQuantity
+	}
+	if f.MaxQuantity != nil {
+		applied["max_qty"] = *f.MaxQuantity
+	}
+	if f.UpdatedSince != nil {
+		applied["updated_since"] = *f.UpdatedSince
+	}
+	if f.IncludeVariants {
+		applied["include_variants"] = true
+	}
+	if len(f.Sort) > 0 {
+		sort := make([]string, len(f.Sort))
+		for i, field := range f.Sort {
+			sort[i] = field.String()
+		}
+		applied["sort"] = sort
+	}
+	return applied
+}
+
+// UnitOfMeasure identifica a unidade em que a quantidade de um produto é contada.
+type UnitOfMeasure string
+
+// Unidades de medida suportadas.
+const (
+	UnitPiece      UnitOfMeasure = "UN"  // Unidade
+	UnitBox        UnitOfMeasure = "CX"  // Caixa
+	UnitPack       UnitOfMeasure = "PCT" // Pacote
+	UnitKilogram   UnitOfMeasure = "KG"  // Quilograma
+	UnitGram       UnitOfMeasure = "G"   // Grama
+	UnitLiter      UnitOfMeasure = "L"   // Litro
+	UnitMilliliter UnitOfMeasure = "ML"  // Mililitro
+	UnitMeter      UnitOfMeasure = "M"   // Metro
+)
+
+// Valid informa se a unidade de medida é uma das suportadas.
+func (u UnitOfMeasure) Valid() bool {
+	switch u {
+	case UnitPiece, UnitBox, UnitPack, UnitKilogram, UnitGram, UnitLiter, UnitMilliliter, UnitMeter:
+		return true
+	}
+	return false
 }
 
 // Explicação das Escolhas:
@@ -26,5 +164,14 @@ type Produto struct {
 // - PriceInCents (int64): NUNCA use float para dinheiro devido a problemas de arredondamento. A melhor prática
 //   é armazenar o valor na menor unidade monetária (centavos) como um número inteiro.
 //
+// - SKU / Barcodes: o SKU é o código interno e único do produto; os códigos de barras (EAN-13, EAN-8, UPC-A)
+//   ficam em uma tabela própria para que um mesmo produto possa ter vários e a busca por leitor seja indexada.
+//
+// - Attributes / Tags: atributos personalizados ficam em uma coluna JSONB validada contra as definições
+//   da categoria (domain.AttributeDefinition); as tags são livres. Ambos usam índices GIN para filtragem.
+//
+// - Variantes: cada combinação (ex.: M/Azul) é um Produto com ParentID, SKU e estoque próprios. O preço da
+//   variante é o do pai, a menos que PriceOverrideInCents esteja definido.
+//
 // - CreatedAt / UpdatedAt (time.Time): Campos essenciais para auditoria. Sabemos quando um registro foi
 //   criado e modificado pela última vez.
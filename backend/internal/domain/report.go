@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ValuationBasis indica como o estoque é valorizado.
+type ValuationBasis string
+
+// Bases de valorização do estoque.
+const (
+	// ValuationByPrice multiplica as quantidades pelo preço de venda efetivo atual. Como
+	// os preços não têm histórico, vale apenas para o instante atual.
+	ValuationByPrice ValuationBasis = "price"
+	// ValuationByCost usa o custo registrado nas movimentações (médio ou PEPS),
+	// reconstituído para qualquer instante.
+	ValuationByCost ValuationBasis = "cost"
+)
+
+// ValuationLine é a quantidade e o valor, em uma moeda, de uma categoria de produtos com
+// um detentor: o estoque global (ClientID nulo) ou um cliente. Produtos sem categoria têm
+// CategoryID nulo.
+type ValuationLine struct {
+	ClientID     *uuid.UUID
+	ClientName   string
+	CategoryID   *uuid.UUID
+	CategoryName string
+	Currency     Currency
+	Quantity     int64
+	ValueInCents int64
+}
+
+// CategoryValuation é a quantidade e o valor dos produtos de uma categoria precificados
+// em uma moeda; uma categoria com preços em várias moedas aparece uma vez por moeda.
+type CategoryValuation struct {
+	CategoryID   *uuid.UUID `json:"category_id"`
+	CategoryName string     `json:"category_name"`
+	Currency     Currency   `json:"currency"`
+	Quantity     int64      `json:"quantity"`
+	ValueInCents int64      `json:"value_in_cents"`
+}
+
+// Valuation é a quantidade e o valor de um estoque, no total e por categoria. Valores em
+// moedas diferentes não são somados: Values traz um total por moeda.
+type Valuation struct {
+	Quantity   int64               `json:"quantity"`
+	Values     []Amount            `json:"values"`
+	Categories []CategoryValuation `json:"categories"`
+}
+
+// ClientValuation é a valorização do estoque em poder de um cliente.
+type ClientValuation struct {
+	ClientID   uuid.UUID `json:"client_id"`
+	ClientName string    `json:"client_name"`
+	Valuation
+}
+
+// ValuationReport é a valorização do estoque em um instante: pelo preço efetivo atual ou,
+// em qualquer data, pelo custo daquele momento, reconstituído pelas movimentações.
+type ValuationReport struct {
+	AsOf         time.Time         `json:"as_of"`
+	Basis        ValuationBasis    `json:"basis"`
+	Warehouse    Valuation         `json:"warehouse"`     // Estoque global
+	Clients      []ClientValuation `json:"clients"`       // Apenas clientes com saldo
+	ClientsTotal Valuation         `json:"clients_total"` // Soma dos clientes
+	Total        Valuation         `json:"total"`         // Estoque global mais clientes
+}
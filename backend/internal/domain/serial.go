@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SerialStatus indica onde se encontra um item serializado.
+type SerialStatus string
+
+// Situações possíveis de um número de série.
+const (
+	SerialInStock  SerialStatus = "in_stock"  // No estoque global
+	SerialAtClient SerialStatus = "at_client" // Entregue a um cliente
+	SerialReturned SerialStatus = "returned"  // Devolvido por um cliente, de volta ao estoque global
+	SerialScrapped SerialStatus = "scrapped"  // Baixado (perda, avaria, descarte)
+)
+
+// Available informa se o item está no estoque global e pode ser transferido.
+func (s SerialStatus) Available() bool {
+	return s == SerialInStock || s == SerialReturned
+}
+
+// SerialNumber representa uma unidade rastreada individualmente de um produto serializado.
+type SerialNumber struct {
+	ID        uuid.UUID    `json:"id" db:"id"`
+	ProductID uuid.UUID    `json:"product_id" db:"product_id"`
+	Serial    string       `json:"serial" db:"serial_number"`
+	Status    SerialStatus `json:"status" db:"status"`
+	ClientID  *uuid.UUID   `json:"client_id" db:"client_id"`
+	CreatedAt time.Time    `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at" db:"updated_at"`
+}
+
+// SerialEvent é uma mudança de situação no histórico de um número de série.
+type SerialEvent struct {
+	Status       SerialStatus  `json:"status"`
+	ClientID     *uuid.UUID    `json:"client_id"`
+	ClientName   *string       `json:"client_name"`
+	MovementID   *uuid.UUID    `json:"movement_id"`
+	MovementType *MovementType `json:"movement_type"`
+	CreatedAt    time.Time     `json:"created_at"`
+}
+
+// SerialHistory é a resposta de GET /serials/{serial}: o item e todo o seu histórico de localização.
+type SerialHistory struct {
+	SerialNumber
+	ProductName string        `json:"product_name"`
+	History     []SerialEvent `json:"history"`
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MovementType identifica a origem de uma movimentação de estoque.
+type MovementType string
+
+// Tipos de movimentação suportados.
+const (
+	MovementReceipt    MovementType = "receipt"    // Entrada de mercadoria no estoque global
+	MovementAdjustment MovementType = "adjustment" // Correção manual (inventário, avaria, etc.)
+	MovementTransfer   MovementType = "transfer"   // Saída do estoque global para um cliente
+	MovementReturn     MovementType = "return"     // Devolução de um cliente para o estoque global
+)
+
+// StockMovement registra uma alteração no estoque global de um produto.
+// Quantity está sempre na unidade base: positiva para entradas e negativa para saídas.
+// Os campos Packaging* guardam a quantidade como foi informada na requisição.
+type StockMovement struct {
+	ID                uuid.UUID       `json:"id" db:"id"`
+	ProductID         uuid.UUID       `json:"product_id" db:"product_id"`
+	ClientID          *uuid.UUID      `json:"client_id,omitempty" db:"client_id"`
+	Type              MovementType    `json:"type" db:"type"`
+	Packaging         string          `json:"packaging" db:"packaging_code"`
+	PackagingQuantity int             `json:"packaging_quantity" db:"packaging_quantity"`
+	PackagingFactor   int             `json:"packaging_factor" db:"packaging_factor"`
+	Quantity          int             `json:"quantity" db:"quantity"`
+	Reason            string          `json:"reason,omitempty" db:"reason"`
+	CostInCents       *int64          `json:"cost_in_cents,omitempty" db:"cost_in_cents"`             // Custo total, com o sinal da quantidade
+	UnitPriceInCents  *int64          `json:"unit_price_in_cents,omitempty" db:"unit_price_in_cents"` // Preço de venda, em transferências e devoluções
+	Currency          Currency        `json:"currency,omitempty" db:"currency"`                       // Moeda do preço (a da tabela de preços do cliente, se houver)
+	CostCurrency      Currency        `json:"cost_currency,omitempty" db:"cost_currency"`             // Moeda do custo (a do produto)
+	Lots              []LotAllocation `json:"lots,omitempty" db:"-"`
+	Serials           []string        `json:"serials,omitempty" db:"-"`
+	CreatedAt         time.Time       `json:"created_at" db:"created_at"`
+}
+
+// StockEvent notifica uma movimentação de estoque confirmada aos assinantes do fluxo
+// de eventos. Traz apenas o essencial da movimentação, sem embalagem, lotes e séries.
+type StockEvent struct {
+	MovementID uuid.UUID    `json:"id"`
+	ProductID  uuid.UUID    `json:"product_id"`
+	ClientID   *uuid.UUID   `json:"client_id"`
+	Type       MovementType `json:"type"`
+	Quantity   int          `json:"quantity"`
+	CreatedAt  time.Time    `json:"created_at"`
+}
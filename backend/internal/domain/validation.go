@@ -0,0 +1,42 @@
+package domain
+
+import "strings"
+
+// Códigos de erro de validação por campo, estáveis para uso pelo frontend.
+const (
+	CodeRequired     = "required"      // Campo obrigatório ausente ou vazio
+	CodeInvalid      = "invalid"       // Valor fora do formato ou do conjunto aceito
+	CodeOutOfRange   = "out_of_range"  // Número negativo, zero ou acima do limite
+	CodeTooLong      = "too_long"      // Texto ou lista maior que o permitido
+	CodeDuplicate    = "duplicate"     // Valor repetido dentro da própria requisição
+	CodeMismatch     = "mismatch"      // Valor não confere com outro campo
+	CodeUnknownField = "unknown_field" // Campo não reconhecido no corpo da requisição
+	CodeInvalidType  = "invalid_type"  // Tipo JSON incompatível com o campo
+)
+
+// FieldError descreve o problema de um campo do corpo da requisição. Field segue o
+// caminho JSON do campo, ex.: "name" ou "addresses[1].cep".
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ValidationError reúne os erros de campo de uma requisição. Err é o erro de domínio
+// correspondente (ex.: ErrInvalidProductData), preservado para errors.Is.
+type ValidationError struct {
+	Err    error
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		messages[i] = f.Field + ": " + f.Message
+	}
+	return e.Err.Error() + ": " + strings.Join(messages, "; ")
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,182 @@
+package graph
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"controle-de-estoque/backend/internal/domain"
+
+	"github.com/graph-gophers/graphql-go/errors"
+	"github.com/graph-gophers/graphql-go/types"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+// checkComplexity estima o custo da operação solicitada e a rejeita acima de
+// MaxComplexity. Cada campo custa 1; os campos de uma lista custam tantas vezes quanto
+// o número de itens esperado, que é o argumento first (com o mesmo limite da
+// paginação) ou domain.DefaultPageSize. Nas conexões (tipos *Connection), o first do
+// campo da conexão já multiplica os nós, que não contam de novo como lista.
+//
+// Uma consulta que não pode ser analisada é rejeitada, já que o seu custo não seria
+// estimado, mesmo que o executor do esquema a aceitasse. Operações inexistentes ficam
+// para a validação do esquema, que não as executa.
+func checkComplexity(schema *types.Schema, req Request) *errors.QueryError {
+	doc, parseErr := parser.ParseQuery(&ast.Source{Input: req.Query})
+	if parseErr != nil {
+		queryErr := &errors.QueryError{
+			Err:        parseErr,
+			Message:    fmt.Sprintf("consulta inválida: %v", parseErr),
+			Extensions: map[string]any{"code": codeInvalidQuery},
+		}
+		if gqlErr, ok := parseErr.(*gqlerror.Error); ok {
+			queryErr.Message = "consulta inválida: " + gqlErr.Message
+			for _, l := range gqlErr.Locations {
+				queryErr.Locations = append(queryErr.Locations, errors.Location{Line: l.Line, Column: l.Column})
+			}
+		}
+		return queryErr
+	}
+	op := doc.Operations.ForName(req.OperationName)
+	if op == nil {
+		return nil
+	}
+	root, ok := schema.EntryPoints[string(op.Operation)]
+	if !ok {
+		return nil
+	}
+
+	c := costCalculator{schema: schema, doc: doc, op: op, variables: req.Variables, visiting: make(map[string]bool)}
+	cost := c.selectionSet(op.SelectionSet, root)
+	if cost <= MaxComplexity {
+		return nil
+	}
+	return &errors.QueryError{
+		Message:    fmt.Sprintf("consulta muito complexa: custo estimado %d, máximo %d", cost, MaxComplexity),
+		Extensions: map[string]any{"code": codeQueryTooComplex, "complexity": cost, "maxComplexity": MaxComplexity},
+	}
+}
+
+type costCalculator struct {
+	schema    *types.Schema
+	doc       *ast.QueryDocument
+	op        *ast.OperationDefinition
+	variables map[string]any
+	visiting  map[string]bool // Fragmentos em expansão, para ignorar ciclos
+}
+
+func (c *costCalculator) selectionSet(set ast.SelectionSet, parent types.NamedType) int {
+	total := 0
+	for _, selection := range set {
+		switch s := selection.(type) {
+		case *ast.Field:
+			total += c.field(s, parent)
+		case *ast.InlineFragment:
+			t := parent
+			if s.TypeCondition != "" {
+				t = c.schema.Types[s.TypeCondition]
+			}
+			total += c.selectionSet(s.SelectionSet, t)
+		case *ast.FragmentSpread:
+			fragment := c.doc.Fragments.ForName(s.Name)
+			if fragment == nil || c.visiting[s.Name] {
+				continue
+			}
+			c.visiting[s.Name] = true
+			total += c.selectionSet(fragment.SelectionSet, c.schema.Types[fragment.TypeCondition])
+			delete(c.visiting, s.Name)
+		}
+	}
+	return total
+}
+
+func (c *costCalculator) field(f *ast.Field, parent types.NamedType) int {
+	if strings.HasPrefix(f.Name, "__") {
+		return 0 // Introspecção e __typename não acessam o banco
+	}
+	def := fieldDefinition(parent, f.Name)
+	if def == nil || len(f.SelectionSet) == 0 {
+		return 1
+	}
+
+	fieldType, isList := unwrap(def.Type)
+	children := c.selectionSet(f.SelectionSet, fieldType)
+	if arg := def.Arguments.Get("first"); arg != nil {
+		children *= c.first(f, arg)
+	} else if isList && !strings.HasSuffix(parent.TypeName(), "Connection") {
+		children *= domain.DefaultPageSize
+	}
+	return 1 + children
+}
+
+// first resolve o número de itens pedido no argumento first, que pode ser um literal,
+// uma variável ou o valor padrão declarado no esquema.
+func (c *costCalculator) first(f *ast.Field, def *types.InputValueDefinition) int {
+	n := -1
+	if arg := f.Arguments.ForName("first"); arg != nil && arg.Value != nil {
+		switch arg.Value.Kind {
+		case ast.IntValue:
+			n, _ = strconv.Atoi(arg.Value.Raw)
+		case ast.Variable:
+			n = c.variable(arg.Value.Raw)
+		}
+	} else if def.Default != nil {
+		if v, ok := def.Default.Deserialize(nil).(int32); ok {
+			n = int(v)
+		}
+	}
+	if n < 1 {
+		return domain.DefaultPageSize
+	}
+	return min(n, domain.MaxPageSize)
+}
+
+func (c *costCalculator) variable(name string) int {
+	value, ok := c.variables[name]
+	if !ok {
+		if def := c.op.VariableDefinitions.ForName(name); def != nil && def.DefaultValue != nil {
+			n, _ := strconv.Atoi(def.DefaultValue.Raw)
+			return n
+		}
+		return -1
+	}
+	switch v := value.(type) {
+	case float64:
+		return int(v)
+	case int:
+		return v
+	case int32:
+		return int(v)
+	}
+	return -1
+}
+
+// fieldDefinition busca a definição do campo no tipo pai, se ele tiver campos.
+func fieldDefinition(parent types.NamedType, name string) *types.FieldDefinition {
+	switch t := parent.(type) {
+	case *types.ObjectTypeDefinition:
+		return t.Fields.Get(name)
+	case *types.InterfaceTypeDefinition:
+		return t.Fields.Get(name)
+	}
+	return nil
+}
+
+// unwrap remove os modificadores de não nulo e de lista, informando se havia lista.
+func unwrap(t types.Type) (named types.NamedType, isList bool) {
+	for {
+		switch u := t.(type) {
+		case *types.NonNull:
+			t = u.OfType
+		case *types.List:
+			isList = true
+			t = u.OfType
+		case types.NamedType:
+			return u, isList
+		default:
+			return nil, isList
+		}
+	}
+}
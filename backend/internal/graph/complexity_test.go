@@ -0,0 +1,52 @@
+package graph
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCheckComplexity(t *testing.T) {
+	schema := NewSchema(nil, nil, nil).schema.ASTSchema()
+	tests := []struct {
+		name     string
+		req      Request
+		wantCode string
+	}{
+		{"consulta simples", Request{Query: `{ products(first: 5) { nodes { id name } } }`}, ""},
+		{"first por variável", Request{
+			Query:     `query($n: Int) { products(first: $n) { nodes { holdings { client { stock { quantity } } } } } }`,
+			Variables: map[string]any{"n": float64(100)},
+		}, codeQueryTooComplex},
+		{"listas aninhadas", Request{Query: `{ products(first: 100) { nodes { holdings { client { stock { quantity } } } } } }`}, codeQueryTooComplex},
+		{"sintaxe inválida", Request{Query: `{ products(first: 100) { nodes { id `}, codeInvalidQuery},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkComplexity(schema, tt.req)
+			if tt.wantCode == "" {
+				if err != nil {
+					t.Fatalf("erro inesperado: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("consulta aceita, esperado %s", tt.wantCode)
+			}
+			if code := err.Extensions["code"]; code != tt.wantCode {
+				t.Errorf("código %v, esperado %s (%s)", code, tt.wantCode, err.Message)
+			}
+		})
+	}
+}
+
+// TestExecRejectsUnparsedQuery garante que uma consulta que a análise de custo não
+// consegue ler não chega aos resolvers, que acessariam o banco.
+func TestExecRejectsUnparsedQuery(t *testing.T) {
+	resp := NewSchema(nil, nil, nil).Exec(context.Background(), Request{Query: `{ products(first: 100) { nodes { id } `})
+	if len(resp.Errors) != 1 || resp.Errors[0].Extensions["code"] != codeInvalidQuery {
+		t.Fatalf("erros = %v, esperado um erro %s", resp.Errors, codeInvalidQuery)
+	}
+	if len(resp.Errors[0].Locations) == 0 {
+		t.Errorf("erro sem localização: %+v", resp.Errors[0])
+	}
+}
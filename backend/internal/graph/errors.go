@@ -0,0 +1,84 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"controle-de-estoque/backend/internal/domain"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	"github.com/graph-gophers/graphql-go"
+)
+
+// Códigos dos erros detectados pela própria camada GraphQL.
+const (
+	codeInvalidID       = "invalid_id"
+	codeInvalidQuery    = "invalid_query"
+	codeQueryTooComplex = "query_too_complex"
+	codeInternal        = "internal_error"
+)
+
+// resolverError é um erro devolvido ao cliente com o mesmo código estável das
+// respostas de problema da API REST em extensions.code e, nos erros de validação,
+// os erros de campo em extensions.errors.
+type resolverError struct {
+	message string
+	code    string
+	fields  []domain.FieldError
+}
+
+func (e *resolverError) Error() string {
+	return e.message
+}
+
+func (e *resolverError) Extensions() map[string]any {
+	extensions := map[string]any{"code": e.code}
+	if len(e.fields) > 0 {
+		extensions["errors"] = e.fields
+	}
+	return extensions
+}
+
+// resolveError traduz um erro dos serviços para o erro devolvido ao cliente. Erros
+// fora do domínio são registrados no log e mascarados, sem expor a mensagem original.
+func resolveError(ctx context.Context, err error) error {
+	var validationErr *domain.ValidationError
+	var domainErr *domain.Error
+	switch {
+	case errors.As(err, &validationErr):
+		e := &resolverError{message: err.Error(), code: "validation_failed", fields: validationErr.Fields}
+		if errors.As(validationErr.Err, &domainErr) {
+			e.code = domainErr.Code
+		}
+		return e
+	case errors.As(err, &domainErr):
+		return &resolverError{message: err.Error(), code: domainErr.Code}
+	default:
+		log.Printf("Erro interno [%s] GraphQL: %v", middleware.GetReqID(ctx), err)
+		return &resolverError{message: "Erro interno no servidor", code: codeInternal}
+	}
+}
+
+// parseID converte um ID recebido nos argumentos em UUID.
+func parseID(id graphql.ID) (uuid.UUID, error) {
+	parsed, err := uuid.Parse(string(id))
+	if err != nil {
+		return uuid.Nil, &resolverError{message: fmt.Sprintf("ID inválido: %q", string(id)), code: codeInvalidID}
+	}
+	return parsed, nil
+}
+
+// parseOptionalID converte um ID opcional em UUID, preservando a ausência.
+func parseOptionalID(id *graphql.ID) (*uuid.UUID, error) {
+	if id == nil {
+		return nil, nil
+	}
+	parsed, err := parseID(*id)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
@@ -0,0 +1,96 @@
+package graph
+
+import (
+	"context"
+
+	"controle-de-estoque/backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/graph-gophers/dataloader/v7"
+)
+
+// loaders agrupa as buscas feitas pelos resolvers de uma mesma requisição em uma
+// consulta por tipo, evitando o problema N+1 (ex.: a categoria de cada produto da página).
+type loaders struct {
+	products         *dataloader.Loader[uuid.UUID, *domain.Produto]
+	clients          *dataloader.Loader[uuid.UUID, *domain.Client] // Com endereços e contatos
+	categories       *dataloader.Loader[uuid.UUID, *domain.Category]
+	stockByClient    *dataloader.Loader[uuid.UUID, []domain.ClientStock]
+	holdersByProduct *dataloader.Loader[uuid.UUID, []domain.ClientStock]
+}
+
+type loadersKey struct{}
+
+// withLoaders devolve o contexto com carregadores novos. O cache dos carregadores
+// vive apenas durante a requisição, então não há dados desatualizados entre requisições.
+func withLoaders(ctx context.Context, svc services) context.Context {
+	l := &loaders{
+		products: dataloader.NewBatchedLoader(byID(svc.products.GetProductsByIDs,
+			func(p domain.Produto) uuid.UUID { return p.ID }, domain.ErrProductNotFound)),
+		clients: dataloader.NewBatchedLoader(byID(svc.clients.GetByIDs,
+			func(c domain.Client) uuid.UUID { return c.ID }, domain.ErrClientNotFound)),
+		categories: dataloader.NewBatchedLoader(byID(svc.categories.GetByIDs,
+			func(c domain.Category) uuid.UUID { return c.ID }, domain.ErrCategoryNotFound)),
+		stockByClient: dataloader.NewBatchedLoader(groupBy(svc.clients.ListStockByClientIDs,
+			func(s domain.ClientStock) uuid.UUID { return s.ClientID })),
+		holdersByProduct: dataloader.NewBatchedLoader(groupBy(svc.clients.ListHoldersByProductIDs,
+			func(s domain.ClientStock) uuid.UUID { return s.ProductID })),
+	}
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+// byID monta a função de lote de um carregador a partir de uma busca por vários IDs.
+// IDs não devolvidos pela busca resultam em notFound.
+func byID[V any](fetch func(context.Context, []uuid.UUID) ([]V, error), id func(V) uuid.UUID, notFound error) dataloader.BatchFunc[uuid.UUID, *V] {
+	return func(ctx context.Context, keys []uuid.UUID) []*dataloader.Result[*V] {
+		results := make([]*dataloader.Result[*V], len(keys))
+		items, err := fetch(ctx, keys)
+		if err != nil {
+			for i := range results {
+				results[i] = &dataloader.Result[*V]{Error: err}
+			}
+			return results
+		}
+
+		index := make(map[uuid.UUID]*V, len(items))
+		for i := range items {
+			index[id(items[i])] = &items[i]
+		}
+		for i, key := range keys {
+			if item, ok := index[key]; ok {
+				results[i] = &dataloader.Result[*V]{Data: item}
+			} else {
+				results[i] = &dataloader.Result[*V]{Error: notFound}
+			}
+		}
+		return results
+	}
+}
+
+// groupBy monta a função de lote de um carregador de saldos, agrupando o resultado
+// da busca pela chave. Chaves sem saldos resultam em uma lista vazia.
+func groupBy(fetch func(context.Context, []uuid.UUID) ([]domain.ClientStock, error), key func(domain.ClientStock) uuid.UUID) dataloader.BatchFunc[uuid.UUID, []domain.ClientStock] {
+	return func(ctx context.Context, keys []uuid.UUID) []*dataloader.Result[[]domain.ClientStock] {
+		results := make([]*dataloader.Result[[]domain.ClientStock], len(keys))
+		stocks, err := fetch(ctx, keys)
+		if err != nil {
+			for i := range results {
+				results[i] = &dataloader.Result[[]domain.ClientStock]{Error: err}
+			}
+			return results
+		}
+
+		groups := make(map[uuid.UUID][]domain.ClientStock, len(keys))
+		for _, s := range stocks {
+			groups[key(s)] = append(groups[key(s)], s)
+		}
+		for i, k := range keys {
+			results[i] = &dataloader.Result[[]domain.ClientStock]{Data: groups[k]}
+		}
+		return results
+	}
+}
@@ -0,0 +1,427 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"controle-de-estoque/backend/internal/domain"
+	"controle-de-estoque/backend/internal/service"
+
+	"github.com/graph-gophers/graphql-go"
+)
+
+// resolver é a raiz das consultas e mutações.
+type resolver struct {
+	svc services
+}
+
+// pageInfo resolve o tipo PageInfo a partir dos metadados da listagem.
+type pageInfo struct {
+	m domain.Metadata
+}
+
+func (p *pageInfo) TotalCount() *int32 {
+	if p.m.TotalRecords == nil {
+		return nil
+	}
+	total := int32(*p.m.TotalRecords)
+	return &total
+}
+
+func (p *pageInfo) NextCursor() *string {
+	if p.m.NextCursor == "" {
+		return nil
+	}
+	return &p.m.NextCursor
+}
+
+type productConnection struct {
+	nodes    []*productResolver
+	pageInfo *pageInfo
+}
+
+func (c *productConnection) Nodes() []*productResolver { return c.nodes }
+func (c *productConnection) PageInfo() *pageInfo       { return c.pageInfo }
+
+type clientConnection struct {
+	nodes    []*clientResolver
+	pageInfo *pageInfo
+}
+
+func (c *clientConnection) Nodes() []*clientResolver { return c.nodes }
+func (c *clientConnection) PageInfo() *pageInfo      { return c.pageInfo }
+
+// pageRequest converte os argumentos first e after em uma página por cursor, com as
+// mesmas regras da API REST: o limite é restrito a domain.MaxPageSize e o total só é
+// calculado na primeira página.
+func pageRequest(first int32, after *string) domain.PageRequest {
+	page := domain.PageRequest{Limit: domain.DefaultPageSize, Page: 1}
+	if first >= 1 {
+		page.Limit = min(int(first), domain.MaxPageSize)
+	}
+	if after != nil {
+		page.Cursor = *after
+	}
+	page.IncludeTotal = page.Cursor == ""
+	return page
+}
+
+func (r *resolver) Products(ctx context.Context, args struct {
+	Search     *string
+	CategoryID *graphql.ID
+	First      int32
+	After      *string
+}) (*productConnection, error) {
+	var filter domain.ProductFilter
+	if args.Search != nil {
+		filter.Search = strings.TrimSpace(*args.Search)
+	}
+	categoryID, err := parseOptionalID(args.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+	filter.CategoryID = categoryID
+
+	result, err := r.svc.products.ListProducts(ctx, filter, pageRequest(args.First, args.After))
+	if err != nil {
+		return nil, resolveError(ctx, err)
+	}
+	products, _ := result.Data.([]domain.Produto)
+	nodes := make([]*productResolver, len(products))
+	for i := range products {
+		nodes[i] = &productResolver{p: products[i]}
+	}
+	return &productConnection{nodes: nodes, pageInfo: &pageInfo{m: result.Metadata}}, nil
+}
+
+func (r *resolver) Product(ctx context.Context, args struct{ ID graphql.ID }) (*productResolver, error) {
+	productID, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	product, err := r.svc.products.GetProductByID(ctx, productID)
+	if errors.Is(err, domain.ErrProductNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, resolveError(ctx, err)
+	}
+	return &productResolver{p: product}, nil
+}
+
+func (r *resolver) Clients(ctx context.Context, args struct {
+	Search *string
+	Status *string
+	First  int32
+	After  *string
+}) (*clientConnection, error) {
+	var filter domain.ClientFilter
+	if args.Search != nil {
+		filter.Search = *args.Search
+	}
+	if args.Status != nil {
+		filter.Status = domain.ClientStatus(*args.Status)
+	}
+
+	result, err := r.svc.clients.List(ctx, filter, pageRequest(args.First, args.After))
+	if err != nil {
+		return nil, resolveError(ctx, err)
+	}
+	clients, _ := result.Data.([]domain.Client)
+	nodes := make([]*clientResolver, len(clients))
+	for i := range clients {
+		nodes[i] = &clientResolver{c: clients[i]}
+	}
+	return &clientConnection{nodes: nodes, pageInfo: &pageInfo{m: result.Metadata}}, nil
+}
+
+func (r *resolver) Client(ctx context.Context, args struct{ ID graphql.ID }) (*clientResolver, error) {
+	clientID, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	client, err := r.svc.clients.GetByID(ctx, clientID)
+	if errors.Is(err, domain.ErrClientNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, resolveError(ctx, err)
+	}
+	return &clientResolver{c: *client, detailed: true}, nil
+}
+
+func (r *resolver) Categories(ctx context.Context) ([]*categoryResolver, error) {
+	categories, err := r.svc.categories.List(ctx)
+	if err != nil {
+		return nil, resolveError(ctx, err)
+	}
+	resolvers := make([]*categoryResolver, len(categories))
+	for i := range categories {
+		resolvers[i] = &categoryResolver{c: categories[i]}
+	}
+	return resolvers, nil
+}
+
+func (r *resolver) Stock(ctx context.Context, args struct {
+	ClientID  *graphql.ID
+	ProductID *graphql.ID
+}) ([]*holdingResolver, error) {
+	clientID, err := parseOptionalID(args.ClientID)
+	if err != nil {
+		return nil, err
+	}
+	productID, err := parseOptionalID(args.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	var stocks []domain.ClientStock
+	switch {
+	case clientID != nil:
+		stocks, err = loadersFrom(ctx).stockByClient.Load(ctx, *clientID)()
+	case productID != nil:
+		stocks, err = loadersFrom(ctx).holdersByProduct.Load(ctx, *productID)()
+	default:
+		return nil, resolveError(ctx, fmt.Errorf("%w: informe clientId ou productId", domain.ErrInvalidFilter))
+	}
+	if err != nil {
+		return nil, resolveError(ctx, err)
+	}
+
+	if clientID != nil && productID != nil {
+		filtered := stocks[:0:0]
+		for _, s := range stocks {
+			if s.ProductID == *productID {
+				filtered = append(filtered, s)
+			}
+		}
+		stocks = filtered
+	}
+	return holdings(stocks), nil
+}
+
+type transferStockInput struct {
+	ClientID  graphql.ID
+	Quantity  int32
+	Packaging *string
+	Serials   *[]string
+}
+
+func (r *resolver) TransferStock(ctx context.Context, args struct {
+	ProductID graphql.ID
+	Input     transferStockInput
+}) (*movementResolver, error) {
+	productID, err := parseID(args.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	clientID, err := parseID(args.Input.ClientID)
+	if err != nil {
+		return nil, err
+	}
+
+	req := service.TransferStockRequest{ClientID: clientID, Quantity: int(args.Input.Quantity)}
+	if args.Input.Packaging != nil {
+		req.Packaging = *args.Input.Packaging
+	}
+	if args.Input.Serials != nil {
+		req.Serials = *args.Input.Serials
+	}
+
+	movement, err := r.svc.products.TransferStock(ctx, productID, req)
+	if err != nil {
+		return nil, resolveError(ctx, err)
+	}
+	return &movementResolver{m: movement}, nil
+}
+
+type productInput struct {
+	SKU          *string
+	CategoryID   *graphql.ID
+	Name         string
+	Description  *string
+	PriceInCents Int64
+	Currency     *string
+	Quantity     *int32
+	Unit         string
+	Barcodes     *[]string
+	Tags         *[]string
+	Attributes   *JSON
+	TrackLots    *bool
+	Serialized   *bool
+}
+
+// product converte a entrada no produto usado pelos serviços.
+func (in productInput) product() (domain.Produto, error) {
+	categoryID, err := parseOptionalID(in.CategoryID)
+	if err != nil {
+		return domain.Produto{}, err
+	}
+	p := domain.Produto{
+		CategoryID:   categoryID,
+		Name:         in.Name,
+		PriceInCents: int64(in.PriceInCents),
+		Currency:     domain.Currency(deref(in.Currency)),
+		Unit:         domain.UnitOfMeasure(in.Unit),
+		SKU:          deref(in.SKU),
+		Description:  deref(in.Description),
+		TrackLots:    deref(in.TrackLots),
+		Serialized:   deref(in.Serialized),
+		Barcodes:     deref(in.Barcodes),
+		Tags:         deref(in.Tags),
+	}
+	if in.Quantity != nil {
+		p.Quantity = int(*in.Quantity)
+	}
+	if in.Attributes != nil {
+		p.Attributes = *in.Attributes
+	}
+	return p, nil
+}
+
+func (r *resolver) CreateProduct(ctx context.Context, args struct{ Input productInput }) (*productResolver, error) {
+	product, err := args.Input.product()
+	if err != nil {
+		return nil, err
+	}
+	if err := r.svc.products.CreateProduct(ctx, &product); err != nil {
+		return nil, resolveError(ctx, err)
+	}
+	return &productResolver{p: product}, nil
+}
+
+func (r *resolver) UpdateProduct(ctx context.Context, args struct {
+	ID    graphql.ID
+	Input productInput
+}) (*productResolver, error) {
+	productID, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	input, err := args.Input.product()
+	if err != nil {
+		return nil, err
+	}
+	product, err := r.svc.products.UpdateProduct(ctx, productID, input)
+	if err != nil {
+		return nil, resolveError(ctx, err)
+	}
+	return &productResolver{p: *product}, nil
+}
+
+func (r *resolver) DeleteProduct(ctx context.Context, args struct{ ID graphql.ID }) (bool, error) {
+	productID, err := parseID(args.ID)
+	if err != nil {
+		return false, err
+	}
+	if err := r.svc.products.DeleteProduct(ctx, productID); err != nil {
+		return false, resolveError(ctx, err)
+	}
+	return true, nil
+}
+
+type addressInput struct {
+	Type       string
+	Street     string
+	Number     *string
+	Complement *string
+	District   *string
+	City       string
+	State      string
+	CEP        string
+}
+
+type contactInput struct {
+	Name  string
+	Email *string
+	Phone *string
+	Role  *string
+}
+
+type clientInput struct {
+	Name      string
+	Email     *string
+	Phone     *string
+	Document  *string
+	Status    *string
+	Addresses *[]addressInput
+	Contacts  *[]contactInput
+}
+
+// client converte a entrada no cliente usado pelos serviços.
+func (in clientInput) client() domain.Client {
+	c := domain.Client{
+		Name:     in.Name,
+		Email:    deref(in.Email),
+		Phone:    deref(in.Phone),
+		Document: deref(in.Document),
+		Status:   domain.ClientStatus(deref(in.Status)),
+	}
+	for _, a := range deref(in.Addresses) {
+		c.Addresses = append(c.Addresses, domain.Address{
+			Type:       domain.AddressType(a.Type),
+			Street:     a.Street,
+			Number:     deref(a.Number),
+			Complement: deref(a.Complement),
+			District:   deref(a.District),
+			City:       a.City,
+			State:      a.State,
+			CEP:        a.CEP,
+		})
+	}
+	for _, ct := range deref(in.Contacts) {
+		c.Contacts = append(c.Contacts, domain.Contact{
+			Name:  ct.Name,
+			Email: deref(ct.Email),
+			Phone: deref(ct.Phone),
+			Role:  deref(ct.Role),
+		})
+	}
+	return c
+}
+
+func (r *resolver) CreateClient(ctx context.Context, args struct{ Input clientInput }) (*clientResolver, error) {
+	client := args.Input.client()
+	if err := r.svc.clients.Create(ctx, &client); err != nil {
+		return nil, resolveError(ctx, err)
+	}
+	return &clientResolver{c: client, detailed: true}, nil
+}
+
+func (r *resolver) UpdateClient(ctx context.Context, args struct {
+	ID    graphql.ID
+	Input clientInput
+}) (*clientResolver, error) {
+	clientID, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	client := args.Input.client()
+	client.ID = clientID
+	if err := r.svc.clients.Update(ctx, &client); err != nil {
+		return nil, resolveError(ctx, err)
+	}
+	return &clientResolver{c: client, detailed: true}, nil
+}
+
+func (r *resolver) DeleteClient(ctx context.Context, args struct{ ID graphql.ID }) (bool, error) {
+	clientID, err := parseID(args.ID)
+	if err != nil {
+		return false, err
+	}
+	if err := r.svc.clients.Delete(ctx, clientID); err != nil {
+		return false, resolveError(ctx, err)
+	}
+	return true, nil
+}
+
+// deref devolve o valor apontado ou o valor zero quando o argumento foi omitido.
+func deref[T any](p *T) T {
+	var zero T
+	if p == nil {
+		return zero
+	}
+	return *p
+}
@@ -0,0 +1,66 @@
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// Int64 é o escalar usado nos valores em centavos, que podem exceder o Int de 32 bits
+// do GraphQL. Aceita números inteiros ou strings com dígitos na entrada.
+type Int64 int64
+
+func (Int64) ImplementsGraphQLType(name string) bool {
+	return name == "Int64"
+}
+
+func (i *Int64) UnmarshalGraphQL(input any) error {
+	switch v := input.(type) {
+	case int32:
+		*i = Int64(v)
+	case int64:
+		*i = Int64(v)
+	case float64:
+		if v != math.Trunc(v) || math.Abs(v) > 1<<53 {
+			return fmt.Errorf("Int64 inválido: %v", v)
+		}
+		*i = Int64(v)
+	case string:
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return fmt.Errorf("Int64 inválido: %q", v)
+		}
+		*i = Int64(n)
+	default:
+		return fmt.Errorf("Int64 inválido: %T", input)
+	}
+	return nil
+}
+
+func (i Int64) MarshalJSON() ([]byte, error) {
+	return strconv.AppendInt(nil, int64(i), 10), nil
+}
+
+// JSON é o escalar dos atributos personalizados dos produtos: um objeto JSON livre.
+type JSON map[string]any
+
+func (JSON) ImplementsGraphQLType(name string) bool {
+	return name == "JSON"
+}
+
+func (j *JSON) UnmarshalGraphQL(input any) error {
+	object, ok := input.(map[string]any)
+	if !ok {
+		return fmt.Errorf("JSON inválido: esperado um objeto, recebido %T", input)
+	}
+	*j = object
+	return nil
+}
+
+func (j JSON) MarshalJSON() ([]byte, error) {
+	if j == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(map[string]any(j))
+}
@@ -0,0 +1,67 @@
+// Package graph expõe os serviços de produtos, clientes e categorias em um esquema
+// GraphQL, com carregadores em lote por requisição e limites de profundidade e custo.
+package graph
+
+import (
+	"context"
+	_ "embed"
+
+	"controle-de-estoque/backend/internal/service"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/errors"
+)
+
+//go:embed schema.graphql
+var schemaSDL string
+
+// Limites aplicados a cada consulta antes da execução.
+const (
+	MaxDepth      = 10   // Níveis de aninhamento de campos
+	MaxComplexity = 5000 // Custo estimado (ver complexity)
+)
+
+// services reúne os serviços usados pelos resolvers e pelos carregadores.
+type services struct {
+	products   *service.ProductService
+	clients    *service.ClientService
+	categories *service.CategoryService
+}
+
+// Schema é o esquema GraphQL executável da API.
+type Schema struct {
+	schema   *graphql.Schema
+	services services
+}
+
+// Request é o corpo de uma requisição GraphQL sobre HTTP. Extensions é aceito para
+// compatibilidade com os clientes, mas ignorado.
+type Request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+	Extensions    map[string]any `json:"extensions,omitempty"`
+}
+
+// NewSchema monta o esquema sobre os serviços. Entra em pânico se o esquema embutido
+// não corresponder aos resolvers, o que é um erro de programação.
+func NewSchema(products *service.ProductService, clients *service.ClientService, categories *service.CategoryService) *Schema {
+	svc := services{products: products, clients: clients, categories: categories}
+	return &Schema{
+		schema: graphql.MustParseSchema(schemaSDL, &resolver{svc: svc},
+			graphql.UseStringDescriptions(),
+			graphql.MaxDepth(MaxDepth),
+		),
+		services: svc,
+	}
+}
+
+// Exec executa a requisição. Consultas acima de MaxComplexity são rejeitadas sem
+// acessar o banco; as demais usam carregadores novos, válidos apenas nesta requisição.
+func (s *Schema) Exec(ctx context.Context, req Request) *graphql.Response {
+	if err := checkComplexity(s.schema.ASTSchema(), req); err != nil {
+		return &graphql.Response{Errors: []*errors.QueryError{err}}
+	}
+	ctx = withLoaders(ctx, s.services)
+	return s.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+}
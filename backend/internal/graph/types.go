@@ -0,0 +1,228 @@
+package graph
+
+import (
+	"context"
+
+	"controle-de-estoque/backend/internal/domain"
+
+	"github.com/graph-gophers/graphql-go"
+)
+
+// productResolver resolve o tipo Product.
+type productResolver struct {
+	p domain.Produto
+}
+
+func (r *productResolver) ID() graphql.ID          { return graphql.ID(r.p.ID.String()) }
+func (r *productResolver) SKU() string             { return r.p.SKU }
+func (r *productResolver) Name() string            { return r.p.Name }
+func (r *productResolver) Description() string     { return r.p.Description }
+func (r *productResolver) PriceInCents() Int64     { return Int64(r.p.PriceInCents) }
+func (r *productResolver) Currency() string        { return string(r.p.Currency) }
+func (r *productResolver) Quantity() int32         { return int32(r.p.Quantity) }
+func (r *productResolver) Unit() string            { return string(r.p.Unit) }
+func (r *productResolver) Barcodes() []string      { return nonNil(r.p.Barcodes) }
+func (r *productResolver) Tags() []string          { return nonNil(r.p.Tags) }
+func (r *productResolver) Attributes() JSON        { return JSON(r.p.Attributes) }
+func (r *productResolver) TrackLots() bool         { return r.p.TrackLots }
+func (r *productResolver) Serialized() bool        { return r.p.Serialized }
+func (r *productResolver) CreatedAt() graphql.Time { return graphql.Time{Time: r.p.CreatedAt} }
+func (r *productResolver) UpdatedAt() graphql.Time { return graphql.Time{Time: r.p.UpdatedAt} }
+
+func (r *productResolver) Category(ctx context.Context) (*categoryResolver, error) {
+	if r.p.CategoryID == nil {
+		return nil, nil
+	}
+	category, err := loadersFrom(ctx).categories.Load(ctx, *r.p.CategoryID)()
+	if err != nil {
+		return nil, resolveError(ctx, err)
+	}
+	return &categoryResolver{c: *category}, nil
+}
+
+func (r *productResolver) Holdings(ctx context.Context) ([]*holdingResolver, error) {
+	stocks, err := loadersFrom(ctx).holdersByProduct.Load(ctx, r.p.ID)()
+	if err != nil {
+		return nil, resolveError(ctx, err)
+	}
+	return holdings(stocks), nil
+}
+
+// clientResolver resolve o tipo Client. Os clientes das listagens vêm sem endereços e
+// contatos; nesse caso detailed é falso e eles são buscados pelo carregador.
+type clientResolver struct {
+	c        domain.Client
+	detailed bool
+}
+
+func (r *clientResolver) ID() graphql.ID          { return graphql.ID(r.c.ID.String()) }
+func (r *clientResolver) Name() string            { return r.c.Name }
+func (r *clientResolver) Email() string           { return r.c.Email }
+func (r *clientResolver) Phone() string           { return r.c.Phone }
+func (r *clientResolver) Document() string        { return r.c.Document }
+func (r *clientResolver) Status() string          { return string(r.c.Status) }
+func (r *clientResolver) CreatedAt() graphql.Time { return graphql.Time{Time: r.c.CreatedAt} }
+func (r *clientResolver) UpdatedAt() graphql.Time { return graphql.Time{Time: r.c.UpdatedAt} }
+
+func (r *clientResolver) Addresses(ctx context.Context) ([]*addressResolver, error) {
+	client, err := r.details(ctx)
+	if err != nil {
+		return nil, err
+	}
+	addresses := make([]*addressResolver, len(client.Addresses))
+	for i := range client.Addresses {
+		addresses[i] = &addressResolver{a: client.Addresses[i]}
+	}
+	return addresses, nil
+}
+
+func (r *clientResolver) Contacts(ctx context.Context) ([]*contactResolver, error) {
+	client, err := r.details(ctx)
+	if err != nil {
+		return nil, err
+	}
+	contacts := make([]*contactResolver, len(client.Contacts))
+	for i := range client.Contacts {
+		contacts[i] = &contactResolver{c: client.Contacts[i]}
+	}
+	return contacts, nil
+}
+
+func (r *clientResolver) Stock(ctx context.Context) ([]*holdingResolver, error) {
+	stocks, err := loadersFrom(ctx).stockByClient.Load(ctx, r.c.ID)()
+	if err != nil {
+		return nil, resolveError(ctx, err)
+	}
+	return holdings(stocks), nil
+}
+
+func (r *clientResolver) details(ctx context.Context) (*domain.Client, error) {
+	if r.detailed {
+		return &r.c, nil
+	}
+	client, err := loadersFrom(ctx).clients.Load(ctx, r.c.ID)()
+	if err != nil {
+		return nil, resolveError(ctx, err)
+	}
+	return client, nil
+}
+
+// addressResolver resolve o tipo Address.
+type addressResolver struct {
+	a domain.Address
+}
+
+func (r *addressResolver) ID() graphql.ID     { return graphql.ID(r.a.ID.String()) }
+func (r *addressResolver) Type() string       { return string(r.a.Type) }
+func (r *addressResolver) Street() string     { return r.a.Street }
+func (r *addressResolver) Number() string     { return r.a.Number }
+func (r *addressResolver) Complement() string { return r.a.Complement }
+func (r *addressResolver) District() string   { return r.a.District }
+func (r *addressResolver) City() string       { return r.a.City }
+func (r *addressResolver) State() string      { return r.a.State }
+func (r *addressResolver) CEP() string        { return r.a.CEP }
+
+// contactResolver resolve o tipo Contact.
+type contactResolver struct {
+	c domain.Contact
+}
+
+func (r *contactResolver) ID() graphql.ID { return graphql.ID(r.c.ID.String()) }
+func (r *contactResolver) Name() string   { return r.c.Name }
+func (r *contactResolver) Email() string  { return r.c.Email }
+func (r *contactResolver) Phone() string  { return r.c.Phone }
+func (r *contactResolver) Role() string   { return r.c.Role }
+
+// categoryResolver resolve o tipo Category.
+type categoryResolver struct {
+	c domain.Category
+}
+
+func (r *categoryResolver) ID() graphql.ID          { return graphql.ID(r.c.ID.String()) }
+func (r *categoryResolver) Name() string            { return r.c.Name }
+func (r *categoryResolver) CreatedAt() graphql.Time { return graphql.Time{Time: r.c.CreatedAt} }
+func (r *categoryResolver) UpdatedAt() graphql.Time { return graphql.Time{Time: r.c.UpdatedAt} }
+
+func (r *categoryResolver) Parent(ctx context.Context) (*categoryResolver, error) {
+	if r.c.ParentID == nil {
+		return nil, nil
+	}
+	parent, err := loadersFrom(ctx).categories.Load(ctx, *r.c.ParentID)()
+	if err != nil {
+		return nil, resolveError(ctx, err)
+	}
+	return &categoryResolver{c: *parent}, nil
+}
+
+// holdingResolver resolve o tipo Holding.
+type holdingResolver struct {
+	s domain.ClientStock
+}
+
+func holdings(stocks []domain.ClientStock) []*holdingResolver {
+	resolvers := make([]*holdingResolver, len(stocks))
+	for i := range stocks {
+		resolvers[i] = &holdingResolver{s: stocks[i]}
+	}
+	return resolvers
+}
+
+func (r *holdingResolver) Quantity() int32 { return int32(r.s.Quantity) }
+
+func (r *holdingResolver) Client(ctx context.Context) (*clientResolver, error) {
+	client, err := loadersFrom(ctx).clients.Load(ctx, r.s.ClientID)()
+	if err != nil {
+		return nil, resolveError(ctx, err)
+	}
+	return &clientResolver{c: *client, detailed: true}, nil
+}
+
+func (r *holdingResolver) Product(ctx context.Context) (*productResolver, error) {
+	product, err := loadersFrom(ctx).products.Load(ctx, r.s.ProductID)()
+	if err != nil {
+		return nil, resolveError(ctx, err)
+	}
+	return &productResolver{p: *product}, nil
+}
+
+// movementResolver resolve o tipo StockMovement.
+type movementResolver struct {
+	m *domain.StockMovement
+}
+
+func (r *movementResolver) ID() graphql.ID           { return graphql.ID(r.m.ID.String()) }
+func (r *movementResolver) Type() string             { return string(r.m.Type) }
+func (r *movementResolver) Packaging() string        { return r.m.Packaging }
+func (r *movementResolver) PackagingQuantity() int32 { return int32(r.m.PackagingQuantity) }
+func (r *movementResolver) PackagingFactor() int32   { return int32(r.m.PackagingFactor) }
+func (r *movementResolver) Quantity() int32          { return int32(r.m.Quantity) }
+func (r *movementResolver) Reason() string           { return r.m.Reason }
+func (r *movementResolver) Serials() []string        { return nonNil(r.m.Serials) }
+func (r *movementResolver) CreatedAt() graphql.Time  { return graphql.Time{Time: r.m.CreatedAt} }
+
+func (r *movementResolver) Product(ctx context.Context) (*productResolver, error) {
+	product, err := loadersFrom(ctx).products.Load(ctx, r.m.ProductID)()
+	if err != nil {
+		return nil, resolveError(ctx, err)
+	}
+	return &productResolver{p: *product}, nil
+}
+
+func (r *movementResolver) Client(ctx context.Context) (*clientResolver, error) {
+	if r.m.ClientID == nil {
+		return nil, nil
+	}
+	client, err := loadersFrom(ctx).clients.Load(ctx, *r.m.ClientID)()
+	if err != nil {
+		return nil, resolveError(ctx, err)
+	}
+	return &clientResolver{c: *client, detailed: true}, nil
+}
+
+// nonNil garante listas vazias, e não nulas, nos campos declarados como não nulos.
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
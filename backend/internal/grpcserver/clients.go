@@ -0,0 +1,115 @@
+package grpcserver
+
+import (
+	"context"
+
+	"controle-de-estoque/backend/internal/domain"
+	"controle-de-estoque/backend/internal/service"
+	estoquev1 "controle-de-estoque/backend/proto/estoque/v1"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// clientServer implementa estoquev1.ClientServiceServer sobre service.ClientService.
+type clientServer struct {
+	estoquev1.UnimplementedClientServiceServer
+	clients *service.ClientService
+}
+
+func (s *clientServer) CreateClient(ctx context.Context, req *estoquev1.CreateClientRequest) (*estoquev1.Client, error) {
+	client := clientFromProto(req.GetClient())
+	if err := s.clients.Create(ctx, &client); err != nil {
+		return nil, statusError(ctx, err)
+	}
+	return clientToProto(&client), nil
+}
+
+func (s *clientServer) GetClient(ctx context.Context, req *estoquev1.GetClientRequest) (*estoquev1.Client, error) {
+	clientID, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	client, err := s.clients.GetByID(ctx, clientID)
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+	return clientToProto(client), nil
+}
+
+func (s *clientServer) ListClients(ctx context.Context, req *estoquev1.ListClientsRequest) (*estoquev1.ListClientsResponse, error) {
+	productID, err := parseOptionalID("holding_product_id", req.GetHoldingProductId())
+	if err != nil {
+		return nil, err
+	}
+	filter := domain.ClientFilter{
+		Search:           req.GetSearch(),
+		Status:           clientStatusFromProto(req.GetStatus()),
+		HoldingProductID: productID,
+		WithStock:        req.GetWithStock(),
+	}
+
+	result, err := s.clients.List(ctx, filter, pageRequest(req.GetPageSize(), req.GetPageToken()))
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+	clients, _ := result.Data.([]domain.Client)
+	res := &estoquev1.ListClientsResponse{
+		Clients:       make([]*estoquev1.Client, len(clients)),
+		NextPageToken: result.Metadata.NextCursor,
+		TotalSize:     totalSize(result.Metadata),
+	}
+	for i := range clients {
+		res.Clients[i] = clientToProto(&clients[i])
+	}
+	return res, nil
+}
+
+func (s *clientServer) UpdateClient(ctx context.Context, req *estoquev1.UpdateClientRequest) (*estoquev1.Client, error) {
+	clientID, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	client := clientFromProto(req.GetClient())
+	client.ID = clientID
+	if err := s.clients.Update(ctx, &client); err != nil {
+		return nil, statusError(ctx, err)
+	}
+	return clientToProto(&client), nil
+}
+
+func (s *clientServer) DeleteClient(ctx context.Context, req *estoquev1.DeleteClientRequest) (*emptypb.Empty, error) {
+	clientID, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.clients.Delete(ctx, clientID); err != nil {
+		return nil, statusError(ctx, err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *clientServer) ListClientStock(ctx context.Context, req *estoquev1.ListClientStockRequest) (*estoquev1.ListClientStockResponse, error) {
+	clientID, err := parseID("client_id", req.GetClientId())
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.clients.ListStockByClientID(ctx, clientID, pageRequest(req.GetPageSize(), req.GetPageToken()))
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+	stocks, _ := result.Data.([]domain.ClientStockDetails)
+	res := &estoquev1.ListClientStockResponse{
+		Items:         make([]*estoquev1.ClientStockItem, len(stocks)),
+		NextPageToken: result.Metadata.NextCursor,
+		TotalSize:     totalSize(result.Metadata),
+	}
+	for i, stock := range stocks {
+		res.Items[i] = &estoquev1.ClientStockItem{
+			ProductId:   stock.ProductID.String(),
+			ProductName: stock.ProductName,
+			Quantity:    int32(stock.Quantity),
+			Lots:        lotsToProto(stock.Lots),
+		}
+	}
+	return res, nil
+}
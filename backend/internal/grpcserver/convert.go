@@ -0,0 +1,271 @@
+package grpcserver
+
+import (
+	"time"
+
+	"controle-de-estoque/backend/internal/domain"
+	estoquev1 "controle-de-estoque/backend/proto/estoque/v1"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// pageRequest converte page_size e page_token em uma página por cursor, com as mesmas
+// regras da API REST: o tamanho é restrito a domain.MaxPageSize e o total só é
+// calculado na primeira página.
+func pageRequest(size int32, token string) domain.PageRequest {
+	page := domain.PageRequest{Limit: domain.DefaultPageSize, Page: 1, Cursor: token}
+	if size >= 1 {
+		page.Limit = min(int(size), domain.MaxPageSize)
+	}
+	page.IncludeTotal = token == ""
+	return page
+}
+
+// totalSize converte o total dos metadados, presente apenas quando calculado.
+func totalSize(m domain.Metadata) *int32 {
+	if m.TotalRecords == nil {
+		return nil
+	}
+	total := int32(*m.TotalRecords)
+	return &total
+}
+
+func optionalID(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	s := id.String()
+	return &s
+}
+
+func optionalTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func productToProto(p *domain.Produto) *estoquev1.Product {
+	out := &estoquev1.Product{
+		Id:                   p.ID.String(),
+		ParentId:             optionalID(p.ParentID),
+		VariantOptions:       p.VariantOptions,
+		CreatedAt:            timestamppb.New(p.CreatedAt),
+		UpdatedAt:            timestamppb.New(p.UpdatedAt),
+		Sku:                  p.SKU,
+		CategoryId:           optionalID(p.CategoryID),
+		Name:                 p.Name,
+		Description:          p.Description,
+		PriceInCents:         p.PriceInCents,
+		Quantity:             int32(p.Quantity),
+		Unit:                 string(p.Unit),
+		Barcodes:             p.Barcodes,
+		Tags:                 p.Tags,
+		TrackLots:            p.TrackLots,
+		Serialized:           p.Serialized,
+		CostingMethod:        costingMethods[p.CostingMethod],
+		Currency:             string(p.Currency),
+		PriceOverrideInCents: p.PriceOverrideInCents,
+		StockValueInCents:    p.StockValueInCents,
+		AverageCostInCents:   p.AverageCostInCents,
+	}
+	// Os atributos vêm de JSON, então sempre têm representação em Struct.
+	out.Attributes, _ = structpb.NewStruct(p.Attributes)
+	for i := range p.Variants {
+		out.Variants = append(out.Variants, productToProto(&p.Variants[i]))
+	}
+	return out
+}
+
+// productFromProto converte os campos editáveis do produto recebido.
+func productFromProto(in *estoquev1.Product) (domain.Produto, error) {
+	categoryID, err := parseOptionalID("category_id", in.GetCategoryId())
+	if err != nil {
+		return domain.Produto{}, err
+	}
+	p := domain.Produto{
+		SKU:                  in.GetSku(),
+		CategoryID:           categoryID,
+		Name:                 in.GetName(),
+		Description:          in.GetDescription(),
+		PriceInCents:         in.GetPriceInCents(),
+		Quantity:             int(in.GetQuantity()),
+		Unit:                 domain.UnitOfMeasure(in.GetUnit()),
+		Barcodes:             in.GetBarcodes(),
+		Tags:                 in.GetTags(),
+		TrackLots:            in.GetTrackLots(),
+		Serialized:           in.GetSerialized(),
+		CostingMethod:        costingMethodFromProto(in.GetCostingMethod()),
+		Currency:             domain.Currency(in.GetCurrency()),
+		PriceOverrideInCents: in.PriceOverrideInCents,
+	}
+	if in.GetAttributes() != nil {
+		p.Attributes = in.GetAttributes().AsMap()
+	}
+	return p, nil
+}
+
+var costingMethods = map[domain.CostingMethod]estoquev1.CostingMethod{
+	domain.CostingAverage: estoquev1.CostingMethod_COSTING_METHOD_AVERAGE,
+	domain.CostingFIFO:    estoquev1.CostingMethod_COSTING_METHOD_FIFO,
+}
+
+// costingMethodFromProto converte o método de custeio; UNSPECIFIED mantém o atual na
+// atualização e usa o custo médio na criação.
+func costingMethodFromProto(m estoquev1.CostingMethod) domain.CostingMethod {
+	for method, value := range costingMethods {
+		if value == m {
+			return method
+		}
+	}
+	return ""
+}
+
+var clientStatuses = map[domain.ClientStatus]estoquev1.ClientStatus{
+	domain.ClientActive:  estoquev1.ClientStatus_CLIENT_STATUS_ACTIVE,
+	domain.ClientBlocked: estoquev1.ClientStatus_CLIENT_STATUS_BLOCKED,
+}
+
+var addressTypes = map[domain.AddressType]estoquev1.AddressType{
+	domain.AddressBilling:  estoquev1.AddressType_ADDRESS_TYPE_BILLING,
+	domain.AddressDelivery: estoquev1.AddressType_ADDRESS_TYPE_DELIVERY,
+}
+
+// clientStatusFromProto converte a situação; UNSPECIFIED corresponde à situação vazia.
+func clientStatusFromProto(s estoquev1.ClientStatus) domain.ClientStatus {
+	for status, value := range clientStatuses {
+		if value == s {
+			return status
+		}
+	}
+	return ""
+}
+
+func clientToProto(c *domain.Client) *estoquev1.Client {
+	out := &estoquev1.Client{
+		Id:        c.ID.String(),
+		CreatedAt: timestamppb.New(c.CreatedAt),
+		UpdatedAt: timestamppb.New(c.UpdatedAt),
+		Name:      c.Name,
+		Email:     c.Email,
+		Phone:     c.Phone,
+		Document:  c.Document,
+		Status:    clientStatuses[c.Status],
+	}
+	for _, a := range c.Addresses {
+		out.Addresses = append(out.Addresses, &estoquev1.Address{
+			Id:         a.ID.String(),
+			Type:       addressTypes[a.Type],
+			Street:     a.Street,
+			Number:     a.Number,
+			Complement: a.Complement,
+			District:   a.District,
+			City:       a.City,
+			State:      a.State,
+			Cep:        a.CEP,
+		})
+	}
+	for _, ct := range c.Contacts {
+		out.Contacts = append(out.Contacts, &estoquev1.Contact{
+			Id:    ct.ID.String(),
+			Name:  ct.Name,
+			Email: ct.Email,
+			Phone: ct.Phone,
+			Role:  ct.Role,
+		})
+	}
+	return out
+}
+
+// clientFromProto converte os campos editáveis do cliente recebido. Tipos de endereço
+// não informados ficam vazios e são rejeitados pela validação do serviço.
+func clientFromProto(in *estoquev1.Client) domain.Client {
+	c := domain.Client{
+		Name:     in.GetName(),
+		Email:    in.GetEmail(),
+		Phone:    in.GetPhone(),
+		Document: in.GetDocument(),
+		Status:   clientStatusFromProto(in.GetStatus()),
+	}
+	for _, a := range in.GetAddresses() {
+		var addressType domain.AddressType
+		for t, value := range addressTypes {
+			if value == a.GetType() {
+				addressType = t
+			}
+		}
+		c.Addresses = append(c.Addresses, domain.Address{
+			Type:       addressType,
+			Street:     a.GetStreet(),
+			Number:     a.GetNumber(),
+			Complement: a.GetComplement(),
+			District:   a.GetDistrict(),
+			City:       a.GetCity(),
+			State:      a.GetState(),
+			CEP:        a.GetCep(),
+		})
+	}
+	for _, ct := range in.GetContacts() {
+		c.Contacts = append(c.Contacts, domain.Contact{
+			Name:  ct.GetName(),
+			Email: ct.GetEmail(),
+			Phone: ct.GetPhone(),
+			Role:  ct.GetRole(),
+		})
+	}
+	return c
+}
+
+var movementTypes = map[domain.MovementType]estoquev1.MovementType{
+	domain.MovementReceipt:    estoquev1.MovementType_MOVEMENT_TYPE_RECEIPT,
+	domain.MovementAdjustment: estoquev1.MovementType_MOVEMENT_TYPE_ADJUSTMENT,
+	domain.MovementTransfer:   estoquev1.MovementType_MOVEMENT_TYPE_TRANSFER,
+	domain.MovementReturn:     estoquev1.MovementType_MOVEMENT_TYPE_RETURN,
+}
+
+func lotsToProto(lots []domain.LotAllocation) []*estoquev1.LotAllocation {
+	out := make([]*estoquev1.LotAllocation, len(lots))
+	for i, l := range lots {
+		out[i] = &estoquev1.LotAllocation{
+			LotId:       l.LotID.String(),
+			BatchNumber: l.BatchNumber,
+			ExpiryDate:  optionalTimestamp(l.ExpiryDate),
+			Quantity:    int32(l.Quantity),
+		}
+	}
+	return out
+}
+
+func movementToProto(m *domain.StockMovement) *estoquev1.StockMovement {
+	return &estoquev1.StockMovement{
+		Id:                m.ID.String(),
+		ProductId:         m.ProductID.String(),
+		ClientId:          optionalID(m.ClientID),
+		Type:              movementTypes[m.Type],
+		Packaging:         m.Packaging,
+		PackagingQuantity: int32(m.PackagingQuantity),
+		PackagingFactor:   int32(m.PackagingFactor),
+		Quantity:          int32(m.Quantity),
+		Reason:            m.Reason,
+		Lots:              lotsToProto(m.Lots),
+		Serials:           m.Serials,
+		CreatedAt:         timestamppb.New(m.CreatedAt),
+		CostInCents:       m.CostInCents,
+		UnitPriceInCents:  m.UnitPriceInCents,
+		Currency:          string(m.Currency),
+		CostCurrency:      string(m.CostCurrency),
+	}
+}
+
+func stockEventToProto(e domain.StockEvent) *estoquev1.StockEvent {
+	return &estoquev1.StockEvent{
+		MovementId: e.MovementID.String(),
+		ProductId:  e.ProductID.String(),
+		ClientId:   optionalID(e.ClientID),
+		Type:       movementTypes[e.Type],
+		Quantity:   int32(e.Quantity),
+		CreatedAt:  timestamppb.New(e.CreatedAt),
+	}
+}
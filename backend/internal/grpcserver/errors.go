@@ -0,0 +1,110 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"controle-de-estoque/backend/internal/domain"
+
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// errorDomain identifica a origem dos códigos de erro nos detalhes ErrorInfo.
+const errorDomain = "controle-de-estoque"
+
+// kindCode associa cada categoria de erro de domínio ao código gRPC da resposta.
+var kindCode = map[domain.ErrorKind]codes.Code{
+	domain.KindValidation:        codes.InvalidArgument,
+	domain.KindBadRequest:        codes.InvalidArgument,
+	domain.KindNotFound:          codes.NotFound,
+	domain.KindConflict:          codes.FailedPrecondition,
+	domain.KindInsufficientStock: codes.FailedPrecondition,
+	domain.KindUnauthorized:      codes.Unauthenticated,
+	domain.KindInternal:          codes.Internal,
+}
+
+// statusError traduz um erro dos serviços para um status gRPC. O código estável da API
+// REST vai em um detalhe ErrorInfo (reason) e os erros de campo em um detalhe
+// BadRequest. Erros fora do domínio são registrados no log e mascarados.
+func statusError(ctx context.Context, err error) error {
+	var validationErr *domain.ValidationError
+	var domainErr *domain.Error
+	switch {
+	case errors.Is(err, domain.ErrStockEventsLagging):
+		return withDetails(codes.ResourceExhausted, err, domain.ErrStockEventsLagging.Code)
+	case errors.Is(err, domain.ErrStockEventsInterrupted):
+		return withDetails(codes.Unavailable, err, domain.ErrStockEventsInterrupted.Code)
+	case errors.As(err, &validationErr):
+		code := "validation_failed"
+		if errors.As(validationErr.Err, &domainErr) {
+			code = domainErr.Code
+		}
+		violations := make([]*errdetails.BadRequest_FieldViolation, len(validationErr.Fields))
+		for i, f := range validationErr.Fields {
+			violations[i] = &errdetails.BadRequest_FieldViolation{Field: f.Field, Description: f.Message, Reason: f.Code}
+		}
+		return withDetails(codes.InvalidArgument, err, code, &errdetails.BadRequest{FieldViolations: violations})
+	case errors.As(err, &domainErr):
+		code, ok := kindCode[domainErr.Kind]
+		if !ok {
+			code = codes.Internal
+		}
+		return withDetails(code, err, domainErr.Code)
+	default:
+		method, _ := grpc.Method(ctx)
+		log.Printf("Erro interno gRPC %s: %v", method, err)
+		return status.Error(codes.Internal, "erro interno no servidor")
+	}
+}
+
+// withDetails cria o status com o código da API em um detalhe ErrorInfo, seguido dos
+// demais detalhes.
+func withDetails(code codes.Code, err error, reason string, details ...protoadapt.MessageV1) error {
+	details = append([]protoadapt.MessageV1{&errdetails.ErrorInfo{Reason: reason, Domain: errorDomain}}, details...)
+	st, detailsErr := status.New(code, err.Error()).WithDetails(details...)
+	if detailsErr != nil {
+		return status.Error(code, err.Error())
+	}
+	return st.Err()
+}
+
+// parseID converte um ID recebido na requisição em UUID.
+func parseID(field, value string) (uuid.UUID, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, withDetails(codes.InvalidArgument, fmt.Errorf("%s inválido: %q", field, value), "invalid_id")
+	}
+	return id, nil
+}
+
+// parseOptionalID converte um ID opcional em UUID; vazio significa ausente.
+func parseOptionalID(field, value string) (*uuid.UUID, error) {
+	if value == "" {
+		return nil, nil
+	}
+	id, err := parseID(field, value)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+// parseIDs converte uma lista de IDs recebida na requisição em UUIDs.
+func parseIDs(field string, values []string) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, len(values))
+	for i, value := range values {
+		id, err := parseID(field, value)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
@@ -0,0 +1,103 @@
+package grpcserver
+
+import (
+	"context"
+
+	"controle-de-estoque/backend/internal/domain"
+	"controle-de-estoque/backend/internal/service"
+	estoquev1 "controle-de-estoque/backend/proto/estoque/v1"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// productServer implementa estoquev1.ProductServiceServer sobre service.ProductService.
+type productServer struct {
+	estoquev1.UnimplementedProductServiceServer
+	products *service.ProductService
+}
+
+func (s *productServer) CreateProduct(ctx context.Context, req *estoquev1.CreateProductRequest) (*estoquev1.Product, error) {
+	product, err := productFromProto(req.GetProduct())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.products.CreateProduct(ctx, &product); err != nil {
+		return nil, statusError(ctx, err)
+	}
+	return productToProto(&product), nil
+}
+
+func (s *productServer) GetProduct(ctx context.Context, req *estoquev1.GetProductRequest) (*estoquev1.Product, error) {
+	productID, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	product, err := s.products.GetProductByID(ctx, productID)
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+	return productToProto(&product), nil
+}
+
+func (s *productServer) LookupProduct(ctx context.Context, req *estoquev1.LookupProductRequest) (*estoquev1.Product, error) {
+	product, err := s.products.GetProductByBarcode(ctx, req.GetBarcode())
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+	return productToProto(&product), nil
+}
+
+func (s *productServer) ListProducts(ctx context.Context, req *estoquev1.ListProductsRequest) (*estoquev1.ListProductsResponse, error) {
+	categoryID, err := parseOptionalID("category_id", req.GetCategoryId())
+	if err != nil {
+		return nil, err
+	}
+	filter := domain.ProductFilter{
+		Search:          req.GetSearch(),
+		CategoryID:      categoryID,
+		Tags:            service.NormalizeTags(req.GetTags()),
+		IncludeVariants: req.GetIncludeVariants(),
+	}
+
+	result, err := s.products.ListProducts(ctx, filter, pageRequest(req.GetPageSize(), req.GetPageToken()))
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+	products, _ := result.Data.([]domain.Produto)
+	res := &estoquev1.ListProductsResponse{
+		Products:      make([]*estoquev1.Product, len(products)),
+		NextPageToken: result.Metadata.NextCursor,
+		TotalSize:     totalSize(result.Metadata),
+	}
+	for i := range products {
+		res.Products[i] = productToProto(&products[i])
+	}
+	return res, nil
+}
+
+func (s *productServer) UpdateProduct(ctx context.Context, req *estoquev1.UpdateProductRequest) (*estoquev1.Product, error) {
+	productID, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	input, err := productFromProto(req.GetProduct())
+	if err != nil {
+		return nil, err
+	}
+	product, err := s.products.UpdateProduct(ctx, productID, input)
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+	return productToProto(product), nil
+}
+
+func (s *productServer) DeleteProduct(ctx context.Context, req *estoquev1.DeleteProductRequest) (*emptypb.Empty, error) {
+	productID, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.products.DeleteProduct(ctx, productID); err != nil {
+		return nil, statusError(ctx, err)
+	}
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,63 @@
+// Package grpcserver expõe os serviços de produtos, clientes e estoque via gRPC, para
+// as integrações dos armazéns. Os contratos ficam em proto/estoque/v1.
+package grpcserver
+
+import (
+	"context"
+	"strings"
+
+	"controle-de-estoque/backend/internal/service"
+	estoquev1 "controle-de-estoque/backend/proto/estoque/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// NewServer cria o servidor gRPC com os serviços registrados. Todas as chamadas exigem
+// o mesmo token da API REST no metadado authorization ("Bearer <token>").
+func NewServer(products *service.ProductService, clients *service.ClientService, events *service.StockEventService, tokens service.TokenGenerator) *grpc.Server {
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unaryAuth(tokens)),
+		grpc.ChainStreamInterceptor(streamAuth(tokens)),
+	)
+	estoquev1.RegisterProductServiceServer(s, &productServer{products: products})
+	estoquev1.RegisterClientServiceServer(s, &clientServer{clients: clients})
+	estoquev1.RegisterStockServiceServer(s, &stockServer{products: products, events: events})
+	return s
+}
+
+// authenticate valida o token enviado no metadado authorization.
+func authenticate(ctx context.Context, tokens service.TokenGenerator) error {
+	values := metadata.ValueFromIncomingContext(ctx, "authorization")
+	if len(values) == 0 {
+		return status.Error(codes.Unauthenticated, "metadado authorization ausente")
+	}
+	token, ok := strings.CutPrefix(values[0], "Bearer ")
+	if !ok {
+		return status.Error(codes.Unauthenticated, "metadado authorization mal formatado")
+	}
+	if userID, err := tokens.ValidateToken(token); err != nil || userID == nil {
+		return status.Error(codes.Unauthenticated, "token inválido ou expirado")
+	}
+	return nil
+}
+
+func unaryAuth(tokens service.TokenGenerator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := authenticate(ctx, tokens); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func streamAuth(tokens service.TokenGenerator) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := authenticate(ss.Context(), tokens); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
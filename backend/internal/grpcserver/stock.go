@@ -0,0 +1,128 @@
+package grpcserver
+
+import (
+	"context"
+
+	"controle-de-estoque/backend/internal/service"
+	estoquev1 "controle-de-estoque/backend/proto/estoque/v1"
+)
+
+// stockServer implementa estoquev1.StockServiceServer sobre as movimentações de
+// service.ProductService e o fluxo de service.StockEventService.
+type stockServer struct {
+	estoquev1.UnimplementedStockServiceServer
+	products *service.ProductService
+	events   *service.StockEventService
+}
+
+func (s *stockServer) TransferStock(ctx context.Context, req *estoquev1.TransferStockRequest) (*estoquev1.StockMovement, error) {
+	productID, err := parseID("product_id", req.GetProductId())
+	if err != nil {
+		return nil, err
+	}
+	clientID, err := parseID("client_id", req.GetClientId())
+	if err != nil {
+		return nil, err
+	}
+	movement, err := s.products.TransferStock(ctx, productID, service.TransferStockRequest{
+		ClientID:  clientID,
+		Quantity:  int(req.GetQuantity()),
+		Packaging: req.GetPackaging(),
+		Serials:   req.GetSerials(),
+	})
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+	return movementToProto(movement), nil
+}
+
+func (s *stockServer) ReceiveStock(ctx context.Context, req *estoquev1.ReceiveStockRequest) (*estoquev1.StockMovement, error) {
+	productID, err := parseID("product_id", req.GetProductId())
+	if err != nil {
+		return nil, err
+	}
+	movement, err := s.products.ReceiveStock(ctx, productID, service.ReceiveStockRequest{
+		Quantity:        int(req.GetQuantity()),
+		Packaging:       req.GetPackaging(),
+		Reason:          req.GetReason(),
+		BatchNumber:     req.GetBatchNumber(),
+		ExpiryDate:      req.GetExpiryDate(),
+		Serials:         req.GetSerials(),
+		UnitCostInCents: req.UnitCostInCents,
+	})
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+	return movementToProto(movement), nil
+}
+
+func (s *stockServer) AdjustStock(ctx context.Context, req *estoquev1.AdjustStockRequest) (*estoquev1.StockMovement, error) {
+	productID, err := parseID("product_id", req.GetProductId())
+	if err != nil {
+		return nil, err
+	}
+	movement, err := s.products.AdjustStock(ctx, productID, service.AdjustStockRequest{
+		Quantity:        int(req.GetQuantity()),
+		Packaging:       req.GetPackaging(),
+		Reason:          req.GetReason(),
+		BatchNumber:     req.GetBatchNumber(),
+		Serials:         req.GetSerials(),
+		UnitCostInCents: req.UnitCostInCents,
+	})
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+	return movementToProto(movement), nil
+}
+
+func (s *stockServer) ReturnStock(ctx context.Context, req *estoquev1.ReturnStockRequest) (*estoquev1.StockMovement, error) {
+	productID, err := parseID("product_id", req.GetProductId())
+	if err != nil {
+		return nil, err
+	}
+	clientID, err := parseID("client_id", req.GetClientId())
+	if err != nil {
+		return nil, err
+	}
+	movement, err := s.products.ReturnStock(ctx, productID, service.ReturnStockRequest{
+		ClientID:    clientID,
+		Quantity:    int(req.GetQuantity()),
+		Packaging:   req.GetPackaging(),
+		Reason:      req.GetReason(),
+		BatchNumber: req.GetBatchNumber(),
+		Serials:     req.GetSerials(),
+	})
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+	return movementToProto(movement), nil
+}
+
+func (s *stockServer) WatchStockEvents(req *estoquev1.WatchStockEventsRequest, stream estoquev1.StockService_WatchStockEventsServer) error {
+	productIDs, err := parseIDs("product_ids", req.GetProductIds())
+	if err != nil {
+		return err
+	}
+	clientIDs, err := parseIDs("client_ids", req.GetClientIds())
+	if err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+	sub := s.events.Subscribe(service.StockEventFilter{ProductIDs: productIDs, ClientIDs: clientIDs})
+	defer sub.Cancel()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event, ok := <-sub.Events():
+			if !ok {
+				return statusError(ctx, sub.Err())
+			}
+			if err := stream.Send(stockEventToProto(event)); err != nil {
+				return err
+			}
+		}
+	}
+}
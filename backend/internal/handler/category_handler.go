@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"controle-de-estoque/backend/internal/domain"
+	"controle-de-estoque/backend/internal/service"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// CategoryHandler gerencia as requisições HTTP para categorias de produtos.
+type CategoryHandler struct {
+	service *service.CategoryService
+}
+
+// NewCategoryHandler cria uma nova instância de CategoryHandler.
+func NewCategoryHandler(s *service.CategoryService) *CategoryHandler {
+	return &CategoryHandler{service: s}
+}
+
+func (h *CategoryHandler) CreateCategory(w http.ResponseWriter, r *http.Request) {
+	var category domain.Category
+	if !decodeJSON(w, r, &category) {
+		return
+	}
+	if err := h.service.Create(r.Context(), &category); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(category); err != nil {
+		log.Printf("Erro ao codificar JSON da categoria: %v", err)
+	}
+}
+
+func (h *CategoryHandler) ListCategories(w http.ResponseWriter, r *http.Request) {
+	categories, err := h.service.List(r.Context())
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(categories); err != nil {
+		log.Printf("Erro ao codificar JSON da lista de categorias: %v", err)
+	}
+}
+
+func (h *CategoryHandler) GetCategoryByID(w http.ResponseWriter, r *http.Request) {
+	categoryID, err := uuid.Parse(chi.URLParam(r, "categoryID"))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidID, "ID da categoria inválido")
+		return
+	}
+	category, err := h.service.GetByID(r.Context(), categoryID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(category); err != nil {
+		log.Printf("Erro ao codificar JSON da categoria: %v", err)
+	}
+}
+
+func (h *CategoryHandler) UpdateCategory(w http.ResponseWriter, r *http.Request) {
+	categoryID, err := uuid.Parse(chi.URLParam(r, "categoryID"))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidID, "ID da categoria inválido")
+		return
+	}
+	var category domain.Category
+	if !decodeJSON(w, r, &category) {
+		return
+	}
+	category.ID = categoryID
+	if err := h.service.Update(r.Context(), &category); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(category); err != nil {
+		log.Printf("Erro ao codificar JSON da categoria atualizada: %v", err)
+	}
+}
+
+func (h *CategoryHandler) DeleteCategory(w http.ResponseWriter, r *http.Request) {
+	categoryID, err := uuid.Parse(chi.URLParam(r, "categoryID"))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidID, "ID da categoria inválido")
+		return
+	}
+	if err := h.service.Delete(r.Context(), categoryID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListAttributes lista as definições de atributos efetivas da categoria.
+func (h *CategoryHandler) ListAttributes(w http.ResponseWriter, r *http.Request) {
+	categoryID, err := uuid.Parse(chi.URLParam(r, "categoryID"))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidID, "ID da categoria inválido")
+		return
+	}
+	definitions, err := h.service.ListAttributes(r.Context(), categoryID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(definitions); err != nil {
+		log.Printf("Erro ao codificar JSON dos atributos: %v", err)
+	}
+}
+
+// ReplaceAttributes substitui as definições de atributos declaradas na categoria.
+func (h *CategoryHandler) ReplaceAttributes(w http.ResponseWriter, r *http.Request) {
+	categoryID, err := uuid.Parse(chi.URLParam(r, "categoryID"))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidID, "ID da categoria inválido")
+		return
+	}
+	var definitions []domain.AttributeDefinition
+	if !decodeJSON(w, r, &definitions) {
+		return
+	}
+	saved, err := h.service.ReplaceAttributes(r.Context(), categoryID, definitions)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(saved); err != nil {
+		log.Printf("Erro ao codificar JSON dos atributos: %v", err)
+	}
+}
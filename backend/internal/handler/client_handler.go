@@ -14,22 +14,26 @@ import (
 
 // ClientHandler gerencia as requisições HTTP para clientes.
 type ClientHandler struct {
-	service *service.ClientService
+	service     *service.ClientService
+	idempotency *service.IdempotencyService
 }
 
 // NewClientHandler cria uma nova instância de ClientHandler.
-func NewClientHandler(s *service.ClientService) *ClientHandler {
-	return &ClientHandler{service: s}
+func NewClientHandler(s *service.ClientService, idempotency *service.IdempotencyService) *ClientHandler {
+	return &ClientHandler{service: s, idempotency: idempotency}
 }
 
 func (h *ClientHandler) CreateClient(w http.ResponseWriter, r *http.Request) {
+	r, ok := withIdempotency(w, r, h.idempotency, respondJSON(http.StatusCreated, asIs))
+	if !ok {
+		return
+	}
 	var client domain.Client
-	if err := json.NewDecoder(r.Body).Decode(&client); err != nil {
-		http.Error(w, "Corpo da requisição inválido", http.StatusBadRequest)
+	if !decodeJSON(w, r, &client) {
 		return
 	}
 	if err := h.service.Create(r.Context(), &client); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, r, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
@@ -39,10 +43,16 @@ func (h *ClientHandler) CreateClient(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// ListClients lista os clientes com paginação, busca (search), ordenação (sort) e
+// filtros por situação (status=active|blocked) e saldo (product=<id> ou has_stock=true).
 func (h *ClientHandler) ListClients(w http.ResponseWriter, r *http.Request) {
-	clients, err := h.service.List(r.Context())
+	filter, ok := clientFilterFromQuery(w, r)
+	if !ok {
+		return
+	}
+	clients, err := h.service.List(r.Context(), filter, parsePageRequest(r))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, r, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
@@ -52,16 +62,79 @@ func (h *ClientHandler) ListClients(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// clientFilterFromQuery lê os filtros e a ordenação da listagem de clientes da query
+// string. Em caso de parâmetro inválido, responde o erro e retorna false.
+func clientFilterFromQuery(w http.ResponseWriter, r *http.Request) (domain.ClientFilter, bool) {
+	filter := domain.ClientFilter{
+		Search:    r.URL.Query().Get("search"),
+		Status:    domain.ClientStatus(r.URL.Query().Get("status")),
+		WithStock: r.URL.Query().Get("has_stock") == "true",
+	}
+	if productStr := r.URL.Query().Get("product"); productStr != "" {
+		productID, err := uuid.Parse(productStr)
+		if err != nil {
+			writeProblem(w, r, http.StatusBadRequest, codeInvalidID, "ID do produto inválido")
+			return filter, false
+		}
+		filter.HoldingProductID = &productID
+	}
+	sort, err := service.ParseSort(r.URL.Query().Get("sort"), domain.ClientSortFields)
+	if err != nil {
+		writeError(w, r, err)
+		return filter, false
+	}
+	filter.Sort = sort
+	return filter, true
+}
+
+// ExportClients exporta os clientes em CSV, NDJSON ou XLSX (format=csv|ndjson|xlsx),
+// com os mesmos filtros e ordenação da listagem, sem paginação.
+func (h *ClientHandler) ExportClients(w http.ResponseWriter, r *http.Request) {
+	format, ok := parseExportFormat(w, r)
+	if !ok {
+		return
+	}
+	filter, ok := clientFilterFromQuery(w, r)
+	if !ok {
+		return
+	}
+	out := newExportWriter(w, r, format, "clientes", clientExportColumns)
+	out.finish(h.service.Export(r.Context(), filter, out.write))
+}
+
+// ExportHoldings exporta o estoque dos clientes, um saldo por linha com os dados do
+// cliente e do produto, em CSV, NDJSON ou XLSX. Aceita os filtros client=<id>,
+// product=<id> e has_stock=true (apenas saldos positivos).
+func (h *ClientHandler) ExportHoldings(w http.ResponseWriter, r *http.Request) {
+	format, ok := parseExportFormat(w, r)
+	if !ok {
+		return
+	}
+	filter := domain.HoldingFilter{WithStock: r.URL.Query().Get("has_stock") == "true"}
+	for param, target := range map[string]**uuid.UUID{"client": &filter.ClientID, "product": &filter.ProductID} {
+		if value := r.URL.Query().Get(param); value != "" {
+			id, err := uuid.Parse(value)
+			if err != nil {
+				writeProblem(w, r, http.StatusBadRequest, codeInvalidID, "ID inválido no parâmetro "+param)
+				return
+			}
+			*target = &id
+		}
+	}
+	out := newExportWriter(w, r, format, "estoque-clientes", holdingExportColumns)
+	out.finish(h.service.ExportHoldings(r.Context(), filter, out.write))
+}
+
 func (h *ClientHandler) GetClientByID(w http.ResponseWriter, r *http.Request) {
 	idStr := chi.URLParam(r, "clientID")
 	clientID, err := uuid.Parse(idStr)
 	if err != nil {
-		http.Error(w, "ID do cliente inválido", http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidID, "ID do cliente inválido")
 		return
 	}
 	client, err := h.service.GetByID(r.Context(), clientID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusNotFound)
+		writeError(w, r, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
@@ -75,17 +148,40 @@ func (h *ClientHandler) UpdateClient(w http.ResponseWriter, r *http.Request) {
 	idStr := chi.URLParam(r, "clientID")
 	clientID, err := uuid.Parse(idStr)
 	if err != nil {
-		http.Error(w, "ID do cliente inválido", http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidID, "ID do cliente inválido")
 		return
 	}
 	var client domain.Client
-	if err := json.NewDecoder(r.Body).Decode(&client); err != nil {
-		http.Error(w, "Corpo da requisição inválido", http.StatusBadRequest)
+	if !decodeJSON(w, r, &client) {
 		return
 	}
 	client.ID = clientID
 	if err := h.service.Update(r.Context(), &client); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(client); err != nil {
+		log.Printf("Erro ao codificar JSON do cliente atualizado: %v", err)
+	}
+}
+
+// PatchClient atualiza parcialmente um cliente a partir de um JSON Merge Patch (RFC 7396).
+func (h *ClientHandler) PatchClient(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "clientID")
+	clientID, err := uuid.Parse(idStr)
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidID, "ID do cliente inválido")
+		return
+	}
+	patch, ok := readMergePatch(w, r)
+	if !ok {
+		return
+	}
+	client, err := h.service.Patch(r.Context(), clientID, patch)
+	if err != nil {
+		writeError(w, r, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
@@ -99,11 +195,11 @@ func (h *ClientHandler) DeleteClient(w http.ResponseWriter, r *http.Request) {
 	idStr := chi.URLParam(r, "clientID")
 	clientID, err := uuid.Parse(idStr)
 	if err != nil {
-		http.Error(w, "ID do cliente inválido", http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidID, "ID do cliente inválido")
 		return
 	}
 	if err := h.service.Delete(r.Context(), clientID); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, r, err)
 		return
 	}
 	w.WriteHeader(http.StatusNoContent)
@@ -114,13 +210,13 @@ func (h *ClientHandler) ListStockByClientID(w http.ResponseWriter, r *http.Reque
 	idStr := chi.URLParam(r, "clientID")
 	clientID, err := uuid.Parse(idStr)
 	if err != nil {
-		http.Error(w, "ID do cliente inválido", http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidID, "ID do cliente inválido")
 		return
 	}
 
-	stocks, err := h.service.ListStockByClientID(r.Context(), clientID)
+	stocks, err := h.service.ListStockByClientID(r.Context(), clientID, parsePageRequest(r))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, r, err)
 		return
 	}
 
@@ -0,0 +1,281 @@
+package handler
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"controle-de-estoque/backend/internal/domain"
+	"controle-de-estoque/backend/internal/service"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// exportFormat é o formato do arquivo de uma exportação.
+type exportFormat string
+
+// Formatos aceitos no parâmetro format das exportações.
+const (
+	exportCSV    exportFormat = "csv"
+	exportNDJSON exportFormat = "ndjson" // Um objeto JSON por linha, com os campos da API
+	exportXLSX   exportFormat = "xlsx"
+)
+
+var exportContentTypes = map[exportFormat]string{
+	exportCSV:    "text/csv; charset=utf-8",
+	exportNDJSON: "application/x-ndjson",
+	exportXLSX:   "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// parseExportFormat lê o parâmetro format (csv, se ausente). Em caso de valor inválido,
+// responde o erro e retorna false.
+func parseExportFormat(w http.ResponseWriter, r *http.Request) (exportFormat, bool) {
+	format := exportFormat(r.URL.Query().Get("format"))
+	if format == "" {
+		return exportCSV, true
+	}
+	if _, ok := exportContentTypes[format]; !ok {
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidParameter, "format deve ser csv, ndjson ou xlsx")
+		return "", false
+	}
+	return format, true
+}
+
+// exportColumn é uma coluna das exportações em CSV e XLSX. value retorna uma string, um
+// número, um booleano ou nil (célula vazia).
+type exportColumn[T any] struct {
+	name  string
+	value func(T) any
+}
+
+var productExportColumns = []exportColumn[domain.Produto]{
+	{"id", func(p domain.Produto) any { return p.ID.String() }},
+	{"sku", func(p domain.Produto) any { return p.SKU }},
+	{"name", func(p domain.Produto) any { return p.Name }},
+	{"description", func(p domain.Produto) any { return p.Description }},
+	{"price_in_cents", func(p domain.Produto) any { return p.PriceInCents }},
+	{"currency", func(p domain.Produto) any { return string(p.Currency) }},
+	{"quantity", func(p domain.Produto) any { return p.Quantity }},
+	{"unit", func(p domain.Produto) any { return string(p.Unit) }},
+	{"category_id", func(p domain.Produto) any { return optionalID(p.CategoryID) }},
+	{"barcodes", func(p domain.Produto) any { return strings.Join(p.Barcodes, service.ImportListSeparator) }},
+	{"tags", func(p domain.Produto) any { return strings.Join(p.Tags, service.ImportListSeparator) }},
+	{"track_lots", func(p domain.Produto) any { return p.TrackLots }},
+	{"serialized", func(p domain.Produto) any { return p.Serialized }},
+	{"costing_method", func(p domain.Produto) any { return string(p.CostingMethod) }},
+	{"average_cost_in_cents", func(p domain.Produto) any { return p.AverageCostInCents }},
+	{"parent_id", func(p domain.Produto) any { return optionalID(p.ParentID) }},
+	{"attributes", func(p domain.Produto) any { return exportJSON(p.Attributes) }},
+	{"created_at", func(p domain.Produto) any { return p.CreatedAt.Format(time.RFC3339) }},
+	{"updated_at", func(p domain.Produto) any { return p.UpdatedAt.Format(time.RFC3339) }},
+}
+
+var clientExportColumns = []exportColumn[domain.Client]{
+	{"id", func(c domain.Client) any { return c.ID.String() }},
+	{"name", func(c domain.Client) any { return c.Name }},
+	{"email", func(c domain.Client) any { return c.Email }},
+	{"phone", func(c domain.Client) any { return c.Phone }},
+	{"document", func(c domain.Client) any { return c.Document }},
+	{"status", func(c domain.Client) any { return string(c.Status) }},
+	{"created_at", func(c domain.Client) any { return c.CreatedAt.Format(time.RFC3339) }},
+	{"updated_at", func(c domain.Client) any { return c.UpdatedAt.Format(time.RFC3339) }},
+}
+
+var holdingExportColumns = []exportColumn[domain.ClientHolding]{
+	{"client_id", func(h domain.ClientHolding) any { return h.ClientID.String() }},
+	{"client_name", func(h domain.ClientHolding) any { return h.ClientName }},
+	{"client_document", func(h domain.ClientHolding) any { return h.ClientDocument }},
+	{"product_id", func(h domain.ClientHolding) any { return h.ProductID.String() }},
+	{"sku", func(h domain.ClientHolding) any { return h.SKU }},
+	{"product_name", func(h domain.ClientHolding) any { return h.ProductName }},
+	{"unit", func(h domain.ClientHolding) any { return string(h.Unit) }},
+	{"quantity", func(h domain.ClientHolding) any { return h.Quantity }},
+}
+
+func optionalID[T fmt.Stringer](id *T) any {
+	if id == nil {
+		return nil
+	}
+	return (*id).String()
+}
+
+func exportJSON(v map[string]any) any {
+	if len(v) == 0 {
+		return nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	return string(data)
+}
+
+// exportWriter grava uma exportação item a item, à medida que as linhas chegam do banco.
+// A resposta só começa no primeiro item, de modo que um erro de filtro ou de consulta
+// ainda pode ser respondido como problema. No XLSX, a planilha é montada pelo
+// StreamWriter do excelize, que passa para um arquivo temporário quando cresce, e é
+// enviada ao final.
+type exportWriter[T any] struct {
+	w        http.ResponseWriter
+	r        *http.Request
+	format   exportFormat
+	name     string
+	columns  []exportColumn[T]
+	started  bool
+	sent     bool // Status e cabeçalhos já enviados
+	buffered *bufio.Writer
+	csv      *csv.Writer
+	json     *json.Encoder
+	xlsx     *excelize.File
+	sheet    *excelize.StreamWriter
+	rows     int
+}
+
+func newExportWriter[T any](w http.ResponseWriter, r *http.Request, format exportFormat, name string, columns []exportColumn[T]) *exportWriter[T] {
+	return &exportWriter[T]{w: w, r: r, format: format, name: name, columns: columns}
+}
+
+func (e *exportWriter[T]) start() error {
+	e.started = true
+	header := make([]string, len(e.columns))
+	for i, c := range e.columns {
+		header[i] = c.name
+	}
+
+	switch e.format {
+	case exportXLSX:
+		e.xlsx = excelize.NewFile()
+		sheet := e.xlsx.GetSheetName(0)
+		var err error
+		if e.sheet, err = e.xlsx.NewStreamWriter(sheet); err != nil {
+			return fmt.Errorf("erro ao criar planilha: %w", err)
+		}
+		values := make([]any, len(header))
+		for i, name := range header {
+			values[i] = name
+		}
+		return e.sheet.SetRow("A1", values)
+	case exportNDJSON:
+		e.sendHeaders()
+		e.buffered = bufio.NewWriter(e.w)
+		e.json = json.NewEncoder(e.buffered)
+		return nil
+	default:
+		e.sendHeaders()
+		e.csv = csv.NewWriter(e.w)
+		return e.csv.Write(header)
+	}
+}
+
+// sendHeaders envia o status e os cabeçalhos do arquivo. O WriteTimeout do servidor é
+// dimensionado para respostas curtas, então o prazo de escrita é removido.
+func (e *exportWriter[T]) sendHeaders() {
+	if err := http.NewResponseController(e.w).SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		log.Printf("Erro ao remover o prazo de escrita da exportação: %v", err)
+	}
+	fileName := fmt.Sprintf("%s-%s.%s", e.name, time.Now().Format(time.DateOnly), e.format)
+	e.w.Header().Set("Content-Type", exportContentTypes[e.format])
+	e.w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, fileName))
+	e.w.WriteHeader(http.StatusOK)
+	e.sent = true
+}
+
+// write grava um item; é passado como callback às exportações dos serviços.
+func (e *exportWriter[T]) write(item T) error {
+	if !e.started {
+		if err := e.start(); err != nil {
+			return err
+		}
+	}
+	switch e.format {
+	case exportNDJSON:
+		return e.json.Encode(item)
+	case exportXLSX:
+		if e.rows+1 >= excelize.TotalRows {
+			return fmt.Errorf("%w: a planilha XLSX comporta até %d linhas; use CSV ou NDJSON ou restrinja os filtros",
+				domain.ErrInvalidFilter, excelize.TotalRows-1)
+		}
+		e.rows++
+		values := make([]any, len(e.columns))
+		for i, c := range e.columns {
+			values[i] = c.value(item)
+		}
+		cell, err := excelize.CoordinatesToCellName(1, e.rows+1)
+		if err != nil {
+			return err
+		}
+		return e.sheet.SetRow(cell, values)
+	default:
+		record := make([]string, len(e.columns))
+		for i, c := range e.columns {
+			record[i] = csvValue(c.value(item))
+		}
+		return e.csv.Write(record)
+	}
+}
+
+// finish conclui a exportação, recebendo o erro da leitura, se houver. Uma exportação
+// vazia tem apenas o cabeçalho. Se a resposta já começou, não há como informar o erro:
+// a conexão é interrompida para que o cliente não tome o arquivo truncado por completo.
+func (e *exportWriter[T]) finish(err error) {
+	if e.xlsx != nil {
+		defer func() { _ = e.xlsx.Close() }()
+	}
+	if err == nil && !e.started {
+		err = e.start()
+	}
+	if err == nil {
+		err = e.flush()
+	}
+	if err == nil {
+		return
+	}
+	if !e.sent {
+		writeError(e.w, e.r, err)
+		return
+	}
+	if e.r.Context().Err() == nil {
+		log.Printf("Erro ao exportar %s: %v", e.name, err)
+	}
+	panic(http.ErrAbortHandler)
+}
+
+func (e *exportWriter[T]) flush() error {
+	switch e.format {
+	case exportNDJSON:
+		return e.buffered.Flush()
+	case exportXLSX:
+		if err := e.sheet.Flush(); err != nil {
+			return fmt.Errorf("erro ao gerar planilha: %w", err)
+		}
+		e.sendHeaders()
+		_, err := e.xlsx.WriteTo(e.w)
+		return err
+	default:
+		e.csv.Flush()
+		return e.csv.Error()
+	}
+}
+
+func csvValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	return fmt.Sprint(v)
+}
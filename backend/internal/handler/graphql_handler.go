@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"controle-de-estoque/backend/internal/graph"
+)
+
+// GraphQLHandler atende o endpoint GraphQL.
+type GraphQLHandler struct {
+	schema *graph.Schema
+}
+
+// NewGraphQLHandler cria uma nova instância de GraphQLHandler.
+func NewGraphQLHandler(schema *graph.Schema) *GraphQLHandler {
+	return &GraphQLHandler{schema: schema}
+}
+
+// Query executa uma requisição GraphQL. Como manda a convenção do GraphQL sobre HTTP,
+// erros de consulta e dos resolvers são devolvidos com status 200 no campo errors,
+// cada um com o código estável em extensions.code.
+func (h *GraphQLHandler) Query(w http.ResponseWriter, r *http.Request) {
+	var req graph.Request
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if req.Query == "" {
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidParameter, "O campo query é obrigatório")
+		return
+	}
+
+	response := h.schema.Exec(r.Context(), req)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		log.Printf("Erro ao codificar JSON da resposta GraphQL: %v", err)
+	}
+}
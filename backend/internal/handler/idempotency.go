@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"controle-de-estoque/backend/internal/domain"
+	"controle-de-estoque/backend/internal/service"
+)
+
+const (
+	// idempotencyKeyHeader identifica tentativas repetidas da mesma operação.
+	idempotencyKeyHeader = "Idempotency-Key"
+	// idempotentReplayedHeader marca as respostas repetidas a partir de uma chave já usada.
+	idempotentReplayedHeader = "Idempotent-Replayed"
+	// maxIdempotencyKeyLength limita o tamanho da chave aceita.
+	maxIdempotencyKeyLength = 255
+
+	codeInvalidIdempotencyKey = "invalid_idempotency_key"
+)
+
+// withIdempotency trata o cabeçalho Idempotency-Key de uma operação de escrita. Sem o
+// cabeçalho, devolve a própria requisição. Se a chave já foi usada com a mesma
+// requisição, repete a resposta gravada; com outra requisição, responde com erro. Nos
+// dois casos ok retorna false. Do contrário, devolve a requisição com a chave no
+// contexto e respond, que monta a resposta gravada a partir do resultado do serviço.
+func withIdempotency(w http.ResponseWriter, r *http.Request, idempotency *service.IdempotencyService, respond func(result any) (int, []byte, error)) (_ *http.Request, ok bool) {
+	key := r.Header.Get(idempotencyKeyHeader)
+	if key == "" {
+		return r, true
+	}
+	if len(key) > maxIdempotencyKeyLength || !isPrintableASCII(key) {
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidIdempotencyKey,
+			fmt.Sprintf("O cabeçalho %s deve ter até %d caracteres ASCII imprimíveis", idempotencyKeyHeader, maxIdempotencyKeyLength))
+		return nil, false
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxJSONBodyBytes))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeProblem(w, r, http.StatusRequestEntityTooLarge, codePayloadTooLarge, fmt.Sprintf("Corpo da requisição excede %d bytes", maxBytesErr.Limit))
+		} else {
+			writeError(w, r, fmt.Errorf("%w: %v", domain.ErrInvalidRequestBody, err))
+		}
+		return nil, false
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	hash := sha256.New()
+	// A mesma operação pela rota legada ou pela versionada deve repetir a resposta.
+	fmt.Fprintf(hash, "%s %s\n", r.Method, strings.TrimPrefix(r.URL.Path, APIV1Prefix))
+	hash.Write(body)
+
+	userID, _ := r.Context().Value(UserIDContextKey).(string)
+	req := &service.IdempotencyRequest{
+		Scope:       userID,
+		Key:         key,
+		RequestHash: hex.EncodeToString(hash.Sum(nil)),
+		Respond:     respond,
+	}
+
+	record, err := idempotency.Lookup(r.Context(), req)
+	if err != nil {
+		writeError(w, r, err)
+		return nil, false
+	}
+	if record != nil {
+		writeReplay(w, record)
+		return nil, false
+	}
+	return r.WithContext(service.WithIdempotency(r.Context(), req)), true
+}
+
+// respondJSON monta uma resposta gravada idêntica à escrita pelo handler com json.Encoder.
+func respondJSON(status int, wrap func(result any) any) func(result any) (int, []byte, error) {
+	return func(result any) (int, []byte, error) {
+		var buf bytes.Buffer
+		if err := json.NewEncoder(&buf).Encode(wrap(result)); err != nil {
+			return 0, nil, err
+		}
+		return status, buf.Bytes(), nil
+	}
+}
+
+// asIs devolve o resultado do serviço sem alterações, para uso com respondJSON.
+func asIs(result any) any { return result }
+
+// movementWith envolve a movimentação retornada pelo serviço na resposta de writeStockMovement.
+func movementWith(message string) func(result any) any {
+	return func(result any) any {
+		movement, _ := result.(*domain.StockMovement)
+		return stockMovementResponse{Message: message, Movement: movement}
+	}
+}
+
+// writeReplay repete a resposta gravada para uma chave de idempotência.
+func writeReplay(w http.ResponseWriter, record *domain.IdempotencyRecord) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set(idempotentReplayedHeader, "true")
+	w.WriteHeader(record.StatusCode)
+	if _, err := w.Write(record.ResponseBody); err != nil {
+		log.Printf("Erro ao repetir resposta idempotente: %v", err)
+	}
+}
+
+func isPrintableASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < 0x20 || s[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
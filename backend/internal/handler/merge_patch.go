@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+
+	"controle-de-estoque/backend/internal/domain"
+)
+
+const (
+	// mergePatchContentType é o media type definido pela RFC 7396.
+	mergePatchContentType = "application/merge-patch+json"
+	// maxMergePatchBytes limita o tamanho do corpo aceito em requisições PATCH.
+	maxMergePatchBytes = 1 << 20
+)
+
+// readMergePatch valida o Content-Type e lê o corpo de uma requisição PATCH.
+// Em caso de falha, a resposta de erro já é escrita e ok retorna false.
+func readMergePatch(w http.ResponseWriter, r *http.Request) (patch []byte, ok bool) {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || (mediaType != mergePatchContentType && mediaType != "application/json") {
+		writeProblem(w, r, http.StatusUnsupportedMediaType, codeUnsupportedMediaType, "Content-Type deve ser "+mergePatchContentType)
+		return nil, false
+	}
+
+	patch, err = io.ReadAll(http.MaxBytesReader(w, r.Body, maxMergePatchBytes))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeProblem(w, r, http.StatusRequestEntityTooLarge, codePayloadTooLarge, fmt.Sprintf("Corpo da requisição excede %d bytes", maxBytesErr.Limit))
+		} else {
+			writeError(w, r, fmt.Errorf("%w: %v", domain.ErrInvalidRequestBody, err))
+		}
+		return nil, false
+	}
+	return patch, true
+}
@@ -2,8 +2,10 @@ package handler
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"controle-de-estoque/backend/internal/service"
 )
@@ -14,13 +16,13 @@ func AuthMiddleware(tokenService service.TokenGenerator) func(http.Handler) http
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			authHeader := r.Header.Get("Authorization")
 			if authHeader == "" {
-				http.Error(w, "Cabeçalho de autorização ausente", http.StatusUnauthorized)
+				writeProblem(w, r, http.StatusUnauthorized, codeUnauthorized, "Cabeçalho de autorização ausente")
 				return
 			}
 
 			parts := strings.Split(authHeader, " ")
 			if len(parts) != 2 || parts[0] != "Bearer" {
-				http.Error(w, "Cabeçalho de autorização mal formatado", http.StatusUnauthorized)
+				writeProblem(w, r, http.StatusUnauthorized, codeUnauthorized, "Cabeçalho de autorização mal formatado")
 				return
 			}
 
@@ -29,7 +31,7 @@ func AuthMiddleware(tokenService service.TokenGenerator) func(http.Handler) http
 			// Valida o token e recupera o userID
 			userIDPtr, err := tokenService.ValidateToken(tokenString)
 			if err != nil || userIDPtr == nil {
-				http.Error(w, "Token inválido ou expirado", http.StatusUnauthorized)
+				writeProblem(w, r, http.StatusUnauthorized, codeUnauthorized, "Token inválido ou expirado")
 				return
 			}
 
@@ -39,3 +41,21 @@ func AuthMiddleware(tokenService service.TokenGenerator) func(http.Handler) http
 		})
 	}
 }
+
+// APIV1Prefix é o prefixo das rotas da versão atual da API.
+const APIV1Prefix = "/api/v1"
+
+// Deprecated marca as respostas de rotas descontinuadas com os cabeçalhos Deprecation
+// (RFC 9745) e Sunset (RFC 8594), e indica em Link a rota equivalente sob successorPrefix.
+func Deprecated(since, sunset time.Time, successorPrefix string) func(http.Handler) http.Handler {
+	deprecation := fmt.Sprintf("@%d", since.Unix())
+	sunsetDate := sunset.UTC().Format(http.TimeFormat)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Deprecation", deprecation)
+			w.Header().Set("Sunset", sunsetDate)
+			w.Header().Set("Link", fmt.Sprintf(`<%s%s>; rel="successor-version"`, successorPrefix, r.URL.Path))
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	_ "embed"
+	"log"
+	"net/http"
+)
+
+// openAPISpec é o documento OpenAPI 3.1 que descreve todas as rotas da API. Deve ser
+// atualizado junto com as rotas registradas em setupRouter; o teste de rotas em
+// cmd/api falha se alguma rota estiver ausente do documento.
+//
+//go:embed openapi.json
+var openAPISpec []byte
+
+// swaggerUIPage carrega o Swagger UI a partir de uma CDN, apontando para o openapi.json
+// servido ao lado da página.
+const swaggerUIPage = `<!DOCTYPE html>
+<html lang="pt-BR">
+<head>
+  <meta charset="utf-8">
+  <title>Controle de Estoque API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js" crossorigin></script>
+  <script>
+    window.onload = () => {
+      window.ui = SwaggerUIBundle({ url: 'openapi.json', dom_id: '#swagger-ui', persistAuthorization: true });
+    };
+  </script>
+</body>
+</html>
+`
+
+// OpenAPISpec serve o documento OpenAPI da API (GET /api/v1/openapi.json).
+func OpenAPISpec(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(openAPISpec); err != nil {
+		log.Printf("Erro ao enviar o documento OpenAPI: %v", err)
+	}
+}
+
+// SwaggerUI serve a documentação interativa da API (GET /api/v1/docs).
+func SwaggerUI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write([]byte(swaggerUIPage)); err != nil {
+		log.Printf("Erro ao enviar a página de documentação: %v", err)
+	}
+}
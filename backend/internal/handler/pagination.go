@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"controle-de-estoque/backend/internal/domain"
+)
+
+// parsePageRequest lê limit, page, cursor e include_total da query string. O limite
+// é restrito a domain.MaxPageSize. Sem cursor, os totais são calculados por padrão
+// (compatível com a paginação por página); com cursor, apenas se include_total=true.
+func parsePageRequest(r *http.Request) domain.PageRequest {
+	q := r.URL.Query()
+	page := domain.PageRequest{Cursor: q.Get("cursor")}
+
+	page.Limit, _ = strconv.Atoi(q.Get("limit"))
+	if page.Limit < 1 {
+		page.Limit = domain.DefaultPageSize
+	}
+	page.Limit = min(page.Limit, domain.MaxPageSize)
+
+	page.Page, _ = strconv.Atoi(q.Get("page"))
+	if page.Page < 1 {
+		page.Page = 1
+	}
+
+	switch q.Get("include_total") {
+	case "true":
+		page.IncludeTotal = true
+	case "false":
+		page.IncludeTotal = false
+	default:
+		page.IncludeTotal = page.Cursor == ""
+	}
+	return page
+}
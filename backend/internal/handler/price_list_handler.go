@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"controle-de-estoque/backend/internal/domain"
+	"controle-de-estoque/backend/internal/service"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// PriceListHandler gerencia as requisições HTTP das tabelas de preços e da sua
+// atribuição aos clientes.
+type PriceListHandler struct {
+	service *service.PriceListService
+}
+
+// NewPriceListHandler cria uma nova instância de PriceListHandler.
+func NewPriceListHandler(s *service.PriceListService) *PriceListHandler {
+	return &PriceListHandler{service: s}
+}
+
+func (h *PriceListHandler) CreatePriceList(w http.ResponseWriter, r *http.Request) {
+	var list domain.PriceList
+	if !decodeJSON(w, r, &list) {
+		return
+	}
+	if err := h.service.Create(r.Context(), &list); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(list); err != nil {
+		log.Printf("Erro ao codificar JSON da tabela de preços: %v", err)
+	}
+}
+
+func (h *PriceListHandler) ListPriceLists(w http.ResponseWriter, r *http.Request) {
+	lists, err := h.service.List(r.Context())
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(lists); err != nil {
+		log.Printf("Erro ao codificar JSON da lista de tabelas de preços: %v", err)
+	}
+}
+
+func (h *PriceListHandler) GetPriceList(w http.ResponseWriter, r *http.Request) {
+	listID, ok := priceListIDParam(w, r)
+	if !ok {
+		return
+	}
+	list, err := h.service.GetByID(r.Context(), listID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(list); err != nil {
+		log.Printf("Erro ao codificar JSON da tabela de preços: %v", err)
+	}
+}
+
+// UpdatePriceList substitui os dados e os itens de uma tabela de preços.
+func (h *PriceListHandler) UpdatePriceList(w http.ResponseWriter, r *http.Request) {
+	listID, ok := priceListIDParam(w, r)
+	if !ok {
+		return
+	}
+	var list domain.PriceList
+	if !decodeJSON(w, r, &list) {
+		return
+	}
+	list.ID = listID
+	if err := h.service.Update(r.Context(), &list); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(list); err != nil {
+		log.Printf("Erro ao codificar JSON da tabela de preços: %v", err)
+	}
+}
+
+func (h *PriceListHandler) DeletePriceList(w http.ResponseWriter, r *http.Request) {
+	listID, ok := priceListIDParam(w, r)
+	if !ok {
+		return
+	}
+	if err := h.service.Delete(r.Context(), listID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListClientPriceLists lista as tabelas de preços atribuídas a um cliente.
+func (h *PriceListHandler) ListClientPriceLists(w http.ResponseWriter, r *http.Request) {
+	clientID, err := uuid.Parse(chi.URLParam(r, "clientID"))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidID, "ID do cliente inválido")
+		return
+	}
+	lists, err := h.service.ListForClient(r.Context(), clientID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(lists); err != nil {
+		log.Printf("Erro ao codificar JSON das tabelas de preços do cliente: %v", err)
+	}
+}
+
+// AssignClientPriceLists substitui as tabelas de preços atribuídas a um cliente.
+func (h *PriceListHandler) AssignClientPriceLists(w http.ResponseWriter, r *http.Request) {
+	clientID, err := uuid.Parse(chi.URLParam(r, "clientID"))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidID, "ID do cliente inválido")
+		return
+	}
+	var req struct {
+		PriceListIDs []uuid.UUID `json:"price_list_ids"`
+	}
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	lists, err := h.service.AssignToClient(r.Context(), clientID, req.PriceListIDs)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(lists); err != nil {
+		log.Printf("Erro ao codificar JSON das tabelas de preços do cliente: %v", err)
+	}
+}
+
+func priceListIDParam(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	listID, err := uuid.Parse(chi.URLParam(r, "priceListID"))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidID, "ID da tabela de preços inválido")
+		return uuid.Nil, false
+	}
+	return listID, true
+}
@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"controle-de-estoque/backend/internal/domain"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+const (
+	// problemContentType é o media type definido pela RFC 7807.
+	problemContentType = "application/problem+json"
+	// problemTypePrefix forma o campo type de cada problema a partir do seu código.
+	problemTypePrefix = "urn:controle-de-estoque:problem:"
+)
+
+// Códigos dos problemas detectados pelos próprios handlers, antes de chegar aos serviços.
+const (
+	codeInvalidID            = "invalid_id"
+	codeInvalidParameter     = "invalid_parameter"
+	codePayloadTooLarge      = "payload_too_large"
+	codeRequestTimeout       = "request_timeout"
+	codeUnsupportedMediaType = "unsupported_media_type"
+	codeUnauthorized         = "unauthorized"
+	codeInternal             = "internal_error"
+)
+
+// problem é o corpo das respostas de erro (RFC 7807). Code é estável e deve ser usado
+// pelos clientes para tratar cada caso; Detail é uma mensagem legível, sujeita a mudanças.
+type problem struct {
+	Type      string              `json:"type"`
+	Title     string              `json:"title"`
+	Status    int                 `json:"status"`
+	Detail    string              `json:"detail,omitempty"`
+	Instance  string              `json:"instance,omitempty"`
+	Code      string              `json:"code"`
+	RequestID string              `json:"request_id,omitempty"`
+	Errors    []domain.FieldError `json:"errors,omitempty"` // Apenas em erros de validação
+}
+
+// kindStatus associa cada categoria de erro de domínio ao status HTTP da resposta.
+var kindStatus = map[domain.ErrorKind]int{
+	domain.KindValidation:        http.StatusUnprocessableEntity,
+	domain.KindBadRequest:        http.StatusBadRequest,
+	domain.KindNotFound:          http.StatusNotFound,
+	domain.KindConflict:          http.StatusConflict,
+	domain.KindInsufficientStock: http.StatusConflict,
+	domain.KindUnauthorized:      http.StatusUnauthorized,
+	domain.KindInternal:          http.StatusInternalServerError,
+}
+
+// writeProblem escreve uma resposta application/problem+json.
+func writeProblem(w http.ResponseWriter, r *http.Request, status int, code, detail string) {
+	sendProblem(w, r, problem{Status: status, Code: code, Detail: detail})
+}
+
+// writeError traduz um erro dos serviços para a resposta adequada. Erros de domínio
+// usam o status da sua categoria e o seu código; os demais são registrados no log e
+// respondidos como 500, sem expor a mensagem original (ex.: erros do banco). Uma
+// operação interrompida por uma chave de idempotência já concluída repete a resposta gravada.
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	var validationErr *domain.ValidationError
+	var domainErr *domain.Error
+	var replayErr *domain.IdempotentReplayError
+	switch {
+	case errors.As(err, &replayErr):
+		writeReplay(w, replayErr.Record)
+	case errors.As(err, &validationErr):
+		p := problem{Status: http.StatusUnprocessableEntity, Code: "validation_failed", Detail: err.Error(), Errors: validationErr.Fields}
+		if errors.As(validationErr.Err, &domainErr) {
+			p.Code = domainErr.Code
+		}
+		sendProblem(w, r, p)
+	case errors.As(err, &domainErr):
+		status, ok := kindStatus[domainErr.Kind]
+		if !ok {
+			status = http.StatusInternalServerError
+		}
+		sendProblem(w, r, problem{Status: status, Code: domainErr.Code, Detail: err.Error()})
+	default:
+		log.Printf("Erro interno [%s] %s %s: %v", middleware.GetReqID(r.Context()), r.Method, r.URL.Path, err)
+		writeProblem(w, r, http.StatusInternalServerError, codeInternal, "Erro interno no servidor")
+	}
+}
+
+func sendProblem(w http.ResponseWriter, r *http.Request, p problem) {
+	p.Type = problemTypePrefix + p.Code
+	p.Title = http.StatusText(p.Status)
+	p.Instance = r.URL.Path
+	p.RequestID = middleware.GetReqID(r.Context())
+	if p.RequestID != "" {
+		w.Header().Set(middleware.RequestIDHeader, p.RequestID)
+	}
+
+	w.Header().Set("Content-Type", problemContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(p.Status)
+	if err := json.NewEncoder(w).Encode(p); err != nil {
+		log.Printf("Erro ao codificar JSON do problema: %v", err)
+	}
+}
+
+// NotFound responde rotas inexistentes no formato application/problem+json.
+func NotFound(w http.ResponseWriter, r *http.Request) {
+	writeProblem(w, r, http.StatusNotFound, "route_not_found", "Rota não encontrada")
+}
+
+// MethodNotAllowed responde métodos não suportados pela rota no formato application/problem+json.
+func MethodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	writeProblem(w, r, http.StatusMethodNotAllowed, "method_not_allowed", "Método não permitido para esta rota")
+}
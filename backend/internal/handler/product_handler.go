@@ -3,13 +3,14 @@ package handler
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"controle-de-estoque/backend/internal/domain"
-	"controle-de-estoque/backend/internal/repository"
 	"controle-de-estoque/backend/internal/service"
 
 	"github.com/go-chi/chi/v5"
@@ -17,22 +18,26 @@ import (
 )
 
 type ProductHandler struct {
-	service *service.ProductService
+	service     *service.ProductService
+	idempotency *service.IdempotencyService
 }
 
-func NewProductHandler(s *service.ProductService) *ProductHandler {
-	return &ProductHandler{service: s}
+func NewProductHandler(s *service.ProductService, idempotency *service.IdempotencyService) *ProductHandler {
+	return &ProductHandler{service: s, idempotency: idempotency}
 }
 
 func (h *ProductHandler) CreateProduct(w http.ResponseWriter, r *http.Request) {
+	r, ok := withIdempotency(w, r, h.idempotency, respondJSON(http.StatusCreated, asIs))
+	if !ok {
+		return
+	}
 	var product domain.Produto
-	if err := json.NewDecoder(r.Body).Decode(&product); err != nil {
-		http.Error(w, "Erro ao decodificar o JSON", http.StatusBadRequest)
+	if !decodeJSON(w, r, &product) {
 		return
 	}
 	err := h.service.CreateProduct(r.Context(), &product)
 	if err != nil {
-		http.Error(w, "Erro ao criar o produto", http.StatusInternalServerError)
+		writeError(w, r, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
@@ -43,18 +48,13 @@ func (h *ProductHandler) CreateProduct(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *ProductHandler) ListProducts(w http.ResponseWriter, r *http.Request) {
-	search := r.URL.Query().Get("search")
-	page, err := strconv.Atoi(r.URL.Query().Get("page"))
-	if err != nil || page < 1 {
-		page = 1
-	}
-	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
-	if err != nil || limit < 1 {
-		limit = 10
+	filter, ok := productFilterFromQuery(w, r)
+	if !ok {
+		return
 	}
-	response, err := h.service.ListProducts(r.Context(), search, page, limit)
+	response, err := h.service.ListProducts(r.Context(), filter, parsePageRequest(r))
 	if err != nil {
-		http.Error(w, "Erro ao listar os produtos", http.StatusInternalServerError)
+		writeError(w, r, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
@@ -64,20 +64,132 @@ func (h *ProductHandler) ListProducts(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// ExportProducts exporta os produtos em CSV, NDJSON ou XLSX (format=csv|ndjson|xlsx),
+// com os mesmos filtros e ordenação da listagem, sem paginação. As colunas do CSV usam
+// os nomes aceitos pela importação.
+func (h *ProductHandler) ExportProducts(w http.ResponseWriter, r *http.Request) {
+	format, ok := parseExportFormat(w, r)
+	if !ok {
+		return
+	}
+	filter, ok := productFilterFromQuery(w, r)
+	if !ok {
+		return
+	}
+	out := newExportWriter(w, r, format, "produtos", productExportColumns)
+	out.finish(h.service.ExportProducts(r.Context(), filter, out.write))
+}
+
+// productFilterFromQuery lê os filtros e a ordenação da listagem de produtos da query
+// string (search, category, attr.<chave>, tag, include_variants, faixas e sort). Em caso
+// de parâmetro inválido, responde o erro e retorna false.
+func productFilterFromQuery(w http.ResponseWriter, r *http.Request) (domain.ProductFilter, bool) {
+	filter := domain.ProductFilter{Search: r.URL.Query().Get("search")}
+	if categoryStr := r.URL.Query().Get("category"); categoryStr != "" {
+		categoryID, err := uuid.Parse(categoryStr)
+		if err != nil {
+			writeProblem(w, r, http.StatusBadRequest, codeInvalidID, "ID da categoria inválido")
+			return filter, false
+		}
+		filter.CategoryID = &categoryID
+	}
+	for param, values := range r.URL.Query() {
+		key, isAttr := strings.CutPrefix(param, "attr.")
+		if !isAttr {
+			continue
+		}
+		if !service.ValidAttributeKey(key) {
+			writeProblem(w, r, http.StatusBadRequest, codeInvalidParameter, "Filtro de atributo inválido: "+param)
+			return filter, false
+		}
+		if filter.Attributes == nil {
+			filter.Attributes = make(map[string]string)
+		}
+		filter.Attributes[key] = values[0]
+	}
+	filter.Tags = service.NormalizeTags(r.URL.Query()["tag"])
+	filter.IncludeVariants = r.URL.Query().Get("include_variants") == "true"
+	if err := parseProductRangeFilters(r, &filter); err != nil {
+		writeError(w, r, err)
+		return filter, false
+	}
+	sort, err := service.ParseSort(r.URL.Query().Get("sort"), domain.ProductSortFields)
+	if err != nil {
+		writeError(w, r, err)
+		return filter, false
+	}
+	filter.Sort = sort
+	return filter, true
+}
+
+// parseProductRangeFilters lê min_price, max_price (em centavos), min_qty, max_qty e
+// updated_since (RFC 3339 ou AAAA-MM-DD) da query string.
+func parseProductRangeFilters(r *http.Request, filter *domain.ProductFilter) error {
+	q := r.URL.Query()
+	for param, target := range map[string]**int64{"min_price": &filter.MinPriceInCents, "max_price": &filter.MaxPriceInCents} {
+		if value := q.Get(param); value != "" {
+			n, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return fmt.Errorf("%w: %s deve ser um valor inteiro em centavos", domain.ErrInvalidFilter, param)
+			}
+			*target = &n
+		}
+	}
+	for param, target := range map[string]**int{"min_qty": &filter.MinQuantity, "max_qty": &filter.MaxQuantity} {
+		if value := q.Get(param); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("%w: %s deve ser um número inteiro", domain.ErrInvalidFilter, param)
+			}
+			*target = &n
+		}
+	}
+	if value := q.Get("updated_since"); value != "" {
+		since, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			if since, err = time.Parse(time.DateOnly, value); err != nil {
+				return fmt.Errorf("%w: updated_since deve estar no formato RFC 3339 ou AAAA-MM-DD", domain.ErrInvalidFilter)
+			}
+		}
+		filter.UpdatedSince = &since
+	}
+	return nil
+}
+
 func (h *ProductHandler) GetProductByID(w http.ResponseWriter, r *http.Request) {
 	idStr := strings.TrimSpace(chi.URLParam(r, "productID"))
 	productID, err := uuid.Parse(idStr)
 	if err != nil {
-		http.Error(w, "ID do produto inválido", http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidID, "ID do produto inválido")
 		return
 	}
 	product, err := h.service.GetProductByID(r.Context(), productID)
 	if err != nil {
-		if errors.Is(err, repository.ErrProductNotFound) {
-			http.Error(w, err.Error(), http.StatusNotFound)
+		writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(product); err != nil {
+		log.Printf("Erro ao encodar a resposta JSON: %v", err)
+	}
+}
+
+// LookupProduct busca um produto pelo código de barras (GET /products/lookup?barcode=).
+func (h *ProductHandler) LookupProduct(w http.ResponseWriter, r *http.Request) {
+	barcode := r.URL.Query().Get("barcode")
+	if barcode == "" {
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidParameter, "Parâmetro barcode é obrigatório")
+		return
+	}
+	product, err := h.service.GetProductByBarcode(r.Context(), barcode)
+	if err != nil {
+		// Um código malformado na consulta é erro do parâmetro, não do corpo da requisição.
+		if errors.Is(err, domain.ErrInvalidBarcode) {
+			writeProblem(w, r, http.StatusBadRequest, domain.ErrInvalidBarcode.Code, err.Error())
 			return
 		}
-		http.Error(w, "Erro ao buscar o produto", http.StatusInternalServerError)
+		writeError(w, r, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
@@ -91,21 +203,40 @@ func (h *ProductHandler) UpdateProduct(w http.ResponseWriter, r *http.Request) {
 	idStr := strings.TrimSpace(chi.URLParam(r, "productID"))
 	productID, err := uuid.Parse(idStr)
 	if err != nil {
-		http.Error(w, "ID do produto inválido", http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidID, "ID do produto inválido")
 		return
 	}
 	var productFromRequest domain.Produto
-	if err := json.NewDecoder(r.Body).Decode(&productFromRequest); err != nil {
-		http.Error(w, "Erro ao decodificar o JSON", http.StatusBadRequest)
+	if !decodeJSON(w, r, &productFromRequest) {
 		return
 	}
 	updatedProduct, err := h.service.UpdateProduct(r.Context(), productID, productFromRequest)
 	if err != nil {
-		if errors.Is(err, repository.ErrProductNotFound) {
-			http.Error(w, err.Error(), http.StatusNotFound)
-			return
-		}
-		http.Error(w, "Erro ao atualizar o produto", http.StatusInternalServerError)
+		writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(updatedProduct); err != nil {
+		log.Printf("Erro ao encodar a resposta JSON: %v", err)
+	}
+}
+
+// PatchProduct atualiza parcialmente um produto a partir de um JSON Merge Patch (RFC 7396).
+func (h *ProductHandler) PatchProduct(w http.ResponseWriter, r *http.Request) {
+	idStr := strings.TrimSpace(chi.URLParam(r, "productID"))
+	productID, err := uuid.Parse(idStr)
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidID, "ID do produto inválido")
+		return
+	}
+	patch, ok := readMergePatch(w, r)
+	if !ok {
+		return
+	}
+	updatedProduct, err := h.service.PatchProduct(r.Context(), productID, patch)
+	if err != nil {
+		writeError(w, r, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
@@ -119,19 +250,106 @@ func (h *ProductHandler) DeleteProduct(w http.ResponseWriter, r *http.Request) {
 	idStr := strings.TrimSpace(chi.URLParam(r, "productID"))
 	productID, err := uuid.Parse(idStr)
 	if err != nil {
-		http.Error(w, "ID do produto inválido", http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidID, "ID do produto inválido")
 		return
 	}
 	err = h.service.DeleteProduct(r.Context(), productID)
 	if err != nil {
-		if errors.Is(err, repository.ErrProductNotFound) {
-			http.Error(w, err.Error(), http.StatusNotFound)
+		writeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListVariants lista as variantes de um produto pai.
+func (h *ProductHandler) ListVariants(w http.ResponseWriter, r *http.Request) {
+	productID, err := uuid.Parse(chi.URLParam(r, "productID"))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidID, "ID do produto inválido")
+		return
+	}
+	variants, err := h.service.ListVariants(r.Context(), productID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(variants); err != nil {
+		log.Printf("Erro ao encodar a resposta JSON: %v", err)
+	}
+}
+
+// GenerateVariants define os eixos de variação e cria as variantes que ainda não existem.
+func (h *ProductHandler) GenerateVariants(w http.ResponseWriter, r *http.Request) {
+	productID, err := uuid.Parse(chi.URLParam(r, "productID"))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidID, "ID do produto inválido")
+		return
+	}
+	var req service.GenerateVariantsRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	variants, err := h.service.GenerateVariants(r.Context(), productID, req)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(variants); err != nil {
+		log.Printf("Erro ao encodar a resposta JSON: %v", err)
+	}
+}
+
+// ListLots lista os lotes de um produto em ordem FEFO.
+func (h *ProductHandler) ListLots(w http.ResponseWriter, r *http.Request) {
+	productID, err := uuid.Parse(chi.URLParam(r, "productID"))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidID, "ID do produto inválido")
+		return
+	}
+	lots, err := h.service.ListLots(r.Context(), productID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(lots); err != nil {
+		log.Printf("Erro ao encodar a resposta JSON: %v", err)
+	}
+}
+
+// ListExpiringLots lista os lotes com saldo que vencem dentro da janela informada
+// (GET /lots/expiring?within=30d). Sem o parâmetro, usa 30 dias.
+func (h *ProductHandler) ListExpiringLots(w http.ResponseWriter, r *http.Request) {
+	within := 30
+	if value := r.URL.Query().Get("within"); value != "" {
+		days, err := service.ParseDaysWindow(value)
+		if err != nil {
+			writeProblem(w, r, http.StatusBadRequest, codeInvalidParameter, err.Error())
 			return
 		}
-		http.Error(w, "Erro ao deletar o produto", http.StatusInternalServerError)
+		within = days
+	}
+	lots, err := h.service.ListExpiringLots(r.Context(), within)
+	if err != nil {
+		writeError(w, r, err)
 		return
 	}
-	w.WriteHeader(http.StatusNoContent)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(lots); err != nil {
+		log.Printf("Erro ao encodar a resposta JSON: %v", err)
+	}
+}
+
+// stockMovementResponse é a resposta das operações que alteram o estoque global.
+type stockMovementResponse struct {
+	Message  string                `json:"message"`
+	Movement *domain.StockMovement `json:"movement"`
 }
 
 // TransferStock realiza a transferência de estoque global para o estoque de um cliente.
@@ -139,24 +357,194 @@ func (h *ProductHandler) TransferStock(w http.ResponseWriter, r *http.Request) {
 	productIDStr := chi.URLParam(r, "productID")
 	productID, err := uuid.Parse(productIDStr)
 	if err != nil {
-		http.Error(w, "ID do produto inválido", http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidID, "ID do produto inválido")
+		return
+	}
+
+	const message = "Transferência de estoque realizada com sucesso."
+	r, ok := withIdempotency(w, r, h.idempotency, respondJSON(http.StatusOK, movementWith(message)))
+	if !ok {
 		return
 	}
 
 	var req service.TransferStockRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Corpo da requisição inválido", http.StatusBadRequest)
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	movement, err := h.service.TransferStock(r.Context(), productID, req)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeStockMovement(w, message, movement)
+}
+
+// ReceiveStock registra uma entrada de mercadoria no estoque global do produto.
+func (h *ProductHandler) ReceiveStock(w http.ResponseWriter, r *http.Request) {
+	productID, err := uuid.Parse(chi.URLParam(r, "productID"))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidID, "ID do produto inválido")
+		return
+	}
+
+	const message = "Recebimento registrado com sucesso."
+	r, ok := withIdempotency(w, r, h.idempotency, respondJSON(http.StatusOK, movementWith(message)))
+	if !ok {
+		return
+	}
+
+	var req service.ReceiveStockRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	movement, err := h.service.ReceiveStock(r.Context(), productID, req)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeStockMovement(w, message, movement)
+}
+
+// AdjustStock aplica um ajuste manual ao estoque global do produto.
+func (h *ProductHandler) AdjustStock(w http.ResponseWriter, r *http.Request) {
+	productID, err := uuid.Parse(chi.URLParam(r, "productID"))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidID, "ID do produto inválido")
+		return
+	}
+
+	const message = "Ajuste de estoque registrado com sucesso."
+	r, ok := withIdempotency(w, r, h.idempotency, respondJSON(http.StatusOK, movementWith(message)))
+	if !ok {
+		return
+	}
+
+	var req service.AdjustStockRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	movement, err := h.service.AdjustStock(r.Context(), productID, req)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeStockMovement(w, message, movement)
+}
+
+// ReturnStock registra a devolução de mercadoria de um cliente ao estoque global do produto.
+func (h *ProductHandler) ReturnStock(w http.ResponseWriter, r *http.Request) {
+	productID, err := uuid.Parse(chi.URLParam(r, "productID"))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidID, "ID do produto inválido")
+		return
+	}
+
+	const message = "Devolução registrada com sucesso."
+	r, ok := withIdempotency(w, r, h.idempotency, respondJSON(http.StatusOK, movementWith(message)))
+	if !ok {
 		return
 	}
 
-	if err := h.service.TransferStock(r.Context(), productID, req); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	var req service.ReturnStockRequest
+	if !decodeJSON(w, r, &req) {
 		return
 	}
 
+	movement, err := h.service.ReturnStock(r.Context(), productID, req)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeStockMovement(w, message, movement)
+}
+
+// ListSerials lista os números de série de um produto serializado.
+func (h *ProductHandler) ListSerials(w http.ResponseWriter, r *http.Request) {
+	productID, err := uuid.Parse(chi.URLParam(r, "productID"))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidID, "ID do produto inválido")
+		return
+	}
+	serials, err := h.service.ListSerials(r.Context(), productID, parsePageRequest(r))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(serials); err != nil {
+		log.Printf("Erro ao encodar a resposta JSON: %v", err)
+	}
+}
+
+// GetSerial retorna um número de série com todo o seu histórico de localização.
+func (h *ProductHandler) GetSerial(w http.ResponseWriter, r *http.Request) {
+	history, err := h.service.GetSerialHistory(r.Context(), chi.URLParam(r, "serial"))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(history); err != nil {
+		log.Printf("Erro ao encodar a resposta JSON: %v", err)
+	}
+}
+
+// ListPackagings lista as embalagens cadastradas para o produto.
+func (h *ProductHandler) ListPackagings(w http.ResponseWriter, r *http.Request) {
+	productID, err := uuid.Parse(chi.URLParam(r, "productID"))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidID, "ID do produto inválido")
+		return
+	}
+	packagings, err := h.service.ListPackagings(r.Context(), productID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(packagings); err != nil {
+		log.Printf("Erro ao encodar a resposta JSON: %v", err)
+	}
+}
+
+// ReplacePackagings substitui as embalagens do produto pela lista enviada.
+func (h *ProductHandler) ReplacePackagings(w http.ResponseWriter, r *http.Request) {
+	productID, err := uuid.Parse(chi.URLParam(r, "productID"))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidID, "ID do produto inválido")
+		return
+	}
+	var packagings []domain.Packaging
+	if !decodeJSON(w, r, &packagings) {
+		return
+	}
+	saved, err := h.service.ReplacePackagings(r.Context(), productID, packagings)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(saved); err != nil {
+		log.Printf("Erro ao encodar a resposta JSON: %v", err)
+	}
+}
+
+// writeStockMovement envia a movimentação registrada, com a quantidade na embalagem e na unidade base.
+func writeStockMovement(w http.ResponseWriter, message string, movement *domain.StockMovement) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(map[string]string{"message": "Transferência de estoque realizada com sucesso."}); err != nil {
-		log.Printf("Erro ao codificar JSON na resposta de transferência: %v", err)
+	if err := json.NewEncoder(w).Encode(stockMovementResponse{Message: message, Movement: movement}); err != nil {
+		log.Printf("Erro ao codificar JSON da movimentação de estoque: %v", err)
 	}
 }
@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"controle-de-estoque/backend/internal/domain"
+	"controle-de-estoque/backend/internal/service"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// maxImportUploadBytes limita o tamanho do envio multipart de um arquivo de importação.
+const maxImportUploadBytes = 20 << 20
+
+// ImportUploadTimeout é o prazo para receber o arquivo de importação. O ReadTimeout e o
+// WriteTimeout do servidor são dimensionados para corpos JSON pequenos e cortariam os
+// envios maiores, e a rota também fica fora do limite de tempo das demais.
+const ImportUploadTimeout = 5 * time.Minute
+
+// ProductImportHandler gerencia as importações de produtos em lote.
+type ProductImportHandler struct {
+	service *service.ProductImportService
+}
+
+// NewProductImportHandler cria uma nova instância de ProductImportHandler.
+func NewProductImportHandler(s *service.ProductImportService) *ProductImportHandler {
+	return &ProductImportHandler{service: s}
+}
+
+// SubmitImport recebe um arquivo CSV ou XLSX (multipart/form-data, campo file) e coloca
+// a importação na fila. Os campos opcionais mapping (JSON campo -> cabeçalho) e
+// dry_run=true controlam o mapeamento das colunas e a simulação sem gravação. Responde
+// 202 com o job, cujo progresso é consultado no endereço do cabeçalho Location.
+func (h *ProductImportHandler) SubmitImport(w http.ResponseWriter, r *http.Request) {
+	rc := http.NewResponseController(w)
+	if err := rc.SetReadDeadline(time.Now().Add(ImportUploadTimeout)); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		log.Printf("Erro ao estender o prazo de leitura da importação: %v", err)
+	}
+	// O prazo de escrita conta desde a leitura dos cabeçalhos e venceria durante o envio.
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		log.Printf("Erro ao remover o prazo de escrita da importação: %v", err)
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportUploadBytes)
+	if err := r.ParseMultipartForm(maxImportUploadBytes); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		switch {
+		case errors.Is(err, http.ErrNotMultipart):
+			writeProblem(w, r, http.StatusUnsupportedMediaType, codeUnsupportedMediaType, "Content-Type deve ser multipart/form-data")
+		case errors.As(err, &maxBytesErr):
+			writeProblem(w, r, http.StatusRequestEntityTooLarge, codePayloadTooLarge, fmt.Sprintf("Corpo da requisição excede %d bytes", maxBytesErr.Limit))
+		case errors.Is(err, os.ErrDeadlineExceeded):
+			writeProblem(w, r, http.StatusRequestTimeout, codeRequestTimeout, fmt.Sprintf("O arquivo não foi recebido em %s", ImportUploadTimeout))
+		default:
+			writeError(w, r, fmt.Errorf("%w: %v", domain.ErrInvalidRequestBody, err))
+		}
+		return
+	}
+	defer func() { _ = r.MultipartForm.RemoveAll() }()
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		writeError(w, r, &domain.ValidationError{
+			Err:    domain.ErrInvalidRequestBody,
+			Fields: []domain.FieldError{{Field: "file", Code: domain.CodeRequired, Message: "envie o arquivo no campo file"}},
+		})
+		return
+	}
+	defer func() { _ = file.Close() }()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		writeError(w, r, fmt.Errorf("%w: %v", domain.ErrInvalidRequestBody, err))
+		return
+	}
+
+	req := service.ImportRequest{FileName: header.Filename, Data: data}
+	if mapping := r.FormValue("mapping"); mapping != "" {
+		if err := json.Unmarshal([]byte(mapping), &req.Mapping); err != nil {
+			writeError(w, r, &domain.ValidationError{
+				Err:    domain.ErrInvalidRequestBody,
+				Fields: []domain.FieldError{{Field: "mapping", Code: domain.CodeInvalidType, Message: "esperado um objeto JSON de campo para cabeçalho"}},
+			})
+			return
+		}
+	}
+	if dryRun := r.FormValue("dry_run"); dryRun != "" {
+		if req.DryRun, err = strconv.ParseBool(dryRun); err != nil {
+			writeProblem(w, r, http.StatusBadRequest, codeInvalidParameter, "dry_run deve ser true ou false")
+			return
+		}
+	}
+	userID, _ := r.Context().Value(UserIDContextKey).(string)
+	if req.CreatedBy, err = uuid.Parse(userID); err != nil {
+		writeProblem(w, r, http.StatusUnauthorized, codeUnauthorized, "Usuário não autenticado")
+		return
+	}
+
+	job, err := h.service.Submit(r.Context(), req)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Location", strings.TrimSuffix(r.URL.Path, "/")+"/"+job.ID.String())
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	if err := json.NewEncoder(w).Encode(job); err != nil {
+		log.Printf("Erro ao codificar JSON do job de importação: %v", err)
+	}
+}
+
+// GetImport retorna a situação e o progresso de uma importação.
+func (h *ProductImportHandler) GetImport(w http.ResponseWriter, r *http.Request) {
+	jobID, ok := importJobID(w, r)
+	if !ok {
+		return
+	}
+	job, err := h.service.Get(r.Context(), jobID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(job); err != nil {
+		log.Printf("Erro ao codificar JSON do job de importação: %v", err)
+	}
+}
+
+// ListImportRows lista o resultado de cada linha da importação (no dry-run, o que seria
+// alterado), com paginação e filtro opcional por ação (action=create|update|unchanged|error).
+func (h *ProductImportHandler) ListImportRows(w http.ResponseWriter, r *http.Request) {
+	jobID, ok := importJobID(w, r)
+	if !ok {
+		return
+	}
+	action := domain.ImportAction(r.URL.Query().Get("action"))
+	rows, err := h.service.ListRows(r.Context(), jobID, action, parsePageRequest(r))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(rows); err != nil {
+		log.Printf("Erro ao codificar JSON das linhas da importação: %v", err)
+	}
+}
+
+// DownloadImportErrors devolve o relatório de erros da importação em CSV, com uma linha
+// por erro de campo, para correção na planilha original.
+func (h *ProductImportHandler) DownloadImportErrors(w http.ResponseWriter, r *http.Request) {
+	jobID, ok := importJobID(w, r)
+	if !ok {
+		return
+	}
+	if _, err := h.service.Get(r.Context(), jobID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="importacao-%s-erros.csv"`, jobID))
+	w.WriteHeader(http.StatusOK)
+
+	out := csv.NewWriter(w)
+	_ = out.Write([]string{"row", "sku", "field", "code", "message"})
+	err := h.service.EachError(r.Context(), jobID, func(row domain.ImportRow) error {
+		for _, e := range row.Errors {
+			if err := out.Write([]string{strconv.Itoa(row.RowNumber), row.SKU, e.Field, e.Code, e.Message}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	out.Flush()
+	if err == nil {
+		err = out.Error()
+	}
+	if err != nil {
+		// O status já foi enviado; resta registrar a falha.
+		log.Printf("Erro ao gerar relatório de erros da importação %s: %v", jobID, err)
+	}
+}
+
+func importJobID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	jobID, err := uuid.Parse(chi.URLParam(r, "jobID"))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidID, "ID da importação inválido")
+		return uuid.Nil, false
+	}
+	return jobID, true
+}
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestSubmitImportSlowBody envia o corpo multipart aos poucos, por mais tempo que o
+// ReadTimeout e o WriteTimeout do servidor: o envio precisa ser lido até o fim e a
+// resposta, entregue. O formulário não traz o arquivo, então a resposta é a validação
+// do campo file, sem chegar ao serviço.
+func TestSubmitImportSlowBody(t *testing.T) {
+	const serverTimeout = 100 * time.Millisecond
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(NewProductImportHandler(nil).SubmitImport))
+	srv.Config.ReadTimeout = serverTimeout
+	srv.Config.WriteTimeout = serverTimeout
+	srv.Start()
+	defer srv.Close()
+
+	body, pw := io.Pipe()
+	form := multipart.NewWriter(pw)
+	go func() {
+		part, err := form.CreateFormField("mapping")
+		if err != nil {
+			_ = pw.CloseWithError(err)
+			return
+		}
+		for i := 0; i < 4; i++ {
+			time.Sleep(serverTimeout)
+			if _, err := part.Write([]byte(" ")); err != nil {
+				_ = pw.CloseWithError(err)
+				return
+			}
+		}
+		_ = pw.CloseWithError(form.Close())
+	}()
+
+	req, err := http.NewRequest(http.MethodPost, srv.URL, body)
+	if err != nil {
+		t.Fatalf("erro ao criar requisição: %v", err)
+	}
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	resp, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatalf("envio lento interrompido: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var p problem
+	if err := json.NewDecoder(resp.Body).Decode(&p); err != nil {
+		t.Fatalf("resposta inválida: %v", err)
+	}
+	if resp.StatusCode != http.StatusUnprocessableEntity || len(p.Errors) != 1 || p.Errors[0].Field != "file" {
+		t.Errorf("status %d, erros %+v; esperado 422 com o campo file", resp.StatusCode, p.Errors)
+	}
+}
@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"controle-de-estoque/backend/internal/domain"
+	"controle-de-estoque/backend/internal/service"
+
+	"github.com/google/uuid"
+)
+
+// ReportHandler gerencia os relatórios gerenciais do estoque.
+type ReportHandler struct {
+	service *service.ReportService
+}
+
+// NewReportHandler cria uma nova instância de ReportHandler.
+func NewReportHandler(s *service.ReportService) *ReportHandler {
+	return &ReportHandler{service: s}
+}
+
+// Valuation responde a valorização do estoque global e dos clientes, por categoria
+// (GET /reports/valuation). O parâmetro opcional as_of aceita um instante RFC 3339 ou
+// uma data AAAA-MM-DD, que corresponde ao fim do dia em UTC, para o fechamento do mês; a
+// data de hoje vale até agora. basis escolhe entre price (padrão, apenas no instante
+// atual) e cost, que aceita datas passadas.
+func (h *ReportHandler) Valuation(w http.ResponseWriter, r *http.Request) {
+	var asOf *time.Time
+	if value := r.URL.Query().Get("as_of"); value != "" {
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			day, dayErr := time.Parse(time.DateOnly, value)
+			if dayErr != nil {
+				writeProblem(w, r, http.StatusBadRequest, codeInvalidParameter, "as_of deve estar no formato RFC 3339 ou AAAA-MM-DD")
+				return
+			}
+			t = day.AddDate(0, 0, 1).Add(-time.Microsecond)
+			if now := time.Now(); t.After(now) && !day.After(now) {
+				t = now
+			}
+		}
+		asOf = &t
+	}
+
+	report, err := h.service.Valuation(r.Context(), asOf, domain.ValuationBasis(r.URL.Query().Get("basis")))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		log.Printf("Erro ao codificar JSON da valorização do estoque: %v", err)
+	}
+}
+
+// Margin responde a receita, o CMV e a margem das transferências de um período, menos as
+// devoluções (GET /reports/margin). from e to aceitam um instante RFC 3339 ou uma data
+// AAAA-MM-DD; como data, o período inclui o dia inteiro de to. Por padrão, vai do início
+// do mês corrente até hoje. group_by escolhe entre product (padrão) e client, e client e
+// product restringem as movimentações.
+func (h *ReportHandler) Margin(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	filter := domain.MarginFilter{
+		From:    today.AddDate(0, 0, 1-today.Day()),
+		To:      today.AddDate(0, 0, 1),
+		GroupBy: domain.MarginGroup(query.Get("group_by")),
+	}
+	for param, target := range map[string]*time.Time{"from": &filter.From, "to": &filter.To} {
+		value := query.Get(param)
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			day, dayErr := time.Parse(time.DateOnly, value)
+			if dayErr != nil {
+				writeProblem(w, r, http.StatusBadRequest, codeInvalidParameter, param+" deve estar no formato RFC 3339 ou AAAA-MM-DD")
+				return
+			}
+			if t = day; param == "to" {
+				t = day.AddDate(0, 0, 1)
+			}
+		}
+		*target = t
+	}
+	for param, target := range map[string]**uuid.UUID{"client": &filter.ClientID, "product": &filter.ProductID} {
+		if value := query.Get(param); value != "" {
+			id, err := uuid.Parse(value)
+			if err != nil {
+				writeProblem(w, r, http.StatusBadRequest, codeInvalidID, "ID inválido no parâmetro "+param)
+				return
+			}
+			*target = &id
+		}
+	}
+
+	report, err := h.service.Margin(r.Context(), filter)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		log.Printf("Erro ao codificar JSON do relatório de margem: %v", err)
+	}
+}
@@ -2,10 +2,8 @@ package handler
 
 import (
 	"encoding/json"
-	"errors"
 	"net/http"
 
-	"controle-de-estoque/backend/internal/domain"
 	"controle-de-estoque/backend/internal/service"
 
 	"github.com/google/uuid"
@@ -39,34 +37,24 @@ type LoginRequest struct {
 	Password string `json:"password"`
 }
 
-// ErrorResponse representa uma resposta de erro padrão
-type ErrorResponse struct {
-	Error   string `json:"error"`
-	Message string `json:"message,omitempty"`
-}
-
 // GetMe lida com a busca do perfil do usuário logado
 func (h *UserHandler) GetMe(w http.ResponseWriter, r *http.Request) {
 	// Pega o userID que o middleware colocou no contexto.
 	userIDStr, ok := r.Context().Value(UserIDContextKey).(string)
 	if !ok {
-		h.sendError(w, "internal_error", "ID de usuário ausente no contexto", http.StatusInternalServerError)
+		writeProblem(w, r, http.StatusInternalServerError, codeInternal, "ID de usuário ausente no contexto")
 		return
 	}
 
 	userID, err := uuid.Parse(userIDStr)
 	if err != nil {
-		h.sendError(w, "invalid_user_id", "ID de usuário inválido", http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidID, "ID de usuário inválido")
 		return
 	}
 
 	userProfile, err := h.userService.GetProfile(r.Context(), userID)
 	if err != nil {
-		if errors.Is(err, domain.ErrUserNotFound) {
-			h.sendError(w, "user_not_found", "Usuário não encontrado", http.StatusNotFound)
-			return
-		}
-		h.handleServiceError(w, err)
+		writeError(w, r, err)
 		return
 	}
 
@@ -76,8 +64,7 @@ func (h *UserHandler) GetMe(w http.ResponseWriter, r *http.Request) {
 // Register lida com requisições de registro de usuários
 func (h *UserHandler) Register(w http.ResponseWriter, r *http.Request) {
 	var req RegisterRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.sendError(w, "invalid_request_body", "Corpo da requisição inválido", http.StatusBadRequest)
+	if !decodeJSON(w, r, &req) {
 		return
 	}
 
@@ -89,7 +76,7 @@ func (h *UserHandler) Register(w http.ResponseWriter, r *http.Request) {
 
 	authResponse, err := h.userService.Register(r.Context(), serviceReq)
 	if err != nil {
-		h.handleServiceError(w, err)
+		writeError(w, r, err)
 		return
 	}
 
@@ -99,8 +86,7 @@ func (h *UserHandler) Register(w http.ResponseWriter, r *http.Request) {
 // Login lida com requisições de autenticação de usuários
 func (h *UserHandler) Login(w http.ResponseWriter, r *http.Request) {
 	var req LoginRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.sendError(w, "invalid_request_body", "Corpo da requisição inválido", http.StatusBadRequest)
+	if !decodeJSON(w, r, &req) {
 		return
 	}
 
@@ -111,30 +97,13 @@ func (h *UserHandler) Login(w http.ResponseWriter, r *http.Request) {
 
 	authResponse, err := h.userService.Login(r.Context(), serviceReq)
 	if err != nil {
-		h.handleServiceError(w, err)
+		writeError(w, r, err)
 		return
 	}
 
 	h.sendJSON(w, authResponse, http.StatusOK)
 }
 
-// handleServiceError trata erros retornados pelo serviço
-func (h *UserHandler) handleServiceError(w http.ResponseWriter, err error) {
-	switch {
-	case errors.Is(err, service.ErrInvalidCredentials):
-		h.sendError(w, "invalid_credentials", "Credenciais inválidas", http.StatusUnauthorized)
-	case errors.Is(err, service.ErrEmailInUse):
-		h.sendError(w, "email_in_use", "Email já está em uso", http.StatusConflict)
-	case errors.Is(err, service.ErrWeakPassword):
-		h.sendError(w, "weak_password", err.Error(), http.StatusBadRequest)
-	case errors.Is(err, service.ErrPasswordsDontMatch):
-		h.sendError(w, "passwords_dont_match", "As senhas não coincidem", http.StatusBadRequest)
-	default:
-		h.logger.Error("Erro interno não mapeado", zap.Error(err))
-		h.sendError(w, "internal_error", "Erro interno no servidor", http.StatusInternalServerError)
-	}
-}
-
 // sendJSON envia uma resposta JSON
 func (h *UserHandler) sendJSON(w http.ResponseWriter, data interface{}, statusCode int) {
 	w.Header().Set("Content-Type", "application/json")
@@ -145,12 +114,3 @@ func (h *UserHandler) sendJSON(w http.ResponseWriter, data interface{}, statusCo
 		}
 	}
 }
-
-// sendError envia uma resposta de erro padronizada
-func (h *UserHandler) sendError(w http.ResponseWriter, errorCode, message string, statusCode int) {
-	resp := ErrorResponse{
-		Error:   errorCode,
-		Message: message,
-	}
-	h.sendJSON(w, resp, statusCode)
-}
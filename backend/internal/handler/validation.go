@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"controle-de-estoque/backend/internal/domain"
+)
+
+// maxJSONBodyBytes limita o tamanho do corpo aceito nas requisições JSON.
+const maxJSONBodyBytes = 1 << 20
+
+// decodeJSON lê o corpo da requisição em dst, rejeitando campos desconhecidos, corpos
+// acima de maxJSONBodyBytes e conteúdo após o documento JSON. Em caso de falha, a
+// resposta de erro já é escrita e ok retorna false.
+func decodeJSON(w http.ResponseWriter, r *http.Request, dst any) (ok bool) {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxJSONBodyBytes))
+	decoder.DisallowUnknownFields()
+
+	err := decoder.Decode(dst)
+	if err == nil && decoder.Decode(&struct{}{}) != io.EOF {
+		err = errors.New("conteúdo após o documento JSON")
+	}
+	if err == nil {
+		return true
+	}
+
+	var maxBytesErr *http.MaxBytesError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &maxBytesErr):
+		writeProblem(w, r, http.StatusRequestEntityTooLarge, codePayloadTooLarge, fmt.Sprintf("Corpo da requisição excede %d bytes", maxBytesErr.Limit))
+	case errors.As(err, &typeErr):
+		writeError(w, r, &domain.ValidationError{
+			Err: domain.ErrInvalidRequestBody,
+			Fields: []domain.FieldError{{
+				Field:   typeErr.Field,
+				Code:    domain.CodeInvalidType,
+				Message: fmt.Sprintf("esperado valor do tipo %s", typeErr.Type),
+			}},
+		})
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		// O pacote encoding/json não exporta um tipo para este erro.
+		field := strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`)
+		writeError(w, r, &domain.ValidationError{
+			Err:    domain.ErrInvalidRequestBody,
+			Fields: []domain.FieldError{{Field: field, Code: domain.CodeUnknownField, Message: "campo não reconhecido"}},
+		})
+	default:
+		writeError(w, r, fmt.Errorf("%w: %v", domain.ErrInvalidRequestBody, err))
+	}
+	return false
+}
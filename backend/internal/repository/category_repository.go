@@ -0,0 +1,273 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"controle-de-estoque/backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// CategoryRepository gerencia a árvore de categorias de produtos.
+type CategoryRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewCategoryRepository cria uma nova instância de CategoryRepository.
+func NewCategoryRepository(db *pgxpool.Pool) *CategoryRepository {
+	return &CategoryRepository{db: db}
+}
+
+const categoryColumns = `id, parent_id, name, path, created_at, updated_at`
+
+func scanCategory(row pgx.Row, c *domain.Category) error {
+	return row.Scan(&c.ID, &c.ParentID, &c.Name, &c.Path, &c.CreatedAt, &c.UpdatedAt)
+}
+
+// CreateCategory insere uma categoria calculando seu caminho a partir do pai.
+func (r *CategoryRepository) CreateCategory(ctx context.Context, category *domain.Category) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("erro ao iniciar transação: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	category.ID = uuid.New()
+	parentPath := "/"
+	if category.ParentID != nil {
+		parent, err := r.getForUpdate(ctx, tx, *category.ParentID)
+		if err != nil {
+			return err
+		}
+		parentPath = parent.Path
+	}
+	category.Path = parentPath + category.ID.String() + "/"
+
+	query := `
+		INSERT INTO categories (id, parent_id, name, path)
+		VALUES ($1, $2, $3, $4)
+		RETURNING created_at, updated_at
+	`
+	err = tx.QueryRow(ctx, query, category.ID, category.ParentID, category.Name, category.Path).
+		Scan(&category.CreatedAt, &category.UpdatedAt)
+	if err != nil {
+		return mapCategoryWriteError("erro ao criar categoria", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("erro ao confirmar transação: %w", err)
+	}
+	return nil
+}
+
+// ListCategories retorna todas as categorias ordenadas pelo caminho, de modo que
+// cada pai aparece antes de seus filhos.
+func (r *CategoryRepository) ListCategories(ctx context.Context) ([]domain.Category, error) {
+	query := `SELECT ` + categoryColumns + ` FROM categories ORDER BY path ASC`
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar categorias: %w", err)
+	}
+	defer rows.Close()
+
+	categories := make([]domain.Category, 0)
+	for rows.Next() {
+		var c domain.Category
+		if err := scanCategory(rows, &c); err != nil {
+			return nil, fmt.Errorf("erro ao escanear categoria: %w", err)
+		}
+		categories = append(categories, c)
+	}
+	return categories, rows.Err()
+}
+
+// GetCategoryByID busca uma categoria pelo ID.
+func (r *CategoryRepository) GetCategoryByID(ctx context.Context, categoryID uuid.UUID) (*domain.Category, error) {
+	query := `SELECT ` + categoryColumns + ` FROM categories WHERE id = $1`
+	var c domain.Category
+	if err := scanCategory(r.db.QueryRow(ctx, query, categoryID), &c); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrCategoryNotFound
+		}
+		return nil, fmt.Errorf("erro ao buscar categoria por ID: %w", err)
+	}
+	return &c, nil
+}
+
+// GetCategoriesByIDs busca as categorias com os IDs informados, em qualquer ordem. IDs
+// inexistentes são ignorados.
+func (r *CategoryRepository) GetCategoriesByIDs(ctx context.Context, categoryIDs []uuid.UUID) ([]domain.Category, error) {
+	query := `SELECT ` + categoryColumns + ` FROM categories WHERE id = ANY($1)`
+	rows, err := r.db.Query(ctx, query, categoryIDs)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar categorias por ID: %w", err)
+	}
+	categories, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.Category, error) {
+		var c domain.Category
+		err := scanCategory(row, &c)
+		return c, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao escanear categoria: %w", err)
+	}
+	return categories, nil
+}
+
+// UpdateCategory renomeia e/ou move uma categoria. Ao mudar de pai, o caminho de
+// toda a subárvore é reescrito na mesma transação.
+func (r *CategoryRepository) UpdateCategory(ctx context.Context, category *domain.Category) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("erro ao iniciar transação: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	current, err := r.getForUpdate(ctx, tx, category.ID)
+	if err != nil {
+		return err
+	}
+
+	parentPath := "/"
+	if category.ParentID != nil {
+		parent, err := r.getForUpdate(ctx, tx, *category.ParentID)
+		if err != nil {
+			return err
+		}
+		if strings.HasPrefix(parent.Path, current.Path) {
+			return fmt.Errorf("%w: uma categoria não pode ser movida para dentro de si mesma", domain.ErrInvalidCategoryData)
+		}
+		parentPath = parent.Path
+	}
+	category.Path = parentPath + category.ID.String() + "/"
+
+	query := `
+		UPDATE categories
+		SET parent_id = $1, name = $2, path = $3, updated_at = NOW()
+		WHERE id = $4
+		RETURNING created_at, updated_at
+	`
+	err = tx.QueryRow(ctx, query, category.ParentID, category.Name, category.Path, category.ID).
+		Scan(&category.CreatedAt, &category.UpdatedAt)
+	if err != nil {
+		return mapCategoryWriteError("erro ao atualizar categoria", err)
+	}
+
+	if category.Path != current.Path {
+		const moveQuery = `
+			UPDATE categories
+			SET path = $1 || substr(path, length($2) + 1), updated_at = NOW()
+			WHERE path LIKE $2 || '%' AND id <> $3
+		`
+		if _, err := tx.Exec(ctx, moveQuery, category.Path, current.Path, category.ID); err != nil {
+			return fmt.Errorf("erro ao mover subcategorias: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("erro ao confirmar transação: %w", err)
+	}
+	return nil
+}
+
+// DeleteCategory remove uma categoria sem filhos. Os produtos associados ficam sem categoria.
+func (r *CategoryRepository) DeleteCategory(ctx context.Context, categoryID uuid.UUID) error {
+	cmdTag, err := r.db.Exec(ctx, `DELETE FROM categories WHERE id = $1`, categoryID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return domain.ErrCategoryHasChildren
+		}
+		return fmt.Errorf("erro ao deletar categoria: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return domain.ErrCategoryNotFound
+	}
+	return nil
+}
+
+// getForUpdate busca e bloqueia uma categoria dentro da transação.
+func (r *CategoryRepository) getForUpdate(ctx context.Context, tx pgx.Tx, categoryID uuid.UUID) (*domain.Category, error) {
+	query := `SELECT ` + categoryColumns + ` FROM categories WHERE id = $1 FOR UPDATE`
+	var c domain.Category
+	if err := scanCategory(tx.QueryRow(ctx, query, categoryID), &c); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrCategoryNotFound
+		}
+		return nil, fmt.Errorf("erro ao buscar categoria: %w", err)
+	}
+	return &c, nil
+}
+
+// mapCategoryWriteError converte violações de unicidade em erros de domínio.
+func mapCategoryWriteError(msg string, err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return domain.ErrCategoryExists
+	}
+	return fmt.Errorf("%s: %w", msg, err)
+}
+
+// ListAttributes retorna as definições de atributos válidas para a categoria, incluindo
+// as herdadas dos ancestrais. Quando a mesma chave é definida em mais de um nível,
+// prevalece a definição mais próxima da categoria.
+func (r *CategoryRepository) ListAttributes(ctx context.Context, categoryID uuid.UUID) ([]domain.AttributeDefinition, error) {
+	const query = `
+		SELECT DISTINCT ON (a.key) a.category_id, a.key, a.label, a.type, a.required, a.options
+		FROM categories target
+		JOIN categories c ON target.path LIKE c.path || '%'
+		JOIN category_attributes a ON a.category_id = c.id
+		WHERE target.id = $1
+		ORDER BY a.key ASC, length(c.path) DESC
+	`
+	rows, err := r.db.Query(ctx, query, categoryID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar atributos da categoria: %w", err)
+	}
+	defer rows.Close()
+
+	definitions := make([]domain.AttributeDefinition, 0)
+	for rows.Next() {
+		var d domain.AttributeDefinition
+		if err := rows.Scan(&d.CategoryID, &d.Key, &d.Label, &d.Type, &d.Required, &d.Options); err != nil {
+			return nil, fmt.Errorf("erro ao escanear atributo: %w", err)
+		}
+		definitions = append(definitions, d)
+	}
+	return definitions, rows.Err()
+}
+
+// ReplaceAttributes substitui as definições de atributos declaradas diretamente na categoria.
+func (r *CategoryRepository) ReplaceAttributes(ctx context.Context, categoryID uuid.UUID, definitions []domain.AttributeDefinition) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("erro ao iniciar transação: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if _, err := r.getForUpdate(ctx, tx, categoryID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, "DELETE FROM category_attributes WHERE category_id = $1", categoryID); err != nil {
+		return fmt.Errorf("erro ao remover atributos: %w", err)
+	}
+	for _, d := range definitions {
+		const query = `
+			INSERT INTO category_attributes (category_id, key, label, type, required, options)
+			VALUES ($1, $2, $3, $4, $5, $6)
+		`
+		if _, err := tx.Exec(ctx, query, categoryID, d.Key, d.Label, d.Type, d.Required, d.Options); err != nil {
+			return fmt.Errorf("erro ao inserir atributo: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("erro ao confirmar transação: %w", err)
+	}
+	return nil
+}
@@ -4,11 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"controle-de-estoque/backend/internal/domain"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -22,59 +24,392 @@ func NewClientRepository(db *pgxpool.Pool) *ClientRepository {
 	return &ClientRepository{db: db}
 }
 
-// CreateClient insere um novo cliente no banco de dados.
-func (r *ClientRepository) CreateClient(ctx context.Context, client *domain.Client) error {
-	query := `INSERT INTO clients (name, email, phone) VALUES ($1, $2, $3) RETURNING id, created_at, updated_at`
-	err := r.db.QueryRow(ctx, query, client.Name, client.Email, client.Phone).Scan(&client.ID, &client.CreatedAt, &client.UpdatedAt)
+// clientColumns lista as colunas lidas por clientScanTargets, na mesma ordem.
+const clientColumns = `c.id, c.name, c.email, c.phone, COALESCE(c.document, ''), c.status, c.created_at, c.updated_at`
+
+// clientScanTargets retorna os destinos de clientColumns, na mesma ordem.
+func clientScanTargets(c *domain.Client) []any {
+	return []any{&c.ID, &c.Name, &c.Email, &c.Phone, &c.Document, &c.Status, &c.CreatedAt, &c.UpdatedAt}
+}
+
+// CreateClient insere um novo cliente, com seus endereços e contatos, na transação informada.
+func (r *ClientRepository) CreateClient(ctx context.Context, tx pgx.Tx, client *domain.Client) error {
+	query := `
+		INSERT INTO clients (name, email, phone, document, status)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5)
+		RETURNING id, created_at, updated_at
+	`
+	err := tx.QueryRow(ctx, query, client.Name, client.Email, client.Phone, client.Document, client.Status).
+		Scan(&client.ID, &client.CreatedAt, &client.UpdatedAt)
 	if err != nil {
-		return fmt.Errorf("erro ao criar cliente: %w", err)
+		return mapClientWriteError("erro ao criar cliente", err)
+	}
+	return r.replaceDetails(ctx, tx, client)
+}
+
+// replaceDetails substitui os endereços e contatos do cliente pelos informados,
+// preservando a ordem recebida. Os IDs gerados são preenchidos em client.
+func (r *ClientRepository) replaceDetails(ctx context.Context, tx pgx.Tx, client *domain.Client) error {
+	if _, err := tx.Exec(ctx, `DELETE FROM client_addresses WHERE client_id = $1`, client.ID); err != nil {
+		return fmt.Errorf("erro ao limpar endereços do cliente: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM client_contacts WHERE client_id = $1`, client.ID); err != nil {
+		return fmt.Errorf("erro ao limpar contatos do cliente: %w", err)
+	}
+
+	const addressQuery = `
+		INSERT INTO client_addresses (client_id, type, street, number, complement, district, city, state, cep, position)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id
+	`
+	for i := range client.Addresses {
+		a := &client.Addresses[i]
+		err := tx.QueryRow(ctx, addressQuery, client.ID, a.Type, a.Street, a.Number, a.Complement, a.District, a.City, a.State, a.CEP, i).Scan(&a.ID)
+		if err != nil {
+			return fmt.Errorf("erro ao salvar endereço do cliente: %w", err)
+		}
+	}
+
+	const contactQuery = `
+		INSERT INTO client_contacts (client_id, name, email, phone, role, position)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id
+	`
+	for i := range client.Contacts {
+		c := &client.Contacts[i]
+		if err := tx.QueryRow(ctx, contactQuery, client.ID, c.Name, c.Email, c.Phone, c.Role, i).Scan(&c.ID); err != nil {
+			return fmt.Errorf("erro ao salvar contato do cliente: %w", err)
+		}
 	}
 	return nil
 }
 
-// ListClients busca todos os clientes.
-func (r *ClientRepository) ListClients(ctx context.Context) ([]domain.Client, error) {
-	query := `SELECT id, name, email, phone, created_at, updated_at FROM clients ORDER BY name ASC`
-	rows, err := r.db.Query(ctx, query)
+// loadDetails preenche os endereços e contatos do cliente.
+func (r *ClientRepository) loadDetails(ctx context.Context, client *domain.Client) error {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, type, street, number, complement, district, city, state, cep
+		FROM client_addresses WHERE client_id = $1 ORDER BY position`, client.ID)
+	if err != nil {
+		return fmt.Errorf("erro ao buscar endereços do cliente: %w", err)
+	}
+	client.Addresses, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.Address, error) {
+		var a domain.Address
+		err := row.Scan(&a.ID, &a.Type, &a.Street, &a.Number, &a.Complement, &a.District, &a.City, &a.State, &a.CEP)
+		return a, err
+	})
+	if err != nil {
+		return fmt.Errorf("erro ao escanear endereço do cliente: %w", err)
+	}
+
+	rows, err = r.db.Query(ctx, `
+		SELECT id, name, email, phone, role
+		FROM client_contacts WHERE client_id = $1 ORDER BY position`, client.ID)
+	if err != nil {
+		return fmt.Errorf("erro ao buscar contatos do cliente: %w", err)
+	}
+	client.Contacts, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.Contact, error) {
+		var c domain.Contact
+		err := row.Scan(&c.ID, &c.Name, &c.Email, &c.Phone, &c.Role)
+		return c, err
+	})
 	if err != nil {
-		return nil, fmt.Errorf("erro ao listar clientes: %w", err)
+		return fmt.Errorf("erro ao escanear contato do cliente: %w", err)
+	}
+	return nil
+}
+
+// mapClientWriteError traduz violações de restrição em erros de domínio.
+func mapClientWriteError(msg string, err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "clients_document_key" {
+		return domain.ErrDocumentInUse
+	}
+	return fmt.Errorf("%s: %w", msg, err)
+}
+
+// clientSortKeys é a lista branca de campos ordenáveis de clientes.
+var clientSortKeys = map[string]sortKey{
+	"name":       {expr: "c.name", sqlType: "text"},
+	"email":      {expr: "c.email", sqlType: "text"},
+	"created_at": {expr: "c.created_at", sqlType: "timestamptz"},
+	"updated_at": {expr: "c.updated_at", sqlType: "timestamptz"},
+}
+
+// buildClientSortKeys monta a ordenação a partir da lista branca (por nome, se vazia),
+// terminando pelo ID para que a ordem seja estável entre páginas.
+func buildClientSortKeys(filter domain.ClientFilter) ([]sortKey, error) {
+	var keys []sortKey
+	for _, field := range filter.Sort {
+		key, ok := clientSortKeys[field.Field]
+		if !ok {
+			return nil, fmt.Errorf("%w: campo de ordenação %q", domain.ErrInvalidFilter, field.Field)
+		}
+		key.desc = field.Desc
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		keys = append(keys, clientSortKeys["name"])
+	}
+	return append(keys, sortKey{expr: "c.id", sqlType: "uuid"}), nil
+}
+
+// buildClientWhere monta a cláusula WHERE da listagem de clientes e seus argumentos.
+func buildClientWhere(filter domain.ClientFilter) (string, []any) {
+	var conditions []string
+	var args []any
+
+	if filter.Search != "" {
+		args = append(args, "%"+likeEscape(filter.Search)+"%")
+		condition := fmt.Sprintf(`c.name ILIKE $%[1]d ESCAPE '\' OR c.email ILIKE $%[1]d ESCAPE '\'`, len(args))
+		// Telefones são comparados só pelos dígitos, ignorando a formatação; o documento
+		// já é armazenado apenas com dígitos e é buscado pelo prefixo.
+		if digits := onlyDigits(filter.Search); digits != "" {
+			args = append(args, "%"+digits+"%", digits+"%")
+			condition += fmt.Sprintf(" OR regexp_replace(c.phone, '\\D', '', 'g') LIKE $%d OR c.document LIKE $%d", len(args)-1, len(args))
+		}
+		conditions = append(conditions, "("+condition+")")
+	}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("c.status = $%d", len(args)))
+	}
+	if filter.HoldingProductID != nil {
+		// O estoque de um produto pai fica nas variantes, que também contam.
+		args = append(args, *filter.HoldingProductID)
+		conditions = append(conditions, fmt.Sprintf(`EXISTS (
+			SELECT 1 FROM client_stocks cs
+			WHERE cs.client_id = c.id AND cs.quantity > 0
+			  AND (cs.product_id = $%[1]d OR cs.product_id IN (SELECT v.id FROM products v WHERE v.parent_id = $%[1]d)))`, len(args)))
+	} else if filter.WithStock {
+		conditions = append(conditions, "EXISTS (SELECT 1 FROM client_stocks cs WHERE cs.client_id = c.id AND cs.quantity > 0)")
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+func onlyDigits(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, s)
+}
+
+// ListClients busca uma página de clientes, aplicando os filtros informados.
+func (r *ClientRepository) ListClients(ctx context.Context, filter domain.ClientFilter, page domain.PageRequest) ([]domain.Client, *int, string, error) {
+	where, args := buildClientWhere(filter)
+	keys, err := buildClientSortKeys(filter)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	var total *int
+	if page.IncludeTotal {
+		var totalRecords int
+		if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM clients c`+where, args...).Scan(&totalRecords); err != nil {
+			return nil, nil, "", fmt.Errorf("erro ao contar clientes: %w", err)
+		}
+		total = &totalRecords
+	}
+
+	tail, args, err := paginate(where, args, keys, page)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	query := `SELECT ` + clientColumns + cursorColumn(keys) + ` FROM clients c` + tail
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("erro ao listar clientes: %w", err)
 	}
 	defer rows.Close()
 
-	clients := make([]domain.Client, 0)
+	clients := make([]domain.Client, 0, page.Limit+1)
+	var keyValues [][]string
 	for rows.Next() {
 		var c domain.Client
-		if err := rows.Scan(&c.ID, &c.Name, &c.Email, &c.Phone, &c.CreatedAt, &c.UpdatedAt); err != nil {
-			return nil, fmt.Errorf("erro ao escanear cliente: %w", err)
+		var values []string
+		if err := rows.Scan(append(clientScanTargets(&c), &values)...); err != nil {
+			return nil, nil, "", fmt.Errorf("erro ao escanear cliente: %w", err)
 		}
 		clients = append(clients, c)
+		keyValues = append(keyValues, values)
 	}
-	return clients, nil
+	if err := rows.Err(); err != nil {
+		return nil, nil, "", fmt.Errorf("erro ao iterar pelos clientes: %w", err)
+	}
+
+	clients, next := trimPage(clients, keyValues, keys, page.Limit)
+	return clients, total, next, nil
+}
+
+// ExportClients percorre todos os clientes que atendem ao filtro, na ordem da listagem,
+// chamando fn para cada um sem carregá-los todos em memória. Um erro de fn interrompe a
+// leitura e é devolvido como está.
+func (r *ClientRepository) ExportClients(ctx context.Context, filter domain.ClientFilter, fn func(domain.Client) error) error {
+	where, args := buildClientWhere(filter)
+	keys, err := buildClientSortKeys(filter)
+	if err != nil {
+		return err
+	}
+	rows, err := r.db.Query(ctx, `SELECT `+clientColumns+` FROM clients c`+where+orderByClause(keys), args...)
+	if err != nil {
+		return fmt.Errorf("erro ao exportar clientes: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var c domain.Client
+		if err := rows.Scan(clientScanTargets(&c)...); err != nil {
+			return fmt.Errorf("erro ao escanear cliente: %w", err)
+		}
+		if err := fn(c); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("erro ao exportar clientes: %w", err)
+	}
+	return nil
 }
 
-// GetClientByID busca um cliente pelo seu ID.
+// GetClientByID busca um cliente pelo seu ID, com endereços e contatos.
 func (r *ClientRepository) GetClientByID(ctx context.Context, clientID uuid.UUID) (*domain.Client, error) {
-	query := `SELECT id, name, email, phone, created_at, updated_at FROM clients WHERE id = $1`
+	query := `SELECT ` + clientColumns + ` FROM clients c WHERE c.id = $1`
 	var c domain.Client
-	err := r.db.QueryRow(ctx, query, clientID).Scan(&c.ID, &c.Name, &c.Email, &c.Phone, &c.CreatedAt, &c.UpdatedAt)
+	err := r.db.QueryRow(ctx, query, clientID).Scan(clientScanTargets(&c)...)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, errors.New("cliente não encontrado")
+			return nil, domain.ErrClientNotFound
 		}
 		return nil, fmt.Errorf("erro ao buscar cliente por ID: %w", err)
 	}
+	if err := r.loadDetails(ctx, &c); err != nil {
+		return nil, err
+	}
 	return &c, nil
 }
 
-// UpdateClient atualiza um cliente existente.
+// GetClientsByIDs busca os clientes com os IDs informados, com endereços e contatos, em
+// qualquer ordem. IDs inexistentes são ignorados.
+func (r *ClientRepository) GetClientsByIDs(ctx context.Context, clientIDs []uuid.UUID) ([]domain.Client, error) {
+	query := `SELECT ` + clientColumns + ` FROM clients c WHERE c.id = ANY($1)`
+	rows, err := r.db.Query(ctx, query, clientIDs)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar clientes por ID: %w", err)
+	}
+	clients, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.Client, error) {
+		var c domain.Client
+		err := row.Scan(clientScanTargets(&c)...)
+		return c, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao escanear cliente: %w", err)
+	}
+	if err := r.loadDetailsBatch(ctx, clients); err != nil {
+		return nil, err
+	}
+	return clients, nil
+}
+
+// loadDetailsBatch preenche os endereços e contatos de vários clientes com uma consulta
+// para cada tipo de dado.
+func (r *ClientRepository) loadDetailsBatch(ctx context.Context, clients []domain.Client) error {
+	if len(clients) == 0 {
+		return nil
+	}
+	ids := make([]uuid.UUID, len(clients))
+	index := make(map[uuid.UUID]*domain.Client, len(clients))
+	for i := range clients {
+		ids[i] = clients[i].ID
+		index[clients[i].ID] = &clients[i]
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT client_id, id, type, street, number, complement, district, city, state, cep
+		FROM client_addresses WHERE client_id = ANY($1) ORDER BY client_id, position`, ids)
+	if err != nil {
+		return fmt.Errorf("erro ao buscar endereços dos clientes: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var clientID uuid.UUID
+		var a domain.Address
+		if err := rows.Scan(&clientID, &a.ID, &a.Type, &a.Street, &a.Number, &a.Complement, &a.District, &a.City, &a.State, &a.CEP); err != nil {
+			return fmt.Errorf("erro ao escanear endereço do cliente: %w", err)
+		}
+		index[clientID].Addresses = append(index[clientID].Addresses, a)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("erro ao iterar pelos endereços dos clientes: %w", err)
+	}
+
+	rows, err = r.db.Query(ctx, `
+		SELECT client_id, id, name, email, phone, role
+		FROM client_contacts WHERE client_id = ANY($1) ORDER BY client_id, position`, ids)
+	if err != nil {
+		return fmt.Errorf("erro ao buscar contatos dos clientes: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var clientID uuid.UUID
+		var c domain.Contact
+		if err := rows.Scan(&clientID, &c.ID, &c.Name, &c.Email, &c.Phone, &c.Role); err != nil {
+			return fmt.Errorf("erro ao escanear contato do cliente: %w", err)
+		}
+		index[clientID].Contacts = append(index[clientID].Contacts, c)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("erro ao iterar pelos contatos dos clientes: %w", err)
+	}
+	return nil
+}
+
+// GetClientForShare busca o cliente dentro da transação, impedindo que sua situação
+// seja alterada até o fim dela (ex.: bloqueio concorrente durante uma transferência).
+func (r *ClientRepository) GetClientForShare(ctx context.Context, tx pgx.Tx, clientID uuid.UUID) (*domain.Client, error) {
+	query := `SELECT ` + clientColumns + ` FROM clients c WHERE c.id = $1 FOR SHARE`
+	var c domain.Client
+	if err := tx.QueryRow(ctx, query, clientID).Scan(clientScanTargets(&c)...); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrClientNotFound
+		}
+		return nil, fmt.Errorf("erro ao buscar cliente por ID: %w", err)
+	}
+	return &c, nil
+}
+
+// UpdateClient atualiza um cliente existente e substitui seus endereços e contatos.
 func (r *ClientRepository) UpdateClient(ctx context.Context, client *domain.Client) error {
-	query := `UPDATE clients SET name = $1, email = $2, phone = $3, updated_at = NOW() WHERE id = $4 RETURNING updated_at`
-	err := r.db.QueryRow(ctx, query, client.Name, client.Email, client.Phone, client.ID).Scan(&client.UpdatedAt)
+	query := `
+		UPDATE clients
+		SET name = $1, email = $2, phone = $3, document = NULLIF($4, ''), status = $5, updated_at = NOW()
+		WHERE id = $6
+		RETURNING created_at, updated_at
+	`
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("erro ao iniciar transação: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	err = tx.QueryRow(ctx, query, client.Name, client.Email, client.Phone, client.Document, client.Status, client.ID).
+		Scan(&client.CreatedAt, &client.UpdatedAt)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return errors.New("cliente não encontrado para atualizar")
+			return fmt.Errorf("%w para atualizar", domain.ErrClientNotFound)
 		}
-		return fmt.Errorf("erro ao atualizar cliente: %w", err)
+		return mapClientWriteError("erro ao atualizar cliente", err)
+	}
+	if err := r.replaceDetails(ctx, tx, client); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("erro ao confirmar transação: %w", err)
 	}
 	return nil
 }
@@ -87,7 +422,7 @@ func (r *ClientRepository) DeleteClient(ctx context.Context, clientID uuid.UUID)
 		return fmt.Errorf("erro ao deletar cliente: %w", err)
 	}
 	if cmdTag.RowsAffected() == 0 {
-		return errors.New("cliente não encontrado para deletar")
+		return fmt.Errorf("%w para deletar", domain.ErrClientNotFound)
 	}
 	return nil
 }
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"reflect"
+	"testing"
+
+	"controle-de-estoque/backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+func TestBuildClientWhere(t *testing.T) {
+	productID := uuid.MustParse("7f1c2a3e-0000-4000-8000-000000000001")
+	tests := []struct {
+		name      string
+		filter    domain.ClientFilter
+		wantWhere string
+		wantArgs  []any
+	}{
+		{
+			name:      "sem filtros",
+			filter:    domain.ClientFilter{},
+			wantWhere: "",
+			wantArgs:  nil,
+		},
+		{
+			name:      "busca com curingas é literal",
+			filter:    domain.ClientFilter{Search: "50%_off"},
+			wantWhere: ` WHERE (c.name ILIKE $1 ESCAPE '\' OR c.email ILIKE $1 ESCAPE '\' OR regexp_replace(c.phone, '\D', '', 'g') LIKE $2 OR c.document LIKE $3)`,
+			wantArgs:  []any{`%50\%\_off%`, "%50%", "50%"},
+		},
+		{
+			name:      "busca sem dígitos não compara telefone",
+			filter:    domain.ClientFilter{Search: `ana\`},
+			wantWhere: ` WHERE (c.name ILIKE $1 ESCAPE '\' OR c.email ILIKE $1 ESCAPE '\')`,
+			wantArgs:  []any{`%ana\\%`},
+		},
+		{
+			name:   "produto pai inclui as variantes",
+			filter: domain.ClientFilter{HoldingProductID: &productID, WithStock: true},
+			wantWhere: ` WHERE EXISTS (
+			SELECT 1 FROM client_stocks cs
+			WHERE cs.client_id = c.id AND cs.quantity > 0
+			  AND (cs.product_id = $1 OR cs.product_id IN (SELECT v.id FROM products v WHERE v.parent_id = $1)))`,
+			wantArgs: []any{productID},
+		},
+		{
+			name:      "com saldo de qualquer produto",
+			filter:    domain.ClientFilter{WithStock: true},
+			wantWhere: " WHERE EXISTS (SELECT 1 FROM client_stocks cs WHERE cs.client_id = c.id AND cs.quantity > 0)",
+			wantArgs:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			where, args := buildClientWhere(tt.filter)
+			if where != tt.wantWhere {
+				t.Errorf("where = %q, esperado %q", where, tt.wantWhere)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("args = %#v, esperado %#v", args, tt.wantArgs)
+			}
+		})
+	}
+}
@@ -2,7 +2,9 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"controle-de-estoque/backend/internal/domain"
 
@@ -36,36 +38,265 @@ func (r *ClientStockRepository) Upsert(ctx context.Context, tx pgx.Tx, stock *do
 	return nil
 }
 
-// ListStockByClientID busca o estoque de um cliente, juntando dados do produto.
-func (r *ClientStockRepository) ListStockByClientID(ctx context.Context, clientID uuid.UUID) ([]domain.ClientStockDetails, error) {
+// UpsertLot registra (somando) a quantidade de um lote entregue ao cliente.
+// Deve ser chamado após Upsert, na mesma transação.
+func (r *ClientStockRepository) UpsertLot(ctx context.Context, tx pgx.Tx, clientID, productID uuid.UUID, allocation domain.LotAllocation) error {
+	query := `
+		INSERT INTO client_stock_lots (client_id, product_id, lot_id, quantity)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (client_id, product_id, lot_id) DO UPDATE
+		SET quantity = client_stock_lots.quantity + EXCLUDED.quantity
+	`
+	_, err := tx.Exec(ctx, query, clientID, productID, allocation.LotID, allocation.Quantity)
+	if err != nil {
+		return fmt.Errorf("erro ao registrar lote no estoque do cliente: %w", err)
+	}
+	return nil
+}
+
+// EffectivePrice resolve, na transação, o preço do produto para o cliente agora: o da
+// tabela de preços do cliente vigente ou, se não houver, o de cadastro, com a sua moeda.
+func (r *ClientStockRepository) EffectivePrice(ctx context.Context, tx pgx.Tx, clientID, productID uuid.UUID) (int64, domain.Currency, error) {
+	query := `
+		SELECT COALESCE(lp.price_in_cents, ` + productPriceExpr + `),
+		       COALESCE(lp.currency, ` + productCurrencyExpr + `)
+		FROM products p` + clientPriceJoin("$1", "NOW()") + `
+		WHERE p.id = $2
+	`
+	var price int64
+	var currency domain.Currency
+	if err := tx.QueryRow(ctx, query, clientID, productID).Scan(&price, &currency); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, "", domain.ErrProductNotFound
+		}
+		return 0, "", fmt.Errorf("erro ao buscar preço efetivo do produto: %w", err)
+	}
+	return price, currency, nil
+}
+
+// clientStockSortKeys ordena o estoque do cliente pelo nome do produto, com o ID do produto como desempate.
+var clientStockSortKeys = []sortKey{
+	{expr: "p.name", sqlType: "text"},
+	{expr: "cs.product_id", sqlType: "uuid"},
+}
+
+// ListStockByClientID busca uma página do estoque de um cliente, juntando dados do produto
+// e o preço efetivo para o cliente.
+func (r *ClientStockRepository) ListStockByClientID(ctx context.Context, clientID uuid.UUID, page domain.PageRequest) ([]domain.ClientStockDetails, *int, string, error) {
+	var total *int
+	if page.IncludeTotal {
+		var totalRecords int
+		if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM client_stocks WHERE client_id = $1`, clientID).Scan(&totalRecords); err != nil {
+			return nil, nil, "", fmt.Errorf("erro ao contar estoque do cliente: %w", err)
+		}
+		total = &totalRecords
+	}
+
+	tail, args, err := paginate(" WHERE cs.client_id = $1", []any{clientID}, clientStockSortKeys, page)
+	if err != nil {
+		return nil, nil, "", err
+	}
 	query := `
 		SELECT
 			cs.client_id,
 			cs.product_id,
 			p.name AS product_name,
-			cs.quantity
+			cs.quantity,
+			COALESCE(lp.price_in_cents, ` + productPriceExpr + `),
+			COALESCE(lp.currency, ` + productCurrencyExpr + `),
+			lp.price_list_id` + cursorColumn(clientStockSortKeys) + `
 		FROM
 			client_stocks cs
 		JOIN
-			products p ON cs.product_id = p.id
-		WHERE
-			cs.client_id = $1
-		ORDER BY
-			p.name ASC
-	`
-	rows, err := r.db.Query(ctx, query, clientID)
+			products p ON cs.product_id = p.id` + clientPriceJoin("cs.client_id", "NOW()") + tail
+	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("erro ao listar estoque do cliente: %w", err)
+		return nil, nil, "", fmt.Errorf("erro ao listar estoque do cliente: %w", err)
 	}
 	defer rows.Close()
 
-	stocks := make([]domain.ClientStockDetails, 0)
+	stocks := make([]domain.ClientStockDetails, 0, page.Limit+1)
+	var keyValues [][]string
 	for rows.Next() {
 		var s domain.ClientStockDetails
-		if err := rows.Scan(&s.ClientID, &s.ProductID, &s.ProductName, &s.Quantity); err != nil {
-			return nil, fmt.Errorf("erro ao escanear estoque do cliente: %w", err)
+		var values []string
+		if err := rows.Scan(&s.ClientID, &s.ProductID, &s.ProductName, &s.Quantity,
+			&s.UnitPriceInCents, &s.Currency, &s.PriceListID, &values); err != nil {
+			return nil, nil, "", fmt.Errorf("erro ao escanear estoque do cliente: %w", err)
 		}
+		s.TotalInCents = int64(s.Quantity) * s.UnitPriceInCents
 		stocks = append(stocks, s)
+		keyValues = append(keyValues, values)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, "", fmt.Errorf("erro ao iterar pelo estoque do cliente: %w", err)
+	}
+
+	stocks, next := trimPage(stocks, keyValues, clientStockSortKeys, page.Limit)
+	if err := r.attachLots(ctx, clientID, stocks); err != nil {
+		return nil, nil, "", err
+	}
+	return stocks, total, next, nil
+}
+
+// ExportHoldings percorre o estoque dos clientes que atende ao filtro, ordenado pelo
+// nome do cliente e do produto, chamando fn para cada saldo sem carregá-los todos em
+// memória. Um erro de fn interrompe a leitura e é devolvido como está.
+func (r *ClientStockRepository) ExportHoldings(ctx context.Context, filter domain.HoldingFilter, fn func(domain.ClientHolding) error) error {
+	var conditions []string
+	var args []any
+	if filter.ClientID != nil {
+		args = append(args, *filter.ClientID)
+		conditions = append(conditions, fmt.Sprintf("cs.client_id = $%d", len(args)))
+	}
+	if filter.ProductID != nil {
+		args = append(args, *filter.ProductID)
+		conditions = append(conditions, fmt.Sprintf("cs.product_id = $%d", len(args)))
+	}
+	if filter.WithStock {
+		conditions = append(conditions, "cs.quantity > 0")
+	}
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	query := `
+		SELECT
+			cs.client_id, c.name, COALESCE(c.document, ''),
+			cs.product_id, COALESCE(p.sku, ''), p.name, p.unit,
+			cs.quantity
+		FROM client_stocks cs
+		JOIN clients c ON c.id = cs.client_id
+		JOIN products p ON p.id = cs.product_id` + where + `
+		ORDER BY c.name, cs.client_id, p.name, cs.product_id`
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("erro ao exportar estoque dos clientes: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var h domain.ClientHolding
+		err := rows.Scan(&h.ClientID, &h.ClientName, &h.ClientDocument,
+			&h.ProductID, &h.SKU, &h.ProductName, &h.Unit, &h.Quantity)
+		if err != nil {
+			return fmt.Errorf("erro ao escanear estoque do cliente: %w", err)
+		}
+		if err := fn(h); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("erro ao exportar estoque dos clientes: %w", err)
+	}
+	return nil
+}
+
+// attachLots preenche os lotes entregues ao cliente em cada item da página.
+func (r *ClientStockRepository) attachLots(ctx context.Context, clientID uuid.UUID, stocks []domain.ClientStockDetails) error {
+	if len(stocks) == 0 {
+		return nil
+	}
+	productIDs := make([]uuid.UUID, len(stocks))
+	for i, s := range stocks {
+		productIDs[i] = s.ProductID
+	}
+
+	query := `
+		SELECT csl.product_id, l.id, l.batch_number, l.expiry_date, csl.quantity
+		FROM client_stock_lots csl
+		JOIN lots l ON l.id = csl.lot_id
+		WHERE csl.client_id = $1 AND csl.product_id = ANY($2) AND csl.quantity > 0
+		ORDER BY l.expiry_date ASC NULLS LAST, l.batch_number ASC
+	`
+	rows, err := r.db.Query(ctx, query, clientID, productIDs)
+	if err != nil {
+		return fmt.Errorf("erro ao listar lotes do cliente: %w", err)
+	}
+	defer rows.Close()
+
+	byProduct := make(map[uuid.UUID][]domain.LotAllocation)
+	for rows.Next() {
+		var productID uuid.UUID
+		var a domain.LotAllocation
+		if err := rows.Scan(&productID, &a.LotID, &a.BatchNumber, &a.ExpiryDate, &a.Quantity); err != nil {
+			return fmt.Errorf("erro ao escanear lote do cliente: %w", err)
+		}
+		byProduct[productID] = append(byProduct[productID], a)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("erro ao iterar pelos lotes do cliente: %w", err)
+	}
+
+	for i := range stocks {
+		stocks[i].Lots = byProduct[stocks[i].ProductID]
+	}
+	return nil
+}
+
+// ListByClientIDs retorna os saldos positivos dos clientes informados, ordenados por cliente e produto.
+func (r *ClientStockRepository) ListByClientIDs(ctx context.Context, clientIDs []uuid.UUID) ([]domain.ClientStock, error) {
+	return r.listStocks(ctx, `client_id = ANY($1)`, clientIDs)
+}
+
+// ListByProductIDs retorna os saldos positivos dos produtos informados, ordenados por cliente e produto.
+func (r *ClientStockRepository) ListByProductIDs(ctx context.Context, productIDs []uuid.UUID) ([]domain.ClientStock, error) {
+	return r.listStocks(ctx, `product_id = ANY($1)`, productIDs)
+}
+
+func (r *ClientStockRepository) listStocks(ctx context.Context, condition string, ids []uuid.UUID) ([]domain.ClientStock, error) {
+	query := `
+		SELECT client_id, product_id, quantity
+		FROM client_stocks
+		WHERE ` + condition + ` AND quantity > 0
+		ORDER BY client_id, product_id
+	`
+	rows, err := r.db.Query(ctx, query, ids)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar estoques de clientes: %w", err)
+	}
+	stocks, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.ClientStock, error) {
+		var s domain.ClientStock
+		err := row.Scan(&s.ClientID, &s.ProductID, &s.Quantity)
+		return s, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao escanear estoque de cliente: %w", err)
 	}
 	return stocks, nil
 }
+
+// Decrement retira a quantidade do estoque do cliente, por exemplo em uma devolução.
+// Falha se o cliente não possuir a quantidade informada do produto.
+func (r *ClientStockRepository) Decrement(ctx context.Context, tx pgx.Tx, clientID, productID uuid.UUID, quantity int) error {
+	query := `
+		UPDATE client_stocks SET quantity = quantity - $3
+		WHERE client_id = $1 AND product_id = $2 AND quantity >= $3
+	`
+	cmdTag, err := tx.Exec(ctx, query, clientID, productID, quantity)
+	if err != nil {
+		return fmt.Errorf("erro ao retirar do estoque do cliente: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: o cliente não possui %d unidades do produto", domain.ErrInsufficientStock, quantity)
+	}
+	return nil
+}
+
+// DecrementLot retira a quantidade de um lote do estoque do cliente.
+// Deve ser chamado após Decrement, na mesma transação.
+func (r *ClientStockRepository) DecrementLot(ctx context.Context, tx pgx.Tx, clientID, productID uuid.UUID, allocation domain.LotAllocation) error {
+	query := `
+		UPDATE client_stock_lots SET quantity = quantity - $4
+		WHERE client_id = $1 AND product_id = $2 AND lot_id = $3 AND quantity >= $4
+	`
+	cmdTag, err := tx.Exec(ctx, query, clientID, productID, allocation.LotID, allocation.Quantity)
+	if err != nil {
+		return fmt.Errorf("erro ao retirar lote do estoque do cliente: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: o cliente não possui %d unidades do lote %s", domain.ErrInsufficientStock, allocation.Quantity, allocation.BatchNumber)
+	}
+	return nil
+}
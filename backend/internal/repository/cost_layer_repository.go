@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// CostLayerRepository gerencia as camadas de custo PEPS (FIFO) dos produtos.
+type CostLayerRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewCostLayerRepository cria uma nova instância de CostLayerRepository.
+func NewCostLayerRepository(db *pgxpool.Pool) *CostLayerRepository {
+	return &CostLayerRepository{db: db}
+}
+
+// AddLayer registra uma entrada de quantity unidades ao custo unitário informado.
+func (r *CostLayerRepository) AddLayer(ctx context.Context, tx pgx.Tx, productID uuid.UUID, unitCost int64, quantity int) error {
+	const query = `
+		INSERT INTO cost_layers (product_id, unit_cost_in_cents, quantity, remaining)
+		VALUES ($1, $2, $3, $3)
+	`
+	if _, err := tx.Exec(ctx, query, productID, unitCost, quantity); err != nil {
+		return fmt.Errorf("erro ao registrar camada de custo: %w", err)
+	}
+	return nil
+}
+
+// ConsumeLayers baixa até quantity unidades das camadas mais antigas do produto e
+// retorna quantas foram baixadas e o custo total delas. Deve ser chamado com o produto
+// bloqueado na mesma transação.
+func (r *CostLayerRepository) ConsumeLayers(ctx context.Context, tx pgx.Tx, productID uuid.UUID, quantity int) (int, int64, error) {
+	const query = `
+		SELECT id, unit_cost_in_cents, remaining
+		FROM cost_layers
+		WHERE product_id = $1 AND remaining > 0
+		ORDER BY created_at, id
+		FOR UPDATE
+	`
+	rows, err := tx.Query(ctx, query, productID)
+	if err != nil {
+		return 0, 0, fmt.Errorf("erro ao buscar camadas de custo: %w", err)
+	}
+	type take struct {
+		id       uuid.UUID
+		quantity int
+	}
+	var takes []take
+	consumed, cost := 0, int64(0)
+	for rows.Next() && consumed < quantity {
+		var id uuid.UUID
+		var unitCost int64
+		var remaining int
+		if err := rows.Scan(&id, &unitCost, &remaining); err != nil {
+			rows.Close()
+			return 0, 0, fmt.Errorf("erro ao escanear camada de custo: %w", err)
+		}
+		n := min(remaining, quantity-consumed)
+		takes = append(takes, take{id: id, quantity: n})
+		consumed += n
+		cost += unitCost * int64(n)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, 0, fmt.Errorf("erro ao buscar camadas de custo: %w", err)
+	}
+
+	for _, t := range takes {
+		if _, err := tx.Exec(ctx, `UPDATE cost_layers SET remaining = remaining - $1 WHERE id = $2`, t.quantity, t.id); err != nil {
+			return 0, 0, fmt.Errorf("erro ao baixar camada de custo: %w", err)
+		}
+	}
+	return consumed, cost, nil
+}
+
+// DeleteLayers remove as camadas do produto, ao trocar o método de custeio.
+func (r *CostLayerRepository) DeleteLayers(ctx context.Context, tx pgx.Tx, productID uuid.UUID) error {
+	if _, err := tx.Exec(ctx, `DELETE FROM cost_layers WHERE product_id = $1`, productID); err != nil {
+		return fmt.Errorf("erro ao remover camadas de custo: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+
+	"controle-de-estoque/backend/internal/domain"
+)
+
+// sortKey é uma chave de ordenação de uma listagem paginada por cursor. A última
+// chave de toda listagem deve ser única (normalmente o ID) para que a ordem seja estável.
+type sortKey struct {
+	expr    string // Expressão SQL, sempre vinda de uma lista branca do repositório
+	sqlType string // Tipo usado para converter o valor guardado no cursor (text, bigint, timestamptz...)
+	desc    bool
+}
+
+// pageCursor é o conteúdo do cursor opaco: a assinatura da ordenação e os valores
+// das chaves da última linha entregue.
+type pageCursor struct {
+	Sort   string   `json:"s"`
+	Values []string `json:"v"`
+}
+
+// sortSignature identifica a ordenação, para rejeitar cursores gerados com outro sort.
+func sortSignature(keys []sortKey) string {
+	h := fnv.New64a()
+	for _, k := range keys {
+		fmt.Fprintf(h, "%s|%s|%t;", k.expr, k.sqlType, k.desc)
+	}
+	return strconv.FormatUint(h.Sum64(), 36)
+}
+
+func encodeCursor(keys []sortKey, values []string) string {
+	data, _ := json.Marshal(pageCursor{Sort: sortSignature(keys), Values: values})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(token string, keys []sortKey) ([]string, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, domain.ErrInvalidCursor
+	}
+	var c pageCursor
+	if err := json.Unmarshal(data, &c); err != nil || len(c.Values) != len(keys) {
+		return nil, domain.ErrInvalidCursor
+	}
+	if c.Sort != sortSignature(keys) {
+		return nil, fmt.Errorf("%w: a ordenação mudou desde a página anterior", domain.ErrInvalidCursor)
+	}
+	return c.Values, nil
+}
+
+// cursorColumn seleciona os valores das chaves como um array de texto, lido junto de
+// cada linha para montar o próximo cursor.
+func cursorColumn(keys []sortKey) string {
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = "(" + k.expr + ")::text"
+	}
+	return ", ARRAY[" + strings.Join(parts, ", ") + "]"
+}
+
+func orderByClause(keys []sortKey) string {
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = k.expr + " ASC"
+		if k.desc {
+			parts[i] = k.expr + " DESC"
+		}
+	}
+	return " ORDER BY " + strings.Join(parts, ", ")
+}
+
+// paginate completa uma listagem com a condição do cursor (keyset), o ORDER BY e o
+// LIMIT/OFFSET. Busca uma linha a mais que o limite para saber se há próxima página.
+// where deve estar vazio ou começar com " WHERE ".
+func paginate(where string, args []any, keys []sortKey, page domain.PageRequest) (string, []any, error) {
+	if page.Cursor != "" {
+		values, err := decodeCursor(page.Cursor, keys)
+		if err != nil {
+			return "", nil, err
+		}
+		var condition string
+		condition, args = keysetCondition(keys, values, args)
+		if where == "" {
+			where = " WHERE " + condition
+		} else {
+			where += " AND " + condition
+		}
+	}
+	args = append(args, page.Limit+1, page.Offset())
+	tail := fmt.Sprintf("%s LIMIT $%d OFFSET $%d", orderByClause(keys), len(args)-1, len(args))
+	return where + tail, args, nil
+}
+
+// keysetCondition monta "(k1 > v1) OR (k1 = v1 AND k2 > v2) OR ...", invertendo a
+// comparação nas chaves descendentes.
+func keysetCondition(keys []sortKey, values []string, args []any) (string, []any) {
+	placeholders := make([]string, len(keys))
+	for i, k := range keys {
+		args = append(args, values[i])
+		placeholders[i] = fmt.Sprintf("CAST($%d::text AS %s)", len(args), k.sqlType)
+	}
+
+	alternatives := make([]string, len(keys))
+	for i, k := range keys {
+		terms := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			terms = append(terms, fmt.Sprintf("(%s) = %s", keys[j].expr, placeholders[j]))
+		}
+		op := ">"
+		if k.desc {
+			op = "<"
+		}
+		terms = append(terms, fmt.Sprintf("(%s) %s %s", k.expr, op, placeholders[i]))
+		alternatives[i] = "(" + strings.Join(terms, " AND ") + ")"
+	}
+	return "(" + strings.Join(alternatives, " OR ") + ")", args
+}
+
+// trimPage descarta a linha extra buscada por paginate e gera o cursor da próxima
+// página a partir dos valores de chave da última linha mantida.
+func trimPage[T any](items []T, keyValues [][]string, keys []sortKey, limit int) ([]T, string) {
+	if len(items) <= limit {
+		return items, ""
+	}
+	return items[:limit], encodeCursor(keys, keyValues[limit-1])
+}
@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"encoding/base64"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"controle-de-estoque/backend/internal/domain"
+)
+
+var testSortKeys = []sortKey{
+	{expr: "p.name", sqlType: "text"},
+	{expr: "p.created_at", sqlType: "timestamptz", desc: true},
+	{expr: "p.id", sqlType: "uuid"},
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	tests := [][]string{
+		{"Caneta", "2026-10-18T12:00:00Z", "7f1c7a8e-1d2b-4c59-9d7a-3f0a6e1b2c3d"},
+		{"", "", ""},
+		{"nome com \"aspas\" e acentuação", "2026-01-01T00:00:00Z", "00000000-0000-0000-0000-000000000000"},
+	}
+	for _, values := range tests {
+		token := encodeCursor(testSortKeys, values)
+		got, err := decodeCursor(token, testSortKeys)
+		if err != nil {
+			t.Fatalf("decodeCursor(encodeCursor(%q)) erro inesperado: %v", values, err)
+		}
+		if !slices.Equal(got, values) {
+			t.Errorf("decodeCursor(encodeCursor(%q)) = %q", values, got)
+		}
+	}
+}
+
+func TestDecodeCursorRejects(t *testing.T) {
+	valid := encodeCursor(testSortKeys, []string{"a", "b", "c"})
+	otherSort := slices.Clone(testSortKeys)
+	otherSort[1].desc = false
+
+	tests := []struct {
+		name  string
+		token string
+		keys  []sortKey
+	}{
+		{"base64 inválido", "não é base64!", testSortKeys},
+		{"JSON inválido", base64.RawURLEncoding.EncodeToString([]byte("{")), testSortKeys},
+		{"quantidade de valores diferente", encodeCursor(testSortKeys[:2], []string{"a", "b"}), testSortKeys},
+		{"ordenação diferente", valid, otherSort},
+		{"assinatura adulterada", base64.RawURLEncoding.EncodeToString([]byte(`{"s":"x","v":["a","b","c"]}`)), testSortKeys},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decodeCursor(tt.token, tt.keys); !errors.Is(err, domain.ErrInvalidCursor) {
+				t.Errorf("decodeCursor erro = %v, esperado ErrInvalidCursor", err)
+			}
+		})
+	}
+}
+
+func TestSortSignature(t *testing.T) {
+	reordered := []sortKey{testSortKeys[1], testSortKeys[0], testSortKeys[2]}
+	retyped := slices.Clone(testSortKeys)
+	retyped[0].sqlType = "varchar"
+	for name, keys := range map[string][]sortKey{"ordem": reordered, "tipo": retyped} {
+		if sortSignature(keys) == sortSignature(testSortKeys) {
+			t.Errorf("mudança de %s não alterou a assinatura", name)
+		}
+	}
+	if sortSignature(testSortKeys) != sortSignature(slices.Clone(testSortKeys)) {
+		t.Error("a assinatura deve ser determinística")
+	}
+}
+
+func TestKeysetCondition(t *testing.T) {
+	condition, args := keysetCondition(testSortKeys, []string{"a", "b", "c"}, []any{"filtro"})
+	want := "(((p.name) > CAST($2::text AS text))" +
+		" OR ((p.name) = CAST($2::text AS text) AND (p.created_at) < CAST($3::text AS timestamptz))" +
+		" OR ((p.name) = CAST($2::text AS text) AND (p.created_at) = CAST($3::text AS timestamptz) AND (p.id) > CAST($4::text AS uuid)))"
+	if condition != want {
+		t.Errorf("keysetCondition =\n%s\nesperado\n%s", condition, want)
+	}
+	if !slices.Equal(args, []any{"filtro", "a", "b", "c"}) {
+		t.Errorf("argumentos = %v", args)
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	tail, args, err := paginate(" WHERE p.x = $1", []any{1}, testSortKeys, domain.PageRequest{Page: 3, Limit: 10})
+	if err != nil {
+		t.Fatalf("paginate erro inesperado: %v", err)
+	}
+	if !strings.HasSuffix(tail, "ORDER BY p.name ASC, p.created_at DESC, p.id ASC LIMIT $2 OFFSET $3") {
+		t.Errorf("paginate por página = %q", tail)
+	}
+	if !slices.Equal(args, []any{1, 11, 20}) {
+		t.Errorf("argumentos = %v, esperado [1 11 20]", args)
+	}
+
+	cursor := encodeCursor(testSortKeys, []string{"a", "b", "c"})
+	tail, args, err = paginate("", nil, testSortKeys, domain.PageRequest{Cursor: cursor, Limit: 5})
+	if err != nil {
+		t.Fatalf("paginate com cursor erro inesperado: %v", err)
+	}
+	if !strings.HasPrefix(tail, " WHERE (((p.name) > CAST($1::text AS text))") || !strings.HasSuffix(tail, "LIMIT $4 OFFSET $5") {
+		t.Errorf("paginate com cursor = %q", tail)
+	}
+	if len(args) != 5 || args[3] != 6 {
+		t.Errorf("argumentos = %v, esperado limite 6 em $4", args)
+	}
+
+	if _, _, err := paginate("", nil, testSortKeys, domain.PageRequest{Cursor: "x", Limit: 5}); !errors.Is(err, domain.ErrInvalidCursor) {
+		t.Errorf("cursor inválido: erro = %v", err)
+	}
+}
+
+func TestTrimPage(t *testing.T) {
+	keys := [][]string{{"a", "1", "x"}, {"b", "2", "y"}, {"c", "3", "z"}}
+
+	items, next := trimPage([]int{1, 2, 3}, keys, testSortKeys, 3)
+	if len(items) != 3 || next != "" {
+		t.Errorf("última página: %v, cursor %q", items, next)
+	}
+
+	items, next = trimPage([]int{1, 2, 3}, keys, testSortKeys, 2)
+	if !slices.Equal(items, []int{1, 2}) {
+		t.Errorf("itens = %v, esperado [1 2]", items)
+	}
+	values, err := decodeCursor(next, testSortKeys)
+	if err != nil || !slices.Equal(values, keys[1]) {
+		t.Errorf("próximo cursor = %q (%v), esperado as chaves do segundo item", values, err)
+	}
+}
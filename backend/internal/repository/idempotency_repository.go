@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"controle-de-estoque/backend/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// IdempotencyRepository gerencia as respostas gravadas para as chaves de idempotência.
+type IdempotencyRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewIdempotencyRepository cria uma nova instância de IdempotencyRepository.
+func NewIdempotencyRepository(db *pgxpool.Pool) *IdempotencyRepository {
+	return &IdempotencyRepository{db: db}
+}
+
+const idempotencyColumns = `scope, key, request_hash, status_code, response_body, created_at, expires_at`
+
+// Get busca a resposta gravada para a chave, ignorando as expiradas. Retorna nil se não houver.
+func (r *IdempotencyRepository) Get(ctx context.Context, scope, key string) (*domain.IdempotencyRecord, error) {
+	return r.get(ctx, r.db, scope, key)
+}
+
+// Lock serializa, até o fim da transação, as requisições com a mesma chave e então
+// busca a resposta gravada, que pode ter sido concluída por uma tentativa concorrente.
+func (r *IdempotencyRepository) Lock(ctx context.Context, tx pgx.Tx, scope, key string) (*domain.IdempotencyRecord, error) {
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtextextended($1 || ':' || $2, 0))`, scope, key); err != nil {
+		return nil, fmt.Errorf("erro ao bloquear chave de idempotência: %w", err)
+	}
+	return r.get(ctx, tx, scope, key)
+}
+
+// Save grava a resposta da chave na transação da operação. Um registro expirado com a
+// mesma chave é substituído.
+func (r *IdempotencyRepository) Save(ctx context.Context, tx pgx.Tx, record *domain.IdempotencyRecord) error {
+	const query = `
+		INSERT INTO idempotency_keys (scope, key, request_hash, status_code, response_body, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (scope, key) DO UPDATE
+		SET request_hash = EXCLUDED.request_hash, status_code = EXCLUDED.status_code,
+		    response_body = EXCLUDED.response_body, created_at = NOW(), expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at <= NOW()
+		RETURNING created_at
+	`
+	err := tx.QueryRow(ctx, query, record.Scope, record.Key, record.RequestHash, record.StatusCode, record.ResponseBody, record.ExpiresAt).
+		Scan(&record.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("erro ao gravar chave de idempotência: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpired remove as chaves expiradas e retorna quantas foram removidas.
+func (r *IdempotencyRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	cmdTag, err := r.db.Exec(ctx, `DELETE FROM idempotency_keys WHERE expires_at <= NOW()`)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao remover chaves de idempotência expiradas: %w", err)
+	}
+	return cmdTag.RowsAffected(), nil
+}
+
+type rowQuerier interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+func (r *IdempotencyRepository) get(ctx context.Context, q rowQuerier, scope, key string) (*domain.IdempotencyRecord, error) {
+	query := `SELECT ` + idempotencyColumns + ` FROM idempotency_keys WHERE scope = $1 AND key = $2 AND expires_at > NOW()`
+	var rec domain.IdempotencyRecord
+	err := q.QueryRow(ctx, query, scope, key).Scan(
+		&rec.Scope, &rec.Key, &rec.RequestHash, &rec.StatusCode, &rec.ResponseBody, &rec.CreatedAt, &rec.ExpiresAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("erro ao buscar chave de idempotência: %w", err)
+	}
+	return &rec, nil
+}
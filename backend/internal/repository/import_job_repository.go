@@ -0,0 +1,250 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"controle-de-estoque/backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ImportJobRepository gerencia os jobs de importação de produtos e o resultado de suas linhas.
+type ImportJobRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewImportJobRepository cria uma nova instância de ImportJobRepository.
+func NewImportJobRepository(db *pgxpool.Pool) *ImportJobRepository {
+	return &ImportJobRepository{db: db}
+}
+
+// importJobColumns lista as colunas lidas em importJobScanTargets, na mesma ordem. O arquivo
+// só é lido ao assumir o job.
+const importJobColumns = `
+	j.id, j.created_by, j.status, j.dry_run, j.file_name, j.format, j.mapping,
+	j.total_rows, j.processed_rows, j.created_rows, j.updated_rows, j.unchanged_rows, j.error_rows, j.failure,
+	j.created_at, j.started_at, j.finished_at
+`
+
+func importJobScanTargets(j *domain.ImportJob) []any {
+	return []any{
+		&j.ID, &j.CreatedBy, &j.Status, &j.DryRun, &j.FileName, &j.Format, &j.Mapping,
+		&j.TotalRows, &j.ProcessedRows, &j.CreatedRows, &j.UpdatedRows, &j.UnchangedRows, &j.ErrorRows, &j.Failure,
+		&j.CreatedAt, &j.StartedAt, &j.FinishedAt,
+	}
+}
+
+// Create registra um job pendente, junto com o arquivo a importar.
+func (r *ImportJobRepository) Create(ctx context.Context, job *domain.ImportJob) error {
+	const query = `
+		INSERT INTO import_jobs (created_by, dry_run, file_name, format, mapping, file, total_rows)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, status, created_at
+	`
+	err := r.db.QueryRow(ctx, query, job.CreatedBy, job.DryRun, job.FileName, job.Format, job.Mapping, job.File, job.TotalRows).
+		Scan(&job.ID, &job.Status, &job.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("erro ao criar job de importação: %w", err)
+	}
+	return nil
+}
+
+// GetByID busca um job de importação, sem o arquivo.
+func (r *ImportJobRepository) GetByID(ctx context.Context, jobID uuid.UUID) (*domain.ImportJob, error) {
+	query := `SELECT ` + importJobColumns + ` FROM import_jobs j WHERE j.id = $1`
+	var job domain.ImportJob
+	if err := r.db.QueryRow(ctx, query, jobID).Scan(importJobScanTargets(&job)...); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrImportJobNotFound
+		}
+		return nil, fmt.Errorf("erro ao buscar job de importação: %w", err)
+	}
+	return &job, nil
+}
+
+// Claim assume o job pendente mais antigo, ou um job em processamento cuja instância
+// parou de dar sinal há mais de staleAfter. O processamento recomeça do início, com os
+// contadores zerados e as linhas já gravadas removidas. Retorna nil se não houver job.
+func (r *ImportJobRepository) Claim(ctx context.Context, staleAfter time.Duration) (*domain.ImportJob, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao iniciar transação: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	query := `
+		UPDATE import_jobs j
+		SET status = 'running', started_at = NOW(), heartbeat_at = NOW(),
+		    processed_rows = 0, created_rows = 0, updated_rows = 0, unchanged_rows = 0, error_rows = 0
+		WHERE j.id = (
+			SELECT id FROM import_jobs
+			WHERE status = 'pending' OR (status = 'running' AND heartbeat_at < NOW() - make_interval(secs => $1))
+			ORDER BY created_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + importJobColumns + `, j.file
+	`
+	var job domain.ImportJob
+	err = tx.QueryRow(ctx, query, staleAfter.Seconds()).Scan(append(importJobScanTargets(&job), &job.File)...)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("erro ao assumir job de importação: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM import_job_rows WHERE job_id = $1`, job.ID); err != nil {
+		return nil, fmt.Errorf("erro ao limpar linhas do job de importação: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("erro ao confirmar transação: %w", err)
+	}
+	return &job, nil
+}
+
+// SaveProgress grava o resultado de um lote de linhas e atualiza os contadores e o
+// sinal de vida do job.
+func (r *ImportJobRepository) SaveProgress(ctx context.Context, job *domain.ImportJob, rows []domain.ImportRow) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("erro ao iniciar transação: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	_, err = tx.CopyFrom(ctx,
+		pgx.Identifier{"import_job_rows"},
+		[]string{"job_id", "row_number", "sku", "action", "product_id", "changes", "errors"},
+		pgx.CopyFromSlice(len(rows), func(i int) ([]any, error) {
+			row := rows[i]
+			changes, errs := row.Changes, row.Errors
+			if changes == nil {
+				changes = []domain.ImportChange{}
+			}
+			if errs == nil {
+				errs = []domain.FieldError{}
+			}
+			return []any{job.ID, row.RowNumber, row.SKU, row.Action, row.ProductID, changes, errs}, nil
+		}),
+	)
+	if err != nil {
+		return fmt.Errorf("erro ao gravar linhas do job de importação: %w", err)
+	}
+	if err := r.updateCounters(ctx, tx, job, `heartbeat_at = NOW()`); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("erro ao confirmar transação: %w", err)
+	}
+	return nil
+}
+
+// Finish registra o fim do job, com a situação e os contadores finais, e descarta o arquivo.
+func (r *ImportJobRepository) Finish(ctx context.Context, job *domain.ImportJob) error {
+	return r.updateCounters(ctx, r.db, job, `status = $8, failure = $9, file = NULL, finished_at = NOW()`, job.Status, job.Failure)
+}
+
+// updateCounters grava os contadores do job ($1 a $7) junto com as atribuições de
+// set, cujos parâmetros começam em $8.
+func (r *ImportJobRepository) updateCounters(ctx context.Context, q rowQuerier, job *domain.ImportJob, set string, args ...any) error {
+	query := `
+		UPDATE import_jobs
+		SET processed_rows = $2, created_rows = $3, updated_rows = $4, unchanged_rows = $5, error_rows = $6, total_rows = $7,
+		    ` + set + `
+		WHERE id = $1
+		RETURNING id
+	`
+	args = append([]any{job.ID, job.ProcessedRows, job.CreatedRows, job.UpdatedRows, job.UnchangedRows, job.ErrorRows, job.TotalRows}, args...)
+
+	var id uuid.UUID
+	if err := q.QueryRow(ctx, query, args...).Scan(&id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrImportJobNotFound
+		}
+		return fmt.Errorf("erro ao atualizar job de importação: %w", err)
+	}
+	return nil
+}
+
+// importRowSortKeys ordena as linhas de um job pela posição no arquivo.
+var importRowSortKeys = []sortKey{{expr: "r.row_number", sqlType: "integer"}}
+
+const importRowColumns = `r.row_number, r.sku, r.action, r.product_id, r.changes, r.errors`
+
+func importRowScanTargets(row *domain.ImportRow) []any {
+	return []any{&row.RowNumber, &row.SKU, &row.Action, &row.ProductID, &row.Changes, &row.Errors}
+}
+
+// ListRows retorna uma página das linhas do job, opcionalmente apenas as de uma ação.
+func (r *ImportJobRepository) ListRows(ctx context.Context, jobID uuid.UUID, action domain.ImportAction, page domain.PageRequest) ([]domain.ImportRow, *int, string, error) {
+	where := ` WHERE r.job_id = $1`
+	args := []any{jobID}
+	if action != "" {
+		args = append(args, action)
+		where += fmt.Sprintf(` AND r.action = $%d`, len(args))
+	}
+
+	var total *int
+	if page.IncludeTotal {
+		var count int
+		if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM import_job_rows r`+where, args...).Scan(&count); err != nil {
+			return nil, nil, "", fmt.Errorf("erro ao contar linhas do job de importação: %w", err)
+		}
+		total = &count
+	}
+
+	tail, args, err := paginate(where, args, importRowSortKeys, page)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	query := `SELECT ` + importRowColumns + cursorColumn(importRowSortKeys) + ` FROM import_job_rows r` + tail
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("erro ao listar linhas do job de importação: %w", err)
+	}
+	defer rows.Close()
+
+	items := make([]domain.ImportRow, 0, page.Limit)
+	var keyValues [][]string
+	for rows.Next() {
+		var row domain.ImportRow
+		var values []string
+		if err := rows.Scan(append(importRowScanTargets(&row), &values)...); err != nil {
+			return nil, nil, "", fmt.Errorf("erro ao escanear linha do job de importação: %w", err)
+		}
+		items = append(items, row)
+		keyValues = append(keyValues, values)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, "", fmt.Errorf("erro ao listar linhas do job de importação: %w", err)
+	}
+
+	items, next := trimPage(items, keyValues, importRowSortKeys, page.Limit)
+	return items, total, next, nil
+}
+
+// EachErrorRow percorre as linhas rejeitadas do job na ordem do arquivo, sem
+// carregá-las todas em memória.
+func (r *ImportJobRepository) EachErrorRow(ctx context.Context, jobID uuid.UUID, fn func(domain.ImportRow) error) error {
+	query := `SELECT ` + importRowColumns + ` FROM import_job_rows r WHERE r.job_id = $1 AND r.action = 'error' ORDER BY r.row_number`
+	rows, err := r.db.Query(ctx, query, jobID)
+	if err != nil {
+		return fmt.Errorf("erro ao listar erros do job de importação: %w", err)
+	}
+	defer rows.Close()
+
+	var row domain.ImportRow
+	_, err = pgx.ForEachRow(rows, importRowScanTargets(&row), func() error {
+		return fn(row)
+	})
+	if err != nil {
+		return fmt.Errorf("erro ao listar erros do job de importação: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"controle-de-estoque/backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// LotRepository gerencia os lotes (batch e validade) dos produtos.
+type LotRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewLotRepository cria uma nova instância de LotRepository.
+func NewLotRepository(db *pgxpool.Pool) *LotRepository {
+	return &LotRepository{db: db}
+}
+
+const lotColumns = `l.id, l.product_id, l.batch_number, l.expiry_date, l.quantity, l.received_quantity, l.created_at, l.updated_at`
+
+func scanLot(row pgx.Row, l *domain.Lot) error {
+	return row.Scan(&l.ID, &l.ProductID, &l.BatchNumber, &l.ExpiryDate, &l.Quantity, &l.ReceivedQuantity, &l.CreatedAt, &l.UpdatedAt)
+}
+
+// ListByProduct retorna os lotes de um produto na ordem FEFO (primeiro a vencer, primeiro a sair).
+func (r *LotRepository) ListByProduct(ctx context.Context, productID uuid.UUID) ([]domain.Lot, error) {
+	query := `SELECT ` + lotColumns + ` FROM lots l WHERE l.product_id = $1 ORDER BY l.expiry_date ASC NULLS LAST, l.created_at ASC`
+	return r.queryLots(ctx, r.db, query, productID)
+}
+
+// Receive soma a quantidade ao lote informado, criando-o se ainda não existir.
+// Um lote existente só é aceito se a validade informada for a mesma já registrada.
+func (r *LotRepository) Receive(ctx context.Context, tx pgx.Tx, lot *domain.Lot) error {
+	query := `
+		INSERT INTO lots AS l (product_id, batch_number, expiry_date, quantity, received_quantity)
+		VALUES ($1, $2, $3, $4, $4)
+		ON CONFLICT (product_id, batch_number) DO UPDATE
+		SET quantity = l.quantity + EXCLUDED.quantity,
+		    received_quantity = l.received_quantity + EXCLUDED.received_quantity,
+		    updated_at = NOW()
+		WHERE l.expiry_date IS NOT DISTINCT FROM EXCLUDED.expiry_date
+		RETURNING ` + lotColumns
+	err := scanLot(tx.QueryRow(ctx, query, lot.ProductID, lot.BatchNumber, lot.ExpiryDate, lot.Quantity), lot)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%w: o lote %q já existe com outra validade", domain.ErrInvalidLot, lot.BatchNumber)
+		}
+		return fmt.Errorf("erro ao registrar lote: %w", err)
+	}
+	return nil
+}
+
+// GetByBatchForUpdate busca e bloqueia um lote pelo número dentro da transação.
+func (r *LotRepository) GetByBatchForUpdate(ctx context.Context, tx pgx.Tx, productID uuid.UUID, batchNumber string) (*domain.Lot, error) {
+	query := `SELECT ` + lotColumns + ` FROM lots l WHERE l.product_id = $1 AND l.batch_number = $2 FOR UPDATE`
+	var l domain.Lot
+	if err := scanLot(tx.QueryRow(ctx, query, productID, batchNumber), &l); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrLotNotFound
+		}
+		return nil, fmt.Errorf("erro ao buscar lote: %w", err)
+	}
+	return &l, nil
+}
+
+// ListAvailableForUpdate bloqueia e retorna, em ordem FEFO, os lotes com saldo e ainda
+// dentro da validade. Lotes vencidos nunca são alocados automaticamente.
+func (r *LotRepository) ListAvailableForUpdate(ctx context.Context, tx pgx.Tx, productID uuid.UUID) ([]domain.Lot, error) {
+	query := `
+		SELECT ` + lotColumns + `
+		FROM lots l
+		WHERE l.product_id = $1 AND l.quantity > 0
+		  AND (l.expiry_date IS NULL OR l.expiry_date >= CURRENT_DATE)
+		ORDER BY l.expiry_date ASC NULLS LAST, l.created_at ASC
+		FOR UPDATE
+	`
+	return r.queryLots(ctx, tx, query, productID)
+}
+
+// UpdateQuantity atualiza o saldo de um lote dentro da transação.
+func (r *LotRepository) UpdateQuantity(ctx context.Context, tx pgx.Tx, lotID uuid.UUID, newQuantity int) error {
+	cmdTag, err := tx.Exec(ctx, `UPDATE lots SET quantity = $1, updated_at = NOW() WHERE id = $2`, newQuantity, lotID)
+	if err != nil {
+		return fmt.Errorf("erro ao atualizar saldo do lote: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return domain.ErrLotNotFound
+	}
+	return nil
+}
+
+// ListExpiring retorna os lotes com saldo cuja validade é anterior ou igual a cutoff,
+// incluindo os já vencidos, do mais próximo ao mais distante do vencimento.
+func (r *LotRepository) ListExpiring(ctx context.Context, cutoff time.Time) ([]domain.ExpiringLot, error) {
+	query := `
+		SELECT ` + lotColumns + `, p.name, COALESCE(p.sku, ''), (l.expiry_date - CURRENT_DATE)
+		FROM lots l
+		JOIN products p ON p.id = l.product_id
+		WHERE l.quantity > 0 AND l.expiry_date <= $1
+		ORDER BY l.expiry_date ASC, p.name ASC
+	`
+	rows, err := r.db.Query(ctx, query, cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar lotes a vencer: %w", err)
+	}
+	defer rows.Close()
+
+	lots := make([]domain.ExpiringLot, 0)
+	for rows.Next() {
+		var e domain.ExpiringLot
+		l := &e.Lot
+		err := rows.Scan(
+			&l.ID, &l.ProductID, &l.BatchNumber, &l.ExpiryDate, &l.Quantity, &l.ReceivedQuantity, &l.CreatedAt, &l.UpdatedAt,
+			&e.ProductName, &e.ProductSKU, &e.DaysUntilExpiry,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao escanear lote: %w", err)
+		}
+		lots = append(lots, e)
+	}
+	return lots, rows.Err()
+}
+
+// querier é satisfeito tanto pelo pool quanto por uma transação.
+type querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+func (r *LotRepository) queryLots(ctx context.Context, q querier, query string, args ...any) ([]domain.Lot, error) {
+	rows, err := q.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar lotes: %w", err)
+	}
+	defer rows.Close()
+
+	lots := make([]domain.Lot, 0)
+	for rows.Next() {
+		var l domain.Lot
+		if err := scanLot(rows, &l); err != nil {
+			return nil, fmt.Errorf("erro ao escanear lote: %w", err)
+		}
+		lots = append(lots, l)
+	}
+	return lots, rows.Err()
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"controle-de-estoque/backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PackagingRepository gerencia as embalagens cadastradas para cada produto.
+type PackagingRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewPackagingRepository cria uma nova instância de PackagingRepository.
+func NewPackagingRepository(db *pgxpool.Pool) *PackagingRepository {
+	return &PackagingRepository{db: db}
+}
+
+// ListByProduct retorna as embalagens de um produto, da menor para a maior.
+func (r *PackagingRepository) ListByProduct(ctx context.Context, productID uuid.UUID) ([]domain.Packaging, error) {
+	const query = `
+		SELECT product_id, code, name, factor
+		FROM product_packagings
+		WHERE product_id = $1
+		ORDER BY factor ASC, code ASC
+	`
+	rows, err := r.db.Query(ctx, query, productID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar embalagens: %w", err)
+	}
+	defer rows.Close()
+
+	packagings := make([]domain.Packaging, 0)
+	for rows.Next() {
+		var p domain.Packaging
+		if err := rows.Scan(&p.ProductID, &p.Code, &p.Name, &p.Factor); err != nil {
+			return nil, fmt.Errorf("erro ao escanear embalagem: %w", err)
+		}
+		packagings = append(packagings, p)
+	}
+	return packagings, rows.Err()
+}
+
+// ReplaceForProduct substitui todas as embalagens de um produto.
+func (r *PackagingRepository) ReplaceForProduct(ctx context.Context, productID uuid.UUID, packagings []domain.Packaging) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("erro ao iniciar transação: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if _, err := tx.Exec(ctx, "DELETE FROM product_packagings WHERE product_id = $1", productID); err != nil {
+		return fmt.Errorf("erro ao remover embalagens: %w", err)
+	}
+	for _, p := range packagings {
+		const query = `INSERT INTO product_packagings (product_id, code, name, factor) VALUES ($1, $2, $3, $4)`
+		if _, err := tx.Exec(ctx, query, productID, p.Code, p.Name, p.Factor); err != nil {
+			return fmt.Errorf("erro ao inserir embalagem: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("erro ao confirmar transação: %w", err)
+	}
+	return nil
+}
+
+// GetPackaging busca uma embalagem específica dentro de uma transação.
+func (r *PackagingRepository) GetPackaging(ctx context.Context, tx pgx.Tx, productID uuid.UUID, code string) (*domain.Packaging, error) {
+	const query = `
+		SELECT product_id, code, name, factor
+		FROM product_packagings
+		WHERE product_id = $1 AND code = $2
+	`
+	var p domain.Packaging
+	err := tx.QueryRow(ctx, query, productID, code).Scan(&p.ProductID, &p.Code, &p.Name, &p.Factor)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrPackagingNotFound
+		}
+		return nil, fmt.Errorf("erro ao buscar embalagem: %w", err)
+	}
+	return &p, nil
+}
@@ -0,0 +1,248 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"controle-de-estoque/backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PriceListRepository gerencia as tabelas de preços e a sua atribuição aos clientes.
+type PriceListRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewPriceListRepository cria uma nova instância de PriceListRepository.
+func NewPriceListRepository(db *pgxpool.Pool) *PriceListRepository {
+	return &PriceListRepository{db: db}
+}
+
+const priceListColumns = `l.id, l.name, l.currency, l.valid_from, l.valid_to, l.created_at, l.updated_at`
+
+func scanPriceList(row pgx.Row, l *domain.PriceList) error {
+	return row.Scan(&l.ID, &l.Name, &l.Currency, &l.ValidFrom, &l.ValidTo, &l.CreatedAt, &l.UpdatedAt)
+}
+
+// clientPriceJoin resolve o preço de um produto (alias p) para o cliente da expressão
+// clientExpr no instante do parâmetro atExpr: entre as tabelas atribuídas ao cliente e
+// vigentes naquele instante que listam o produto ou o seu pai, prevalece a de início mais
+// recente (as sem início por último), depois a mais nova, e, dentro dela, o item da
+// própria variante. Expõe lp.price_in_cents, lp.currency e lp.price_list_id, nulos quando
+// nenhuma tabela se aplica.
+func clientPriceJoin(clientExpr, atExpr string) string {
+	return `
+		LEFT JOIN LATERAL (
+			SELECT i.price_in_cents, l.currency, l.id AS price_list_id
+			FROM client_price_lists cpl
+			JOIN price_lists l ON l.id = cpl.price_list_id
+			JOIN price_list_items i ON i.price_list_id = l.id AND i.product_id IN (p.id, p.parent_id)
+			WHERE cpl.client_id = ` + clientExpr + `
+			  AND (l.valid_from IS NULL OR l.valid_from <= ` + atExpr + `)
+			  AND (l.valid_to IS NULL OR l.valid_to > ` + atExpr + `)
+			ORDER BY l.valid_from DESC NULLS LAST, l.created_at DESC, i.product_id = p.id DESC
+			LIMIT 1
+		) lp ON true`
+}
+
+// Create insere uma tabela de preços com os seus itens.
+func (r *PriceListRepository) Create(ctx context.Context, list *domain.PriceList) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("erro ao iniciar transação: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	const query = `
+		INSERT INTO price_lists (name, currency, valid_from, valid_to)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at, updated_at
+	`
+	err = tx.QueryRow(ctx, query, list.Name, list.Currency, list.ValidFrom, list.ValidTo).
+		Scan(&list.ID, &list.CreatedAt, &list.UpdatedAt)
+	if err != nil {
+		return mapPriceListWriteError("erro ao criar tabela de preços", err)
+	}
+	if err := r.insertItems(ctx, tx, list.ID, list.Items); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("erro ao confirmar transação: %w", err)
+	}
+	return nil
+}
+
+// List retorna todas as tabelas de preços, sem os itens, ordenadas pelo nome.
+func (r *PriceListRepository) List(ctx context.Context) ([]domain.PriceList, error) {
+	return r.query(ctx, `SELECT `+priceListColumns+` FROM price_lists l ORDER BY l.name`)
+}
+
+// GetByID busca uma tabela de preços com os seus itens.
+func (r *PriceListRepository) GetByID(ctx context.Context, listID uuid.UUID) (*domain.PriceList, error) {
+	var l domain.PriceList
+	err := scanPriceList(r.db.QueryRow(ctx, `SELECT `+priceListColumns+` FROM price_lists l WHERE l.id = $1`, listID), &l)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrPriceListNotFound
+		}
+		return nil, fmt.Errorf("erro ao buscar tabela de preços: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT i.product_id, i.price_in_cents
+		FROM price_list_items i
+		JOIN products p ON p.id = i.product_id
+		WHERE i.price_list_id = $1
+		ORDER BY p.name, i.product_id
+	`, listID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar itens da tabela de preços: %w", err)
+	}
+	l.Items, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.PriceListItem, error) {
+		var item domain.PriceListItem
+		err := row.Scan(&item.ProductID, &item.PriceInCents)
+		return item, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao escanear item da tabela de preços: %w", err)
+	}
+	return &l, nil
+}
+
+// Update grava os dados da tabela e substitui os seus itens.
+func (r *PriceListRepository) Update(ctx context.Context, list *domain.PriceList) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("erro ao iniciar transação: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	const query = `
+		UPDATE price_lists
+		SET name = $1, currency = $2, valid_from = $3, valid_to = $4, updated_at = NOW()
+		WHERE id = $5
+		RETURNING created_at, updated_at
+	`
+	err = tx.QueryRow(ctx, query, list.Name, list.Currency, list.ValidFrom, list.ValidTo, list.ID).
+		Scan(&list.CreatedAt, &list.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrPriceListNotFound
+		}
+		return mapPriceListWriteError("erro ao atualizar tabela de preços", err)
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM price_list_items WHERE price_list_id = $1`, list.ID); err != nil {
+		return fmt.Errorf("erro ao remover itens da tabela de preços: %w", err)
+	}
+	if err := r.insertItems(ctx, tx, list.ID, list.Items); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("erro ao confirmar transação: %w", err)
+	}
+	return nil
+}
+
+// Delete remove uma tabela de preços, que deixa de valer para os clientes.
+func (r *PriceListRepository) Delete(ctx context.Context, listID uuid.UUID) error {
+	cmdTag, err := r.db.Exec(ctx, `DELETE FROM price_lists WHERE id = $1`, listID)
+	if err != nil {
+		return fmt.Errorf("erro ao remover tabela de preços: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return domain.ErrPriceListNotFound
+	}
+	return nil
+}
+
+// ListForClient retorna as tabelas atribuídas ao cliente, sem os itens, ordenadas pelo nome.
+func (r *PriceListRepository) ListForClient(ctx context.Context, clientID uuid.UUID) ([]domain.PriceList, error) {
+	return r.query(ctx, `
+		SELECT `+priceListColumns+`
+		FROM client_price_lists cpl
+		JOIN price_lists l ON l.id = cpl.price_list_id
+		WHERE cpl.client_id = $1
+		ORDER BY l.name
+	`, clientID)
+}
+
+// ReplaceForClient substitui as tabelas atribuídas ao cliente.
+func (r *PriceListRepository) ReplaceForClient(ctx context.Context, clientID uuid.UUID, listIDs []uuid.UUID) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("erro ao iniciar transação: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var id uuid.UUID
+	if err := tx.QueryRow(ctx, `SELECT id FROM clients WHERE id = $1 FOR UPDATE`, clientID).Scan(&id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrClientNotFound
+		}
+		return fmt.Errorf("erro ao buscar cliente: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM client_price_lists WHERE client_id = $1`, clientID); err != nil {
+		return fmt.Errorf("erro ao remover tabelas de preços do cliente: %w", err)
+	}
+	for _, listID := range listIDs {
+		_, err := tx.Exec(ctx, `INSERT INTO client_price_lists (client_id, price_list_id) VALUES ($1, $2)`, clientID, listID)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+				return fmt.Errorf("%w: %s", domain.ErrPriceListNotFound, listID)
+			}
+			return fmt.Errorf("erro ao atribuir tabela de preços ao cliente: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("erro ao confirmar transação: %w", err)
+	}
+	return nil
+}
+
+func (r *PriceListRepository) query(ctx context.Context, query string, args ...any) ([]domain.PriceList, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar tabelas de preços: %w", err)
+	}
+	lists, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.PriceList, error) {
+		var l domain.PriceList
+		err := scanPriceList(row, &l)
+		return l, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao escanear tabela de preços: %w", err)
+	}
+	return lists, nil
+}
+
+func (r *PriceListRepository) insertItems(ctx context.Context, tx pgx.Tx, listID uuid.UUID, items []domain.PriceListItem) error {
+	for _, item := range items {
+		const query = `INSERT INTO price_list_items (price_list_id, product_id, price_in_cents) VALUES ($1, $2, $3)`
+		if _, err := tx.Exec(ctx, query, listID, item.ProductID, item.PriceInCents); err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+				return fmt.Errorf("%w: %s", domain.ErrProductNotFound, item.ProductID)
+			}
+			return fmt.Errorf("erro ao inserir item da tabela de preços: %w", err)
+		}
+	}
+	return nil
+}
+
+// mapPriceListWriteError converte a violação do nome único em erro de domínio.
+func mapPriceListWriteError(msg string, err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return domain.ErrPriceListExists
+	}
+	return fmt.Errorf("%s: %w", msg, err)
+}
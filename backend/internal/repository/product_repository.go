@@ -2,19 +2,99 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 
 	"controle-de-estoque/backend/internal/domain"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 // ErrProductNotFound é retornado quando um produto não é encontrado no banco.
-var ErrProductNotFound = errors.New("produto não encontrado")
+var ErrProductNotFound = domain.ErrProductNotFound
+
+// productColumns lista as colunas lidas por scanProduct, na mesma ordem.
+// Os códigos de barras são agregados em um array para evitar uma consulta extra por produto.
+// Variantes herdam a moeda do pai e o preço, quando não há price_override_in_cents, e produtos pai
+// exibem como quantidade a soma do estoque de suas variantes.
+const productColumns = `
+	p.id, COALESCE(p.sku, ''), p.category_id, p.name, p.description,
+	` + productPriceExpr + `, ` + productCurrencyExpr + `,
+	` + productQuantityExpr + `,
+	p.unit,
+	COALESCE((SELECT array_agg(b.barcode ORDER BY b.barcode) FROM product_barcodes b WHERE b.product_id = p.id), '{}'),
+	p.attributes, p.tags, p.track_lots, p.serialized, p.parent_id, p.variant_axes, p.variant_options, p.price_override_in_cents,
+	p.costing_method, p.stock_value_in_cents, p.average_cost_in_cents,
+	p.created_at, p.updated_at
+`
+
+// productPriceExpr, productCurrencyExpr e productQuantityExpr calculam o preço efetivo, a
+// sua moeda e a quantidade exibida, usados tanto na seleção quanto nos filtros e na ordenação.
+const (
+	productPriceExpr = `CASE WHEN p.parent_id IS NULL THEN p.price_in_cents
+	     ELSE COALESCE(p.price_override_in_cents, (SELECT pp.price_in_cents FROM products pp WHERE pp.id = p.parent_id))
+	END`
+	productCurrencyExpr = `CASE WHEN p.parent_id IS NULL THEN p.currency
+	     ELSE (SELECT pp.currency FROM products pp WHERE pp.id = p.parent_id)
+	END`
+	productQuantityExpr = `CASE WHEN jsonb_array_length(p.variant_axes) > 0
+	     THEN (SELECT COALESCE(SUM(v.quantity), 0) FROM products v WHERE v.parent_id = p.id)::int
+	     ELSE p.quantity
+	END`
+)
+
+// productSortKeys é a lista branca de campos ordenáveis: apenas expressões daqui
+// entram no ORDER BY, nunca o texto recebido na requisição.
+var productSortKeys = map[string]sortKey{
+	"name":       {expr: "p.name", sqlType: "text"},
+	"price":      {expr: productPriceExpr, sqlType: "bigint"},
+	"quantity":   {expr: productQuantityExpr, sqlType: "integer"},
+	"updated_at": {expr: "p.updated_at", sqlType: "timestamptz"},
+	"created_at": {expr: "p.created_at", sqlType: "timestamptz"},
+}
+
+// buildProductSortKeys monta a ordenação a partir da lista branca, terminando sempre
+// pelo ID para que a ordem seja estável entre páginas.
+func buildProductSortKeys(filter domain.ProductFilter) ([]sortKey, error) {
+	var keys []sortKey
+	for _, field := range filter.Sort {
+		key, ok := productSortKeys[field.Field]
+		if !ok {
+			return nil, fmt.Errorf("%w: campo de ordenação %q", domain.ErrInvalidFilter, field.Field)
+		}
+		key.desc = field.Desc
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		if filter.Search != "" {
+			keys = append(keys, sortKey{expr: searchRankExpr, sqlType: "real", desc: true})
+		}
+		keys = append(keys, sortKey{expr: "p.created_at", sqlType: "timestamptz", desc: true})
+	}
+	return append(keys, sortKey{expr: "p.id", sqlType: "uuid"}), nil
+}
+
+// scanProduct lê uma linha selecionada com productColumns.
+func scanProduct(row pgx.Row, p *domain.Produto) error {
+	return row.Scan(productScanTargets(p)...)
+}
+
+// productScanTargets retorna os destinos de productColumns, na mesma ordem.
+func productScanTargets(p *domain.Produto) []any {
+	return []any{
+		&p.ID, &p.SKU, &p.CategoryID, &p.Name, &p.Description, &p.PriceInCents, &p.Currency, &p.Quantity, &p.Unit,
+		&p.Barcodes, &p.Attributes, &p.Tags, &p.TrackLots, &p.Serialized, &p.ParentID, &p.VariantAxes, &p.VariantOptions, &p.PriceOverrideInCents,
+		&p.CostingMethod, &p.StockValueInCents, &p.AverageCostInCents,
+		&p.CreatedAt, &p.UpdatedAt,
+	}
+}
 
 // ProductRepository gerencia operações no banco relacionadas a produtos.
 type ProductRepository struct {
@@ -29,13 +109,17 @@ func NewProductRepository(db *pgxpool.Pool) *ProductRepository {
 // GetProductForUpdate busca um produto por ID e bloqueia a linha para update dentro da transação.
 func (r *ProductRepository) GetProductForUpdate(ctx context.Context, tx pgx.Tx, productID uuid.UUID) (*domain.Produto, error) {
 	const query = `
-		SELECT id, name, description, price_in_cents, quantity
-		FROM products
-		WHERE id = $1
-		FOR UPDATE
+		SELECT p.id, p.name, p.description, ` + productPriceExpr + `, ` + productCurrencyExpr + `, p.quantity, p.unit, p.track_lots, p.serialized, p.parent_id, p.variant_axes,
+		       p.costing_method, p.stock_value_in_cents, p.average_cost_in_cents
+		FROM products p
+		WHERE p.id = $1
+		FOR UPDATE OF p
 	`
 	var p domain.Produto
-	err := tx.QueryRow(ctx, query, productID).Scan(&p.ID, &p.Name, &p.Description, &p.PriceInCents, &p.Quantity)
+	err := tx.QueryRow(ctx, query, productID).Scan(
+		&p.ID, &p.Name, &p.Description, &p.PriceInCents, &p.Currency, &p.Quantity, &p.Unit, &p.TrackLots, &p.Serialized, &p.ParentID, &p.VariantAxes,
+		&p.CostingMethod, &p.StockValueInCents, &p.AverageCostInCents,
+	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrProductNotFound
@@ -64,92 +148,256 @@ func (r *ProductRepository) UpdateQuantity(ctx context.Context, tx pgx.Tx, produ
 	return nil
 }
 
-// CreateProduct insere um novo produto no banco.
-func (r *ProductRepository) CreateProduct(ctx context.Context, product *domain.Produto) error {
+// CreateProduct insere um novo produto e seus códigos de barras na transação informada.
+func (r *ProductRepository) CreateProduct(ctx context.Context, tx pgx.Tx, product *domain.Produto) error {
 	const query = `
-        INSERT INTO products (name, description, price_in_cents, quantity)
-        VALUES ($1, $2, $3, $4)
+        INSERT INTO products (sku, category_id, name, description, price_in_cents, quantity, unit, attributes, tags, track_lots, serialized, costing_method, currency)
+        VALUES (NULLIF($1, ''), $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
         RETURNING id, created_at, updated_at
     `
-	err := r.db.QueryRow(ctx, query,
+	err := tx.QueryRow(ctx, query,
+		product.SKU,
+		product.CategoryID,
 		product.Name,
 		product.Description,
 		product.PriceInCents,
 		product.Quantity,
+		product.Unit,
+		product.Attributes,
+		product.Tags,
+		product.TrackLots,
+		product.Serialized,
+		product.CostingMethod,
+		product.Currency,
 	).Scan(&product.ID, &product.CreatedAt, &product.UpdatedAt)
 	if err != nil {
-		return fmt.Errorf("não foi possível criar o produto: %w", err)
+		return mapProductWriteError("não foi possível criar o produto", err)
+	}
+
+	return r.replaceBarcodes(ctx, tx, product.ID, product.Barcodes)
+}
+
+// replaceBarcodes substitui o conjunto de códigos de barras de um produto.
+func (r *ProductRepository) replaceBarcodes(ctx context.Context, tx pgx.Tx, productID uuid.UUID, barcodes []string) error {
+	if _, err := tx.Exec(ctx, "DELETE FROM product_barcodes WHERE product_id = $1", productID); err != nil {
+		return fmt.Errorf("erro ao remover códigos de barras: %w", err)
+	}
+	for _, barcode := range barcodes {
+		_, err := tx.Exec(ctx, "INSERT INTO product_barcodes (barcode, product_id) VALUES ($1, $2)", barcode, productID)
+		if err != nil {
+			return mapProductWriteError("erro ao inserir código de barras", err)
+		}
 	}
 	return nil
 }
 
-// ListProducts busca produtos com paginação e busca por nome (case-insensitive).
-func (r *ProductRepository) ListProducts(ctx context.Context, search string, page, limit int) ([]domain.Produto, int, error) {
-	countQuery := "SELECT COUNT(*) FROM products"
-	var countArgs []any
-	if search != "" {
-		countQuery += " WHERE name ILIKE $1"
-		countArgs = append(countArgs, "%"+search+"%")
+// mapProductWriteError converte violações de unicidade em erros de domínio.
+func mapProductWriteError(msg string, err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch {
+		case pgErr.Code == "23505" && pgErr.ConstraintName == "products_sku_key":
+			return domain.ErrSKUAlreadyExists
+		case pgErr.Code == "23505" && pgErr.ConstraintName == "product_barcodes_pkey":
+			return domain.ErrBarcodeInUse
+		case pgErr.Code == "23505" && pgErr.ConstraintName == "products_parent_variant_options_key":
+			return fmt.Errorf("%w: combinação de variante duplicada", domain.ErrInvalidVariants)
+		case pgErr.Code == "23503" && pgErr.ConstraintName == "products_category_id_fkey":
+			return domain.ErrCategoryNotFound
+		}
 	}
+	return fmt.Errorf("%s: %w", msg, err)
+}
+
+// searchConfig é a configuração de busca textual criada na migração 000008:
+// português com stemming e sem acentos.
+const searchConfig = `'portuguese_unaccent'::regconfig`
 
-	var totalRecords int
-	if err := r.db.QueryRow(ctx, countQuery, countArgs...).Scan(&totalRecords); err != nil {
-		return nil, 0, fmt.Errorf("erro ao contar produtos: %w", err)
+// searchRankExpr soma a relevância full-text à similaridade por trigramas do nome, para
+// que resultados vindos apenas da tolerância a erros de digitação também sejam ordenados.
+const searchRankExpr = `ts_rank_cd(p.search_vector, websearch_to_tsquery(` + searchConfig + `, $1))
+		+ word_similarity(immutable_unaccent(lower($1)), immutable_unaccent(lower(p.name)))`
+
+// searchColumns complementa productColumns nas listagens com busca: o rank e os trechos
+// destacados. O termo é sempre $1. Os textos são escapados antes de ts_headline, então os
+// trechos são HTML cuja única marcação é o <mark> em volta dos termos encontrados.
+const searchColumns = `,
+	` + searchRankExpr + `,
+	ts_headline(` + searchConfig + `, html_escape(p.name), websearch_to_tsquery(` + searchConfig + `, $1),
+		'HighlightAll=true, StartSel=<mark>, StopSel=</mark>'),
+	ts_headline(` + searchConfig + `, html_escape(COALESCE(p.description, '')), websearch_to_tsquery(` + searchConfig + `, $1),
+		'MaxFragments=2, MaxWords=20, MinWords=5, StartSel=<mark>, StopSel=</mark>')
+`
+
+// ListProducts busca uma página de produtos, aplicando os filtros informados. Com busca
+// textual e sem ordenação explícita, os resultados vêm ordenados por relevância.
+// O total só é calculado quando solicitado; o cursor retornado fica vazio na última página.
+func (r *ProductRepository) ListProducts(ctx context.Context, filter domain.ProductFilter, page domain.PageRequest) ([]domain.Produto, *int, string, error) {
+	where, args := buildProductWhere(filter)
+	keys, err := buildProductSortKeys(filter)
+	if err != nil {
+		return nil, nil, "", err
 	}
 
-	if totalRecords == 0 {
-		return []domain.Produto{}, 0, nil
+	var total *int
+	if page.IncludeTotal {
+		var totalRecords int
+		if err := r.db.QueryRow(ctx, "SELECT COUNT(*) FROM products p"+where, args...).Scan(&totalRecords); err != nil {
+			return nil, nil, "", fmt.Errorf("erro ao contar produtos: %w", err)
+		}
+		total = &totalRecords
 	}
 
-	var queryBuilder strings.Builder
-	queryBuilder.WriteString(`
-		SELECT id, name, description, price_in_cents, quantity, created_at, updated_at
-		FROM products
-	`)
+	columns := productColumns
+	if filter.Search != "" {
+		columns += searchColumns
+	}
 
-	args := []any{}
-	argID := 1
-	if search != "" {
-		queryBuilder.WriteString(fmt.Sprintf(" WHERE name ILIKE $%d", argID))
-		args = append(args, "%"+search+"%")
-		argID++
+	tail, args, err := paginate(where, args, keys, page)
+	if err != nil {
+		return nil, nil, "", err
 	}
 
-	queryBuilder.WriteString(fmt.Sprintf(" ORDER BY created_at DESC LIMIT $%d OFFSET $%d", argID, argID+1))
-	offset := (page - 1) * limit
-	args = append(args, limit, offset)
+	var queryBuilder strings.Builder
+	queryBuilder.WriteString(`SELECT ` + columns + cursorColumn(keys) + ` FROM products p`)
+	queryBuilder.WriteString(tail)
 
 	rows, err := r.db.Query(ctx, queryBuilder.String(), args...)
 	if err != nil {
-		return nil, 0, fmt.Errorf("erro ao listar produtos: %w", err)
+		return nil, nil, "", fmt.Errorf("erro ao listar produtos: %w", err)
 	}
 	defer rows.Close()
 
-	products := make([]domain.Produto, 0, limit)
+	products := make([]domain.Produto, 0, page.Limit+1)
+	var keyValues [][]string
 	for rows.Next() {
 		var p domain.Produto
-		if err := rows.Scan(&p.ID, &p.Name, &p.Description, &p.PriceInCents, &p.Quantity, &p.CreatedAt, &p.UpdatedAt); err != nil {
-			return nil, 0, fmt.Errorf("erro ao escanear produto: %w", err)
+		var values []string
+		targets := productScanTargets(&p)
+		if filter.Search != "" {
+			p.Search = &domain.SearchMatch{}
+			targets = append(targets, &p.Search.Rank, &p.Search.Name, &p.Search.Description)
+		}
+		if err := rows.Scan(append(targets, &values)...); err != nil {
+			return nil, nil, "", fmt.Errorf("erro ao escanear produto: %w", err)
 		}
 		products = append(products, p)
+		keyValues = append(keyValues, values)
 	}
 
 	if err = rows.Err(); err != nil {
-		return nil, 0, fmt.Errorf("erro ao iterar pelos produtos: %w", err)
+		return nil, nil, "", fmt.Errorf("erro ao iterar pelos produtos: %w", err)
 	}
 
-	return products, totalRecords, nil
+	products, next := trimPage(products, keyValues, keys, page.Limit)
+	return products, total, next, nil
+}
+
+// ExportProducts percorre todos os produtos que atendem ao filtro, na ordem da listagem,
+// chamando fn para cada um sem carregá-los todos em memória. Um erro de fn interrompe a
+// leitura e é devolvido como está.
+func (r *ProductRepository) ExportProducts(ctx context.Context, filter domain.ProductFilter, fn func(domain.Produto) error) error {
+	where, args := buildProductWhere(filter)
+	keys, err := buildProductSortKeys(filter)
+	if err != nil {
+		return err
+	}
+	rows, err := r.db.Query(ctx, `SELECT `+productColumns+` FROM products p`+where+orderByClause(keys), args...)
+	if err != nil {
+		return fmt.Errorf("erro ao exportar produtos: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var p domain.Produto
+		if err := rows.Scan(productScanTargets(&p)...); err != nil {
+			return fmt.Errorf("erro ao escanear produto: %w", err)
+		}
+		if err := fn(p); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("erro ao exportar produtos: %w", err)
+	}
+	return nil
+}
+
+// buildProductWhere monta a cláusula WHERE da listagem de produtos e seus argumentos.
+// Todos os valores vindos do cliente são passados como parâmetros posicionais.
+func buildProductWhere(filter domain.ProductFilter) (string, []any) {
+	var conditions []string
+	var args []any
+
+	// O termo de busca precisa ser o primeiro argumento ($1), pois searchColumns o referencia.
+	// Um produto é encontrado pelo full-text, por trigramas do nome (erros de digitação)
+	// ou por trecho literal do SKU ($2, com os curingas escapados).
+	if filter.Search != "" {
+		args = append(args, filter.Search, "%"+likeEscape(strings.ToLower(filter.Search))+"%")
+		conditions = append(conditions, `(
+			p.search_vector @@ websearch_to_tsquery(`+searchConfig+`, $1)
+			OR immutable_unaccent(lower($1)) <% immutable_unaccent(lower(p.name))
+			OR lower(p.sku) LIKE $2 ESCAPE '\')`)
+	}
+	if !filter.IncludeVariants {
+		conditions = append(conditions, "p.parent_id IS NULL")
+	}
+	if filter.CategoryID != nil {
+		// Inclui a própria categoria e todas as descendentes via prefixo do caminho materializado.
+		args = append(args, *filter.CategoryID)
+		conditions = append(conditions, fmt.Sprintf(`p.category_id IN (
+			SELECT d.id FROM categories c JOIN categories d ON d.path LIKE c.path || '%%'
+			WHERE c.id = $%d)`, len(args)))
+	}
+
+	// Atributos usam o operador de contenção (@>) para aproveitar o índice GIN,
+	// com um documento candidato por tipo possível do valor.
+	for _, key := range sortedKeys(filter.Attributes) {
+		value := filter.Attributes[key]
+		alternatives := []string{}
+		for _, doc := range attributeFilterDocs(key, value) {
+			args = append(args, doc)
+			alternatives = append(alternatives, fmt.Sprintf("p.attributes @> $%d::jsonb", len(args)))
+		}
+		conditions = append(conditions, "("+strings.Join(alternatives, " OR ")+")")
+	}
+	if len(filter.Tags) > 0 {
+		args = append(args, filter.Tags)
+		conditions = append(conditions, fmt.Sprintf("p.tags @> $%d::text[]", len(args)))
+	}
+
+	// Faixas: os valores vão sempre como argumentos; apenas os operadores são fixos.
+	ranges := []struct {
+		expr  string
+		op    string
+		value any
+		isSet bool
+	}{
+		{productPriceExpr, ">=", filter.MinPriceInCents, filter.MinPriceInCents != nil},
+		{productPriceExpr, "<=", filter.MaxPriceInCents, filter.MaxPriceInCents != nil},
+		{productQuantityExpr, ">=", filter.MinQuantity, filter.MinQuantity != nil},
+		{productQuantityExpr, "<=", filter.MaxQuantity, filter.MaxQuantity != nil},
+		{"p.updated_at", ">=", filter.UpdatedSince, filter.UpdatedSince != nil},
+	}
+	for _, r := range ranges {
+		if !r.isSet {
+			continue
+		}
+		args = append(args, r.value)
+		conditions = append(conditions, fmt.Sprintf("(%s) %s $%d", r.expr, r.op, len(args)))
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
 }
 
 // GetProductByID busca um produto pelo ID.
 func (r *ProductRepository) GetProductByID(ctx context.Context, productID uuid.UUID) (domain.Produto, error) {
-	const query = `
-        SELECT id, name, description, price_in_cents, quantity, created_at, updated_at
-        FROM products
-        WHERE id = $1
-    `
+	query := `SELECT ` + productColumns + ` FROM products p WHERE p.id = $1`
 	var p domain.Produto
-	err := r.db.QueryRow(ctx, query, productID).Scan(&p.ID, &p.Name, &p.Description, &p.PriceInCents, &p.Quantity, &p.CreatedAt, &p.UpdatedAt)
+	err := scanProduct(r.db.QueryRow(ctx, query, productID), &p)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return domain.Produto{}, ErrProductNotFound
@@ -159,26 +407,158 @@ func (r *ProductRepository) GetProductByID(ctx context.Context, productID uuid.U
 	return p, nil
 }
 
-// UpdateProduct atualiza os dados de um produto.
-func (r *ProductRepository) UpdateProduct(ctx context.Context, product *domain.Produto) error {
+// GetProductsByIDs busca os produtos com os IDs informados, em qualquer ordem. IDs
+// inexistentes são ignorados.
+func (r *ProductRepository) GetProductsByIDs(ctx context.Context, productIDs []uuid.UUID) ([]domain.Produto, error) {
+	query := `SELECT ` + productColumns + ` FROM products p WHERE p.id = ANY($1)`
+	rows, err := r.db.Query(ctx, query, productIDs)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar produtos por ID: %w", err)
+	}
+	products, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.Produto, error) {
+		var p domain.Produto
+		err := scanProduct(row, &p)
+		return p, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao escanear produto: %w", err)
+	}
+	return products, nil
+}
+
+// GetProductByBarcode busca o produto que possui o código de barras informado.
+func (r *ProductRepository) GetProductByBarcode(ctx context.Context, barcode string) (domain.Produto, error) {
+	query := `
+		SELECT ` + productColumns + `
+		FROM products p
+		JOIN product_barcodes pb ON pb.product_id = p.id
+		WHERE pb.barcode = $1
+	`
+	var p domain.Produto
+	err := scanProduct(r.db.QueryRow(ctx, query, barcode), &p)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Produto{}, ErrProductNotFound
+		}
+		return domain.Produto{}, fmt.Errorf("erro ao buscar produto por código de barras: %w", err)
+	}
+	return p, nil
+}
+
+// GetProductBySKU busca o produto com o SKU informado, já normalizado.
+func (r *ProductRepository) GetProductBySKU(ctx context.Context, sku string) (domain.Produto, error) {
+	query := `SELECT ` + productColumns + ` FROM products p WHERE p.sku = $1`
+	var p domain.Produto
+	err := scanProduct(r.db.QueryRow(ctx, query, sku), &p)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Produto{}, ErrProductNotFound
+		}
+		return domain.Produto{}, fmt.Errorf("erro ao buscar produto por SKU: %w", err)
+	}
+	return p, nil
+}
+
+// UpdateProduct atualiza os dados de um produto e substitui seus códigos de barras.
+// O custo do estoque não é alterado aqui; veja UpdateCost.
+func (r *ProductRepository) UpdateProduct(ctx context.Context, tx pgx.Tx, product *domain.Produto) error {
 	const query = `
         UPDATE products
-        SET name = $1, description = $2, price_in_cents = $3, quantity = $4, updated_at = NOW()
-        WHERE id = $5
+        SET sku = NULLIF($1, ''), category_id = $2, name = $3, description = $4,
+            price_in_cents = CASE WHEN parent_id IS NULL THEN $5 ELSE price_in_cents END,
+            quantity = CASE WHEN jsonb_array_length(variant_axes) > 0 THEN quantity ELSE $6 END,
+            unit = $7, attributes = $8, tags = $9, price_override_in_cents = $10, track_lots = $11, serialized = $12,
+            costing_method = $13, currency = CASE WHEN parent_id IS NULL THEN $14 ELSE currency END, updated_at = NOW()
+        WHERE id = $15
         RETURNING updated_at
     `
-	err := r.db.QueryRow(ctx, query,
+	err := tx.QueryRow(ctx, query,
+		product.SKU,
+		product.CategoryID,
 		product.Name,
 		product.Description,
 		product.PriceInCents,
 		product.Quantity,
+		product.Unit,
+		product.Attributes,
+		product.Tags,
+		product.PriceOverrideInCents,
+		product.TrackLots,
+		product.Serialized,
+		product.CostingMethod,
+		product.Currency,
 		product.ID,
 	).Scan(&product.UpdatedAt)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return ErrProductNotFound
 		}
-		return fmt.Errorf("erro ao atualizar produto: %w", err)
+		return mapProductWriteError("erro ao atualizar produto", err)
+	}
+
+	return r.replaceBarcodes(ctx, tx, product.ID, product.Barcodes)
+}
+
+// UpdateCost grava o custo do estoque global do produto e o custo médio unitário.
+func (r *ProductRepository) UpdateCost(ctx context.Context, tx pgx.Tx, productID uuid.UUID, stockValue, averageCost int64) error {
+	const query = `UPDATE products SET stock_value_in_cents = $1, average_cost_in_cents = $2 WHERE id = $3`
+	cmdTag, err := tx.Exec(ctx, query, stockValue, averageCost, productID)
+	if err != nil {
+		return fmt.Errorf("erro ao atualizar custo do produto: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return ErrProductNotFound
+	}
+	return nil
+}
+
+// ListVariants retorna as variantes de um produto pai, ordenadas pelo SKU e nome.
+func (r *ProductRepository) ListVariants(ctx context.Context, parentID uuid.UUID) ([]domain.Produto, error) {
+	query := `SELECT ` + productColumns + ` FROM products p WHERE p.parent_id = $1 ORDER BY p.sku ASC NULLS LAST, p.name ASC`
+	rows, err := r.db.Query(ctx, query, parentID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar variantes: %w", err)
+	}
+	defer rows.Close()
+
+	variants := make([]domain.Produto, 0)
+	for rows.Next() {
+		var v domain.Produto
+		if err := scanProduct(rows, &v); err != nil {
+			return nil, fmt.Errorf("erro ao escanear variante: %w", err)
+		}
+		variants = append(variants, v)
+	}
+	return variants, rows.Err()
+}
+
+// CreateVariants grava os eixos de variação do produto pai e insere as novas variantes
+// na transação, que deve manter o pai bloqueado.
+func (r *ProductRepository) CreateVariants(ctx context.Context, tx pgx.Tx, parentID uuid.UUID, axes []domain.VariantAxis, variants []domain.Produto) error {
+	const axesQuery = `UPDATE products SET variant_axes = $1, updated_at = NOW() WHERE id = $2`
+	cmdTag, err := tx.Exec(ctx, axesQuery, axes, parentID)
+	if err != nil {
+		return fmt.Errorf("erro ao atualizar eixos de variação: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return ErrProductNotFound
+	}
+
+	const insertQuery = `
+		INSERT INTO products (sku, category_id, name, description, price_in_cents, quantity, unit,
+			attributes, tags, track_lots, serialized, parent_id, variant_options, price_override_in_cents, costing_method, currency)
+		VALUES (NULLIF($1, ''), $2, $3, $4, 0, 0, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		RETURNING id, created_at, updated_at
+	`
+	for i := range variants {
+		v := &variants[i]
+		err := tx.QueryRow(ctx, insertQuery,
+			v.SKU, v.CategoryID, v.Name, v.Description, v.Unit,
+			v.Attributes, v.Tags, v.TrackLots, v.Serialized, parentID, v.VariantOptions, v.PriceOverrideInCents, v.CostingMethod, v.Currency,
+		).Scan(&v.ID, &v.CreatedAt, &v.UpdatedAt)
+		if err != nil {
+			return mapProductWriteError("erro ao criar variante", err)
+		}
 	}
 	return nil
 }
@@ -195,3 +575,41 @@ func (r *ProductRepository) DeleteProduct(ctx context.Context, productID uuid.UU
 	}
 	return nil
 }
+
+// likeEscape escapa os curingas de LIKE (% e _) e a própria barra, para que o termo
+// seja comparado literalmente com ESCAPE '\'.
+func likeEscape(term string) string {
+	return likeEscaper.Replace(term)
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// attributeFilterDocs gera os documentos JSON usados no filtro attr.<chave>=<valor>.
+// Como a query string não carrega tipo, o valor é comparado como texto e, quando
+// possível, também como número ou booleano.
+func attributeFilterDocs(key, value string) []string {
+	docs := []string{mustJSON(map[string]any{key: value})}
+	if n, err := strconv.ParseFloat(value, 64); err == nil {
+		docs = append(docs, mustJSON(map[string]any{key: n}))
+	}
+	if value == "true" || value == "false" {
+		docs = append(docs, mustJSON(map[string]any{key: value == "true"}))
+	}
+	return docs
+}
+
+// mustJSON serializa valores simples (mapas de strings, números e booleanos), que nunca falham.
+func mustJSON(v any) string {
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+// sortedKeys devolve as chaves do mapa em ordem, para gerar SQL determinístico.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
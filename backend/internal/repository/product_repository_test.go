@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"controle-de-estoque/backend/internal/domain"
+)
+
+// likeMatch interpreta um padrão LIKE com ESCAPE '\' da mesma forma que o PostgreSQL.
+func likeMatch(pattern, s string) bool {
+	var re strings.Builder
+	re.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; {
+		case c == '\\' && i+1 < len(pattern):
+			i++
+			re.WriteString(regexp.QuoteMeta(string(pattern[i])))
+		case c == '%':
+			re.WriteString("(?s:.*)")
+		case c == '_':
+			re.WriteString("(?s:.)")
+		default:
+			re.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	re.WriteString("$")
+	return regexp.MustCompile(re.String()).MatchString(s)
+}
+
+func TestLikeEscape(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"cam-01", "cam-01"},
+		{"50%", `50\%`},
+		{"cam_01", `cam\_01`},
+		{`a\b`, `a\\b`},
+	}
+	for _, tt := range tests {
+		if got := likeEscape(tt.in); got != tt.want {
+			t.Errorf("likeEscape(%q) = %q, esperado %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestBuildProductWhereSearchMatchesSKULiterally(t *testing.T) {
+	tests := []struct {
+		search  string
+		sku     string
+		matches bool
+	}{
+		{"CAM_01", "cam_01-azul", true},
+		{"CAM_01", "camx01-azul", false},
+		{"50%", "desc-50%-off", true},
+		{"50%", "desc-500-off", false},
+		{`a\b`, `sku-a\b`, true},
+	}
+	for _, tt := range tests {
+		where, args := buildProductWhere(domain.ProductFilter{Search: tt.search})
+		if !strings.Contains(where, `lower(p.sku) LIKE $2 ESCAPE '\'`) {
+			t.Fatalf("busca %q: cláusula sem LIKE com escape: %s", tt.search, where)
+		}
+		if len(args) < 2 || args[0] != tt.search {
+			t.Fatalf("busca %q: args = %v", tt.search, args)
+		}
+		pattern, _ := args[1].(string)
+		if got := likeMatch(pattern, tt.sku); got != tt.matches {
+			t.Errorf("busca %q contra SKU %q: padrão %q casou = %t, esperado %t", tt.search, tt.sku, pattern, got, tt.matches)
+		}
+	}
+}
+
+// TestSearchColumnsEscapeHighlightedText garante que todo texto passado a ts_headline
+// é escapado antes, para que os trechos destacados só tenham as marcações <mark>.
+func TestSearchColumnsEscapeHighlightedText(t *testing.T) {
+	calls := strings.Split(searchColumns, "ts_headline(")[1:]
+	if len(calls) != 2 {
+		t.Fatalf("esperado ts_headline no nome e na descrição, obtido %d chamadas", len(calls))
+	}
+	for _, call := range calls {
+		args := strings.SplitN(call, ", ", 3)
+		if len(args) < 3 || !strings.HasPrefix(args[1], "html_escape(") {
+			t.Errorf("ts_headline com texto sem escape: %s", call)
+		}
+	}
+}
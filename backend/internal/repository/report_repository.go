@@ -0,0 +1,195 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"controle-de-estoque/backend/internal/domain"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ReportRepository reúne as consultas agregadas dos relatórios.
+type ReportRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewReportRepository cria uma nova instância de ReportRepository.
+func NewReportRepository(db *pgxpool.Pool) *ReportRepository {
+	return &ReportRepository{db: db}
+}
+
+// ValuationLines soma a quantidade e o valor do estoque global e de cada cliente, por
+// categoria e moeda, no instante asOf. Produtos pai com variantes não têm estoque próprio,
+// e produtos criados depois de asOf são ignorados. Vêm primeiro as linhas do estoque
+// global e depois as dos clientes, por nome.
+//
+// Pelo preço, as quantidades são as atuais menos as movimentações posteriores a asOf (no
+// cliente, as transferências e devoluções), vezes o preço de cadastro atual ou, com os
+// clientes, o da tabela de preços vigente em asOf; os preços de cadastro não têm
+// histórico, por isso o serviço só aceita o instante atual.
+//
+// Pelo custo, o estoque global vale o custo atual do produto menos o das movimentações
+// posteriores, na moeda do produto. Nos clientes, a quantidade e o valor são os das
+// transferências com custo registrado até asOf, menos as devoluções, na moeda do custo
+// gravada nelas; saldos anteriores ao custeio não entram.
+func (r *ReportRepository) ValuationLines(ctx context.Context, asOf time.Time, basis domain.ValuationBasis) ([]domain.ValuationLine, error) {
+	query := `
+		WITH warehouse AS (
+			SELECT p.id AS product_id,
+			       p.quantity - COALESCE((
+			           SELECT SUM(m.quantity) FROM stock_movements m
+			           WHERE m.product_id = p.id AND m.created_at > $1), 0) AS quantity
+			FROM products p
+			WHERE jsonb_array_length(p.variant_axes) = 0 AND p.created_at <= $1
+		),
+		holdings AS (
+			SELECT cs.client_id, cs.product_id,
+			       cs.quantity + COALESCE((
+			           SELECT SUM(m.quantity) FROM stock_movements m
+			           WHERE m.client_id = cs.client_id AND m.product_id = cs.product_id
+			             AND m.type IN ('transfer', 'return') AND m.created_at > $1), 0) AS quantity
+			FROM client_stocks cs
+		),
+		lines AS (
+			SELECT NULL::uuid AS client_id, product_id, quantity FROM warehouse
+			UNION ALL
+			SELECT client_id, product_id, quantity FROM holdings
+		)
+		SELECT l.client_id, COALESCE(c.name, ''), p.category_id, COALESCE(cat.name, ''),
+		       COALESCE(lp.currency, ` + productCurrencyExpr + `),
+		       SUM(l.quantity)::bigint,
+		       SUM(l.quantity::bigint * COALESCE(lp.price_in_cents, ` + productPriceExpr + `))::bigint
+		FROM lines l
+		JOIN products p ON p.id = l.product_id
+		LEFT JOIN categories cat ON cat.id = p.category_id
+		LEFT JOIN clients c ON c.id = l.client_id` + clientPriceJoin("l.client_id", "$1") + `
+		WHERE l.quantity <> 0
+		GROUP BY l.client_id, c.name, p.category_id, cat.name, 5
+		ORDER BY l.client_id IS NOT NULL, c.name, l.client_id, cat.name NULLS LAST, p.category_id, 5
+	`
+	if basis == domain.ValuationByCost {
+		query = `
+		WITH warehouse AS (
+			SELECT p.id AS product_id,
+			       p.quantity - COALESCE(later.quantity, 0) AS quantity,
+			       p.stock_value_in_cents - COALESCE(later.cost, 0) AS value,
+			       ` + productCurrencyExpr + ` AS currency
+			FROM products p
+			LEFT JOIN LATERAL (
+			    SELECT SUM(m.quantity) AS quantity, SUM(m.cost_in_cents) AS cost
+			    FROM stock_movements m
+			    WHERE m.product_id = p.id AND m.created_at > $1
+			) later ON true
+			WHERE jsonb_array_length(p.variant_axes) = 0 AND p.created_at <= $1
+		),
+		holdings AS (
+			SELECT m.client_id, m.product_id, SUM(-m.quantity) AS quantity, SUM(-m.cost_in_cents) AS value,
+			       m.cost_currency AS currency
+			FROM stock_movements m
+			WHERE m.type IN ('transfer', 'return') AND m.cost_in_cents IS NOT NULL AND m.created_at <= $1
+			GROUP BY m.client_id, m.product_id, m.cost_currency
+		),
+		lines AS (
+			SELECT NULL::uuid AS client_id, product_id, quantity, value, currency FROM warehouse
+			UNION ALL
+			SELECT client_id, product_id, quantity, value, currency FROM holdings
+		)
+		SELECT l.client_id, COALESCE(c.name, ''), p.category_id, COALESCE(cat.name, ''), l.currency,
+		       SUM(l.quantity)::bigint,
+		       SUM(l.value)::bigint
+		FROM lines l
+		JOIN products p ON p.id = l.product_id
+		LEFT JOIN categories cat ON cat.id = p.category_id
+		LEFT JOIN clients c ON c.id = l.client_id
+		WHERE l.quantity <> 0 OR l.value <> 0
+		GROUP BY l.client_id, c.name, p.category_id, cat.name, 5
+		ORDER BY l.client_id IS NOT NULL, c.name, l.client_id, cat.name NULLS LAST, p.category_id, 5
+	`
+	}
+	rows, err := r.db.Query(ctx, query, asOf)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao calcular valorização do estoque: %w", err)
+	}
+	defer rows.Close()
+
+	var lines []domain.ValuationLine
+	for rows.Next() {
+		var l domain.ValuationLine
+		if err := rows.Scan(&l.ClientID, &l.ClientName, &l.CategoryID, &l.CategoryName, &l.Currency, &l.Quantity, &l.ValueInCents); err != nil {
+			return nil, fmt.Errorf("erro ao escanear valorização do estoque: %w", err)
+		}
+		lines = append(lines, l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("erro ao calcular valorização do estoque: %w", err)
+	}
+	return lines, nil
+}
+
+// MarginLines soma, por produto ou por cliente, as transferências e devoluções com custo
+// registradas em [filter.From, filter.To): as quantidades vendidas e devolvidas, a receita
+// (quantidade vezes o preço de venda gravado) e o CMV (o custo das saídas, menos o das
+// devoluções). Agrupa também pelas moedas gravadas nas movimentações, a do preço e a do
+// custo, que diferem quando a tabela de preços do cliente usa outra moeda que não a do
+// produto. As linhas vêm ordenadas pelo nome do produto ou do cliente e pelas moedas.
+func (r *ReportRepository) MarginLines(ctx context.Context, filter domain.MarginFilter) ([]domain.MarginLine, error) {
+	conditions := []string{
+		"m.type IN ('transfer', 'return')",
+		"m.cost_in_cents IS NOT NULL",
+		"m.created_at >= $1",
+		"m.created_at < $2",
+	}
+	args := []any{filter.From, filter.To}
+	if filter.ClientID != nil {
+		args = append(args, *filter.ClientID)
+		conditions = append(conditions, fmt.Sprintf("m.client_id = $%d", len(args)))
+	}
+	if filter.ProductID != nil {
+		args = append(args, *filter.ProductID)
+		conditions = append(conditions, fmt.Sprintf("m.product_id = $%d", len(args)))
+	}
+
+	group := "m.product_id, p.sku, p.name, m.currency, m.cost_currency"
+	keys := "m.product_id, COALESCE(p.sku, ''), p.name, NULL::uuid, '', m.currency, m.cost_currency"
+	order := "p.name, m.product_id, m.currency, m.cost_currency"
+	if filter.GroupBy == domain.MarginByClient {
+		group = "m.client_id, c.name, m.currency, m.cost_currency"
+		keys = "NULL::uuid, '', '', m.client_id, c.name, m.currency, m.cost_currency"
+		order = "c.name, m.client_id, m.currency, m.cost_currency"
+	}
+
+	query := `
+		SELECT ` + keys + `,
+		       COALESCE(SUM(-m.quantity) FILTER (WHERE m.type = 'transfer'), 0)::bigint,
+		       COALESCE(SUM(m.quantity) FILTER (WHERE m.type = 'return'), 0)::bigint,
+		       COALESCE(SUM(-m.quantity::bigint * COALESCE(m.unit_price_in_cents, 0)), 0)::bigint,
+		       COALESCE(SUM(-m.cost_in_cents), 0)::bigint
+		FROM stock_movements m
+		JOIN products p ON p.id = m.product_id
+		JOIN clients c ON c.id = m.client_id
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		GROUP BY ` + group + `
+		ORDER BY ` + order
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao calcular margem: %w", err)
+	}
+	defer rows.Close()
+
+	var lines []domain.MarginLine
+	for rows.Next() {
+		var l domain.MarginLine
+		if err := rows.Scan(&l.ProductID, &l.SKU, &l.ProductName, &l.ClientID, &l.ClientName, &l.Currency, &l.CostCurrency,
+			&l.QuantitySold, &l.QuantityReturned, &l.RevenueInCents, &l.COGSInCents); err != nil {
+			return nil, fmt.Errorf("erro ao escanear margem: %w", err)
+		}
+		lines = append(lines, l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("erro ao calcular margem: %w", err)
+	}
+	return lines, nil
+}
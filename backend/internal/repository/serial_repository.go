@@ -0,0 +1,205 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"controle-de-estoque/backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// SerialRepository gerencia os números de série dos produtos serializados e o seu histórico.
+type SerialRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewSerialRepository cria uma nova instância de SerialRepository.
+func NewSerialRepository(db *pgxpool.Pool) *SerialRepository {
+	return &SerialRepository{db: db}
+}
+
+const serialColumns = `s.id, s.product_id, s.serial_number, s.status, s.client_id, s.created_at, s.updated_at`
+
+func scanSerial(row pgx.Row, s *domain.SerialNumber) error {
+	return row.Scan(&s.ID, &s.ProductID, &s.Serial, &s.Status, &s.ClientID, &s.CreatedAt, &s.UpdatedAt)
+}
+
+// serialSortKeys ordena os números de série alfabeticamente; o número é único.
+var serialSortKeys = []sortKey{{expr: "s.serial_number", sqlType: "text"}}
+
+// ListByProduct retorna uma página dos números de série de um produto em ordem alfabética.
+func (r *SerialRepository) ListByProduct(ctx context.Context, productID uuid.UUID, page domain.PageRequest) ([]domain.SerialNumber, *int, string, error) {
+	var total *int
+	if page.IncludeTotal {
+		var totalRecords int
+		if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM serial_numbers WHERE product_id = $1`, productID).Scan(&totalRecords); err != nil {
+			return nil, nil, "", fmt.Errorf("erro ao contar números de série: %w", err)
+		}
+		total = &totalRecords
+	}
+
+	tail, args, err := paginate(" WHERE s.product_id = $1", []any{productID}, serialSortKeys, page)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	rows, err := r.db.Query(ctx, `SELECT `+serialColumns+cursorColumn(serialSortKeys)+` FROM serial_numbers s`+tail, args...)
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("erro ao listar números de série: %w", err)
+	}
+	defer rows.Close()
+
+	serials := make([]domain.SerialNumber, 0, page.Limit+1)
+	var keyValues [][]string
+	for rows.Next() {
+		var s domain.SerialNumber
+		var values []string
+		if err := rows.Scan(&s.ID, &s.ProductID, &s.Serial, &s.Status, &s.ClientID, &s.CreatedAt, &s.UpdatedAt, &values); err != nil {
+			return nil, nil, "", fmt.Errorf("erro ao escanear número de série: %w", err)
+		}
+		serials = append(serials, s)
+		keyValues = append(keyValues, values)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, "", fmt.Errorf("erro ao iterar pelos números de série: %w", err)
+	}
+
+	serials, next := trimPage(serials, keyValues, serialSortKeys, page.Limit)
+	return serials, total, next, nil
+}
+
+// Register cadastra novos números de série no estoque global e registra o evento de entrada.
+func (r *SerialRepository) Register(ctx context.Context, tx pgx.Tx, productID uuid.UUID, serials []string, movementID uuid.UUID) ([]domain.SerialNumber, error) {
+	const query = `
+		INSERT INTO serial_numbers AS s (product_id, serial_number, status)
+		VALUES ($1, $2, $3)
+		RETURNING ` + serialColumns
+	registered := make([]domain.SerialNumber, 0, len(serials))
+	for _, serial := range serials {
+		var s domain.SerialNumber
+		if err := scanSerial(tx.QueryRow(ctx, query, productID, serial, domain.SerialInStock), &s); err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+				return nil, fmt.Errorf("%w: %s", domain.ErrSerialAlreadyExists, serial)
+			}
+			return nil, fmt.Errorf("erro ao registrar número de série: %w", err)
+		}
+		registered = append(registered, s)
+	}
+
+	ids := make([]uuid.UUID, len(registered))
+	for i, s := range registered {
+		ids[i] = s.ID
+	}
+	if err := r.insertEvents(ctx, tx, ids, domain.SerialInStock, nil, movementID); err != nil {
+		return nil, err
+	}
+	return registered, nil
+}
+
+// ListForUpdate busca e bloqueia, dentro da transação, os números de série informados do produto.
+// Números inexistentes ou de outro produto simplesmente não aparecem no resultado.
+func (r *SerialRepository) ListForUpdate(ctx context.Context, tx pgx.Tx, productID uuid.UUID, serials []string) ([]domain.SerialNumber, error) {
+	query := `
+		SELECT ` + serialColumns + `
+		FROM serial_numbers s
+		WHERE s.product_id = $1 AND s.serial_number = ANY($2)
+		ORDER BY s.serial_number ASC
+		FOR UPDATE
+	`
+	return r.querySerials(ctx, tx, query, productID, serials)
+}
+
+// UpdateStatus altera a situação dos números de série e registra o evento no histórico.
+func (r *SerialRepository) UpdateStatus(ctx context.Context, tx pgx.Tx, ids []uuid.UUID, status domain.SerialStatus, clientID *uuid.UUID, movementID uuid.UUID) error {
+	const query = `
+		UPDATE serial_numbers
+		SET status = $2, client_id = $3, updated_at = NOW()
+		WHERE id = ANY($1)
+	`
+	if _, err := tx.Exec(ctx, query, ids, status, clientID); err != nil {
+		return fmt.Errorf("erro ao atualizar números de série: %w", err)
+	}
+	return r.insertEvents(ctx, tx, ids, status, clientID, movementID)
+}
+
+func (r *SerialRepository) insertEvents(ctx context.Context, tx pgx.Tx, ids []uuid.UUID, status domain.SerialStatus, clientID *uuid.UUID, movementID uuid.UUID) error {
+	const query = `
+		INSERT INTO serial_number_events (serial_id, status, client_id, movement_id)
+		SELECT id, $2, $3, $4 FROM unnest($1::uuid[]) AS id
+	`
+	if _, err := tx.Exec(ctx, query, ids, status, clientID, movementID); err != nil {
+		return fmt.Errorf("erro ao registrar histórico dos números de série: %w", err)
+	}
+	return nil
+}
+
+// GetHistory busca um número de série e todo o seu histórico de localização, do mais antigo ao mais recente.
+func (r *SerialRepository) GetHistory(ctx context.Context, serial string) (*domain.SerialHistory, error) {
+	query := `
+		SELECT ` + serialColumns + `, p.name
+		FROM serial_numbers s
+		JOIN products p ON p.id = s.product_id
+		WHERE s.serial_number = $1
+	`
+	var h domain.SerialHistory
+	s := &h.SerialNumber
+	err := r.db.QueryRow(ctx, query, serial).Scan(
+		&s.ID, &s.ProductID, &s.Serial, &s.Status, &s.ClientID, &s.CreatedAt, &s.UpdatedAt, &h.ProductName,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrSerialNotFound
+		}
+		return nil, fmt.Errorf("erro ao buscar número de série: %w", err)
+	}
+
+	const eventsQuery = `
+		SELECT e.status, e.client_id, c.name, e.movement_id, m.type, e.created_at
+		FROM serial_number_events e
+		LEFT JOIN clients c ON c.id = e.client_id
+		LEFT JOIN stock_movements m ON m.id = e.movement_id
+		WHERE e.serial_id = $1
+		ORDER BY e.created_at ASC, e.id ASC
+	`
+	rows, err := r.db.Query(ctx, eventsQuery, s.ID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar histórico do número de série: %w", err)
+	}
+	defer rows.Close()
+
+	h.History = make([]domain.SerialEvent, 0)
+	for rows.Next() {
+		var e domain.SerialEvent
+		if err := rows.Scan(&e.Status, &e.ClientID, &e.ClientName, &e.MovementID, &e.MovementType, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("erro ao escanear histórico do número de série: %w", err)
+		}
+		h.History = append(h.History, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("erro ao iterar pelo histórico do número de série: %w", err)
+	}
+	return &h, nil
+}
+
+func (r *SerialRepository) querySerials(ctx context.Context, q querier, query string, args ...any) ([]domain.SerialNumber, error) {
+	rows, err := q.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar números de série: %w", err)
+	}
+	defer rows.Close()
+
+	serials := make([]domain.SerialNumber, 0)
+	for rows.Next() {
+		var s domain.SerialNumber
+		if err := scanSerial(rows, &s); err != nil {
+			return nil, fmt.Errorf("erro ao escanear número de série: %w", err)
+		}
+		serials = append(serials, s)
+	}
+	return serials, rows.Err()
+}
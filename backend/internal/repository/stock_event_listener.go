@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"controle-de-estoque/backend/internal/domain"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// stockMovementsChannel é o canal em que o gatilho de stock_movements publica cada
+// movimentação (migração 000012).
+const stockMovementsChannel = "stock_movements"
+
+// StockEventListener escuta as movimentações de estoque confirmadas via LISTEN/NOTIFY.
+type StockEventListener struct {
+	db *pgxpool.Pool
+}
+
+// NewStockEventListener cria uma nova instância de StockEventListener.
+func NewStockEventListener(db *pgxpool.Pool) *StockEventListener {
+	return &StockEventListener{db: db}
+}
+
+// Listen ocupa uma conexão exclusiva, retirada do pool, e chama handle para cada
+// movimentação confirmada até ctx ser cancelado ou a conexão falhar.
+func (l *StockEventListener) Listen(ctx context.Context, handle func(domain.StockEvent)) error {
+	pooled, err := l.db.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("erro ao obter conexão para escutar movimentações: %w", err)
+	}
+	// A conexão fica com o LISTEN ativo, então não pode voltar ao pool.
+	conn := pooled.Hijack()
+	defer func() { _ = conn.Close(context.Background()) }()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+stockMovementsChannel); err != nil {
+		return fmt.Errorf("erro ao escutar movimentações: %w", err)
+	}
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return fmt.Errorf("erro ao aguardar movimentações: %w", err)
+		}
+		var event domain.StockEvent
+		if err := json.Unmarshal([]byte(notification.Payload), &event); err != nil {
+			return fmt.Errorf("notificação de movimentação inválida: %w", err)
+		}
+		handle(event)
+	}
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"controle-de-estoque/backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// StockMovementRepository gerencia o histórico de movimentações do estoque global.
+type StockMovementRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewStockMovementRepository cria uma nova instância de StockMovementRepository.
+func NewStockMovementRepository(db *pgxpool.Pool) *StockMovementRepository {
+	return &StockMovementRepository{db: db}
+}
+
+// Create registra uma movimentação dentro da transação que alterou o estoque.
+func (r *StockMovementRepository) Create(ctx context.Context, tx pgx.Tx, m *domain.StockMovement) error {
+	const query = `
+		INSERT INTO stock_movements
+			(product_id, client_id, type, packaging_code, packaging_quantity, packaging_factor, quantity, reason,
+			 cost_in_cents, unit_price_in_cents, currency, cost_currency)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NULLIF($11, ''), NULLIF($12, ''))
+		RETURNING id, created_at
+	`
+	err := tx.QueryRow(ctx, query,
+		m.ProductID,
+		m.ClientID,
+		m.Type,
+		m.Packaging,
+		m.PackagingQuantity,
+		m.PackagingFactor,
+		m.Quantity,
+		m.Reason,
+		m.CostInCents,
+		m.UnitPriceInCents,
+		m.Currency,
+		m.CostCurrency,
+	).Scan(&m.ID, &m.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("erro ao registrar movimentação de estoque: %w", err)
+	}
+	return nil
+}
+
+// ClientCostBasis soma as transferências ao cliente menos as devoluções do produto com
+// custo na moeda informada: a quantidade, o custo e a receita, na moeda do preço gravada
+// nelas. Serve de base para o custo e o preço das devoluções, que desfazem as transferências
+// pelo seu valor médio. Se o cliente ainda tem saldo em mais de uma moeda de preço, vale a
+// da transferência mais recente; sem saldo, a moeda volta vazia.
+func (r *StockMovementRepository) ClientCostBasis(ctx context.Context, tx pgx.Tx, clientID, productID uuid.UUID, costCurrency domain.Currency) (currency domain.Currency, quantity, cost, revenue int64, err error) {
+	const query = `
+		SELECT COALESCE(currency, cost_currency),
+		       SUM(-quantity)::bigint,
+		       SUM(-cost_in_cents)::bigint,
+		       COALESCE(SUM(-quantity::bigint * COALESCE(unit_price_in_cents, 0)), 0)::bigint
+		FROM stock_movements
+		WHERE client_id = $1 AND product_id = $2 AND type IN ('transfer', 'return') AND cost_in_cents IS NOT NULL
+		  AND cost_currency = $3
+		GROUP BY 1
+		HAVING SUM(-quantity) > 0
+		ORDER BY MAX(created_at) FILTER (WHERE type = 'transfer') DESC NULLS LAST
+		LIMIT 1
+	`
+	err = tx.QueryRow(ctx, query, clientID, productID, costCurrency).Scan(&currency, &quantity, &cost, &revenue)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", 0, 0, 0, nil
+	}
+	if err != nil {
+		return "", 0, 0, 0, fmt.Errorf("erro ao calcular custo do estoque do cliente: %w", err)
+	}
+	return currency, quantity, cost, revenue, nil
+}
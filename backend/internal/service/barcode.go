@@ -0,0 +1,73 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"controle-de-estoque/backend/internal/domain"
+)
+
+// ValidateBarcode verifica se o código é um EAN-8, UPC-A (12 dígitos) ou EAN-13
+// com dígito verificador GS1 correto.
+func ValidateBarcode(code string) error {
+	switch len(code) {
+	case 8, 12, 13:
+	default:
+		return fmt.Errorf("%w: %q deve ter 8, 12 ou 13 dígitos", domain.ErrInvalidBarcode, code)
+	}
+
+	for _, c := range code {
+		if c < '0' || c > '9' {
+			return fmt.Errorf("%w: %q deve conter apenas dígitos", domain.ErrInvalidBarcode, code)
+		}
+	}
+
+	if gs1CheckDigit(code[:len(code)-1]) != code[len(code)-1] {
+		return fmt.Errorf("%w: dígito verificador incorreto em %q", domain.ErrInvalidBarcode, code)
+	}
+	return nil
+}
+
+// gs1CheckDigit calcula o dígito verificador GS1 (módulo 10) para o payload informado.
+// Da direita para a esquerda, os dígitos recebem pesos alternados 3 e 1.
+func gs1CheckDigit(payload string) byte {
+	sum := 0
+	for i := 0; i < len(payload); i++ {
+		digit := int(payload[len(payload)-1-i] - '0')
+		if i%2 == 0 {
+			digit *= 3
+		}
+		sum += digit
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+// canonicalBarcode converte um UPC-A na sua forma EAN-13 (com um zero à esquerda), que é
+// a gravada e a buscada, para que o código seja o mesmo qualquer que seja a leitura.
+func canonicalBarcode(code string) string {
+	if len(code) == 12 {
+		return "0" + code
+	}
+	return code
+}
+
+// normalizeBarcodes remove espaços e duplicatas, valida cada código de barras e converte
+// os UPC-A em EAN-13, registrando em v os códigos inválidos.
+func normalizeBarcodes(v *validator, codes []string) []string {
+	normalized := make([]string, 0, len(codes))
+	seen := make(map[string]struct{}, len(codes))
+	for i, code := range codes {
+		code = strings.TrimSpace(code)
+		if err := ValidateBarcode(code); err != nil {
+			v.addErr(fmt.Sprintf("barcodes[%d]", i), domain.CodeInvalid, err)
+			continue
+		}
+		code = canonicalBarcode(code)
+		if _, dup := seen[code]; dup {
+			continue
+		}
+		seen[code] = struct{}{}
+		normalized = append(normalized, code)
+	}
+	return normalized
+}
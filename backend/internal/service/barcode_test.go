@@ -0,0 +1,87 @@
+package service
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"controle-de-estoque/backend/internal/domain"
+)
+
+func TestValidateBarcode(t *testing.T) {
+	tests := []struct {
+		name    string
+		code    string
+		wantErr bool
+	}{
+		{"EAN-13", "7891000315507", false},
+		{"EAN-13 com dígito errado", "7891000315508", true},
+		{"EAN-8", "96385074", false},
+		{"EAN-8 com dígito errado", "96385075", true},
+		{"UPC-A", "036000291452", false},
+		{"UPC-A com dígito errado", "036000291453", true},
+		{"UPC-A na forma EAN-13", "0036000291452", false},
+		{"tamanho inválido", "123456789", true},
+		{"letras", "789100031550X", true},
+		{"vazio", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateBarcode(tt.code)
+			if tt.wantErr && !errors.Is(err, domain.ErrInvalidBarcode) {
+				t.Fatalf("ValidateBarcode(%q) erro = %v, esperado ErrInvalidBarcode", tt.code, err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("ValidateBarcode(%q) erro inesperado: %v", tt.code, err)
+			}
+		})
+	}
+}
+
+func TestGS1CheckDigit(t *testing.T) {
+	tests := []struct {
+		payload string
+		want    byte
+	}{
+		{"789100031550", '7'},
+		{"9638507", '4'},
+		{"03600029145", '2'},
+		{"000000000000", '0'}, // soma múltipla de 10
+	}
+	for _, tt := range tests {
+		if got := gs1CheckDigit(tt.payload); got != tt.want {
+			t.Errorf("gs1CheckDigit(%q) = %c, esperado %c", tt.payload, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeBarcodes(t *testing.T) {
+	tests := []struct {
+		name       string
+		codes      []string
+		want       []string
+		wantFields []string
+	}{
+		{"remove espaços", []string{" 7891000315507 "}, []string{"7891000315507"}, nil},
+		{"remove duplicatas", []string{"96385074", "96385074"}, []string{"96385074"}, nil},
+		{"UPC-A vira EAN-13", []string{"036000291452"}, []string{"0036000291452"}, nil},
+		{"UPC-A e EAN-13 equivalentes", []string{"0036000291452", "036000291452"}, []string{"0036000291452"}, nil},
+		{"inválidos por índice", []string{"96385074", "123", "96385075"}, []string{"96385074"}, []string{"barcodes[1]", "barcodes[2]"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var v validator
+			got := normalizeBarcodes(&v, tt.codes)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("normalizeBarcodes(%q) = %q, esperado %q", tt.codes, got, tt.want)
+			}
+			var fields []string
+			for _, f := range v.fields {
+				fields = append(fields, f.Field)
+			}
+			if !slices.Equal(fields, tt.wantFields) {
+				t.Errorf("campos com erro = %q, esperado %q", fields, tt.wantFields)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"controle-de-estoque/backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// ICategoryRepository define a interface para o repositório de categorias.
+type ICategoryRepository interface {
+	CreateCategory(ctx context.Context, category *domain.Category) error
+	ListCategories(ctx context.Context) ([]domain.Category, error)
+	GetCategoryByID(ctx context.Context, categoryID uuid.UUID) (*domain.Category, error)
+	GetCategoriesByIDs(ctx context.Context, categoryIDs []uuid.UUID) ([]domain.Category, error)
+	UpdateCategory(ctx context.Context, category *domain.Category) error
+	DeleteCategory(ctx context.Context, categoryID uuid.UUID) error
+	ListAttributes(ctx context.Context, categoryID uuid.UUID) ([]domain.AttributeDefinition, error)
+	ReplaceAttributes(ctx context.Context, categoryID uuid.UUID, definitions []domain.AttributeDefinition) error
+}
+
+// CategoryService contém a lógica de negócio da árvore de categorias.
+type CategoryService struct {
+	repo ICategoryRepository
+}
+
+// NewCategoryService cria uma nova instância de CategoryService.
+func NewCategoryService(repo ICategoryRepository) *CategoryService {
+	return &CategoryService{repo: repo}
+}
+
+// Create cria uma nova categoria, na raiz ou abaixo de ParentID.
+func (s *CategoryService) Create(ctx context.Context, category *domain.Category) error {
+	if err := validateCategory(category); err != nil {
+		return err
+	}
+	return s.repo.CreateCategory(ctx, category)
+}
+
+// List retorna todas as categorias, com cada pai antes de seus filhos.
+func (s *CategoryService) List(ctx context.Context) ([]domain.Category, error) {
+	return s.repo.ListCategories(ctx)
+}
+
+// GetByID retorna uma categoria pelo ID.
+func (s *CategoryService) GetByID(ctx context.Context, categoryID uuid.UUID) (*domain.Category, error) {
+	return s.repo.GetCategoryByID(ctx, categoryID)
+}
+
+// GetByIDs busca várias categorias de uma vez, em qualquer ordem. IDs inexistentes são ignorados.
+func (s *CategoryService) GetByIDs(ctx context.Context, categoryIDs []uuid.UUID) ([]domain.Category, error) {
+	return s.repo.GetCategoriesByIDs(ctx, categoryIDs)
+}
+
+// Update renomeia e/ou move uma categoria para outro pai.
+func (s *CategoryService) Update(ctx context.Context, category *domain.Category) error {
+	if category.ParentID != nil && *category.ParentID == category.ID {
+		return fmt.Errorf("%w: uma categoria não pode ser pai de si mesma", domain.ErrInvalidCategoryData)
+	}
+	if err := validateCategory(category); err != nil {
+		return err
+	}
+	return s.repo.UpdateCategory(ctx, category)
+}
+
+// Delete remove uma categoria que não possua subcategorias.
+func (s *CategoryService) Delete(ctx context.Context, categoryID uuid.UUID) error {
+	return s.repo.DeleteCategory(ctx, categoryID)
+}
+
+// ListAttributes retorna as definições de atributos válidas para a categoria,
+// incluindo as herdadas das categorias ancestrais.
+func (s *CategoryService) ListAttributes(ctx context.Context, categoryID uuid.UUID) ([]domain.AttributeDefinition, error) {
+	if _, err := s.repo.GetCategoryByID(ctx, categoryID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListAttributes(ctx, categoryID)
+}
+
+// ReplaceAttributes substitui as definições declaradas diretamente na categoria
+// e retorna o conjunto efetivo resultante.
+func (s *CategoryService) ReplaceAttributes(ctx context.Context, categoryID uuid.UUID, definitions []domain.AttributeDefinition) ([]domain.AttributeDefinition, error) {
+	var v validator
+	seen := make(map[string]struct{}, len(definitions))
+	for i := range definitions {
+		d := &definitions[i]
+		d.CategoryID = categoryID
+		d.Key = strings.TrimSpace(d.Key)
+		d.Label = strings.TrimSpace(d.Label)
+
+		key := indexed("", i, "key")
+		if !ValidAttributeKey(d.Key) {
+			v.add(key, domain.CodeInvalid, fmt.Sprintf("chave %q deve conter apenas letras minúsculas, dígitos e _", d.Key))
+		} else if _, dup := seen[d.Key]; dup {
+			v.add(key, domain.CodeDuplicate, fmt.Sprintf("chave %q duplicada", d.Key))
+		}
+		seen[d.Key] = struct{}{}
+
+		v.check(d.Type.Valid(), indexed("", i, "type"), domain.CodeInvalid, fmt.Sprintf("tipo %q não suportado", d.Type))
+		v.check(d.Type != domain.AttributeEnum || len(d.Options) > 0, indexed("", i, "options"), domain.CodeRequired,
+			fmt.Sprintf("%q é do tipo enum e precisa de opções", d.Key))
+		if d.Type != domain.AttributeEnum {
+			d.Options = []string{}
+		}
+	}
+
+	if err := v.err(domain.ErrInvalidAttribute); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.ReplaceAttributes(ctx, categoryID, definitions); err != nil {
+		return nil, err
+	}
+	return s.repo.ListAttributes(ctx, categoryID)
+}
+
+// maxCategoryNameLength limita o tamanho do nome de uma categoria.
+const maxCategoryNameLength = 100
+
+// validateCategory normaliza e valida os dados de uma categoria.
+func validateCategory(c *domain.Category) error {
+	c.Name = strings.TrimSpace(c.Name)
+	var v validator
+	v.check(c.Name != "", "name", domain.CodeRequired, "o nome é obrigatório")
+	v.maxLength("name", c.Name, maxCategoryNameLength)
+	return v.err(domain.ErrInvalidCategoryData)
+}
@@ -2,50 +2,127 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
 
 	"controle-de-estoque/backend/internal/domain"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 // IClientRepository define a interface para o repositório de clientes.
 type IClientRepository interface {
-	CreateClient(ctx context.Context, client *domain.Client) error
-	ListClients(ctx context.Context) ([]domain.Client, error)
+	ListClients(ctx context.Context, filter domain.ClientFilter, page domain.PageRequest) ([]domain.Client, *int, string, error)
+	ExportClients(ctx context.Context, filter domain.ClientFilter, fn func(domain.Client) error) error
 	GetClientByID(ctx context.Context, clientID uuid.UUID) (*domain.Client, error)
+	GetClientsByIDs(ctx context.Context, clientIDs []uuid.UUID) ([]domain.Client, error)
 	UpdateClient(ctx context.Context, client *domain.Client) error
 	DeleteClient(ctx context.Context, clientID uuid.UUID) error
+
+	// Métodos para transação
+	CreateClient(ctx context.Context, tx pgx.Tx, client *domain.Client) error
+	GetClientForShare(ctx context.Context, tx pgx.Tx, clientID uuid.UUID) (*domain.Client, error)
 }
 
 // IClientStockRepository define a interface para o repositório de estoque do cliente.
 type IClientStockRepository interface {
-	ListStockByClientID(ctx context.Context, clientID uuid.UUID) ([]domain.ClientStockDetails, error)
+	ListStockByClientID(ctx context.Context, clientID uuid.UUID, page domain.PageRequest) ([]domain.ClientStockDetails, *int, string, error)
+	ExportHoldings(ctx context.Context, filter domain.HoldingFilter, fn func(domain.ClientHolding) error) error
+	ListByClientIDs(ctx context.Context, clientIDs []uuid.UUID) ([]domain.ClientStock, error)
+	ListByProductIDs(ctx context.Context, productIDs []uuid.UUID) ([]domain.ClientStock, error)
 	Upsert(ctx context.Context, tx pgx.Tx, stock *domain.ClientStock) error
+	UpsertLot(ctx context.Context, tx pgx.Tx, clientID, productID uuid.UUID, allocation domain.LotAllocation) error
+	Decrement(ctx context.Context, tx pgx.Tx, clientID, productID uuid.UUID, quantity int) error
+	DecrementLot(ctx context.Context, tx pgx.Tx, clientID, productID uuid.UUID, allocation domain.LotAllocation) error
+	EffectivePrice(ctx context.Context, tx pgx.Tx, clientID, productID uuid.UUID) (int64, domain.Currency, error)
 }
 
 // ClientService contém a lógica de negócio para clientes e estoques dos clientes.
 type ClientService struct {
-	repo      IClientRepository
-	stockRepo IClientStockRepository
+	db          *pgxpool.Pool // Pool para iniciar transações
+	repo        IClientRepository
+	stockRepo   IClientStockRepository
+	idempotency *IdempotencyService
 }
 
 // NewClientService cria uma nova instância de ClientService.
-func NewClientService(repo IClientRepository, stockRepo IClientStockRepository) *ClientService {
+func NewClientService(db *pgxpool.Pool, repo IClientRepository, stockRepo IClientStockRepository, idempotency *IdempotencyService) *ClientService {
 	return &ClientService{
-		repo:      repo,
-		stockRepo: stockRepo,
+		db:          db,
+		repo:        repo,
+		stockRepo:   stockRepo,
+		idempotency: idempotency,
 	}
 }
 
-// Create cria um novo cliente.
+// Create cria um novo cliente. Sem situação informada, o cliente nasce ativo. Com uma
+// chave de idempotência no contexto, a resposta é gravada na mesma transação.
 func (s *ClientService) Create(ctx context.Context, client *domain.Client) error {
-	return s.repo.CreateClient(ctx, client)
+	if client.Status == "" {
+		client.Status = domain.ClientActive
+	}
+	if err := validateClient(client); err != nil {
+		return err
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("erro ao iniciar transação: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err := s.idempotency.guard(ctx, tx); err != nil {
+		return err
+	}
+	if err := s.repo.CreateClient(ctx, tx, client); err != nil {
+		return err
+	}
+	if err := s.idempotency.record(ctx, tx, client); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("erro ao confirmar transação: %w", err)
+	}
+	return nil
+}
+
+// List retorna uma página de clientes, com os filtros aplicados nos metadados.
+func (s *ClientService) List(ctx context.Context, filter domain.ClientFilter, page domain.PageRequest) (*domain.PaginatedResponse, error) {
+	filter, err := normalizeClientFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	clients, total, next, err := s.repo.ListClients(ctx, filter, page)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := newPageMetadata(page, total, next)
+	metadata.AppliedFilters = filter.Applied()
+	return &domain.PaginatedResponse{Data: clients, Metadata: metadata}, nil
+}
+
+// Export percorre todos os clientes que atendem ao filtro, na ordem da listagem,
+// chamando fn para cada um.
+func (s *ClientService) Export(ctx context.Context, filter domain.ClientFilter, fn func(domain.Client) error) error {
+	filter, err := normalizeClientFilter(filter)
+	if err != nil {
+		return err
+	}
+	return s.repo.ExportClients(ctx, filter, fn)
 }
 
-// List retorna todos os clientes.
-func (s *ClientService) List(ctx context.Context) ([]domain.Client, error) {
-	return s.repo.ListClients(ctx)
+// normalizeClientFilter limpa o termo de busca e valida a situação pedida.
+func normalizeClientFilter(filter domain.ClientFilter) (domain.ClientFilter, error) {
+	filter.Search = strings.TrimSpace(filter.Search)
+	if filter.Status != "" && !filter.Status.Valid() {
+		return filter, fmt.Errorf("%w: situação %q inválida", domain.ErrInvalidFilter, filter.Status)
+	}
+	return filter, nil
 }
 
 // GetByID retorna um cliente pelo ID.
@@ -53,17 +130,153 @@ func (s *ClientService) GetByID(ctx context.Context, clientID uuid.UUID) (*domai
 	return s.repo.GetClientByID(ctx, clientID)
 }
 
-// Update atualiza os dados de um cliente.
+// GetByIDs busca vários clientes de uma vez, com endereços e contatos, em qualquer
+// ordem. IDs inexistentes são ignorados.
+func (s *ClientService) GetByIDs(ctx context.Context, clientIDs []uuid.UUID) ([]domain.Client, error) {
+	return s.repo.GetClientsByIDs(ctx, clientIDs)
+}
+
+// Update atualiza os dados de um cliente. Sem situação informada, mantém a atual.
 func (s *ClientService) Update(ctx context.Context, client *domain.Client) error {
+	if client.Status == "" {
+		current, err := s.repo.GetClientByID(ctx, client.ID)
+		if err != nil {
+			return err
+		}
+		client.Status = current.Status
+	}
+	if err := validateClient(client); err != nil {
+		return err
+	}
 	return s.repo.UpdateClient(ctx, client)
 }
 
+// Patch aplica um JSON Merge Patch (RFC 7396) sobre um cliente existente.
+// Apenas os campos presentes no patch são alterados; o resultado mesclado é validado
+// antes de ser persistido.
+func (s *ClientService) Patch(ctx context.Context, clientID uuid.UUID, patch []byte) (*domain.Client, error) {
+	client, err := s.repo.GetClientByID(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	original, err := json.Marshal(client)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao serializar cliente: %w", err)
+	}
+	merged, err := applyMergePatch(original, patch)
+	if err != nil {
+		return nil, err
+	}
+
+	var patched domain.Client
+	if err := decodeMerged(merged, &patched); err != nil {
+		return nil, err
+	}
+
+	// Campos controlados pelo servidor não podem ser alterados pelo patch.
+	patched.ID = client.ID
+	patched.CreatedAt = client.CreatedAt
+	patched.UpdatedAt = client.UpdatedAt
+
+	if err := validateClient(&patched); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateClient(ctx, &patched); err != nil {
+		return nil, err
+	}
+
+	return &patched, nil
+}
+
+// Limites de tamanho dos campos de texto do cliente.
+const (
+	maxClientNameLength = 200
+	maxClientTextLength = 255
+)
+
+// validateClient verifica as regras de integridade de um cliente, acumulando os erros
+// por campo, e normaliza o documento e os CEPs para apenas dígitos.
+func validateClient(c *domain.Client) error {
+	var v validator
+	c.Name = strings.TrimSpace(c.Name)
+	v.check(c.Name != "", "name", domain.CodeRequired, "o nome é obrigatório")
+	v.maxLength("name", c.Name, maxClientNameLength)
+	v.email("email", c.Email)
+	v.maxLength("phone", c.Phone, maxClientTextLength)
+	v.check(c.Status.Valid(), "status", domain.CodeInvalid, fmt.Sprintf("situação %q inválida (use active ou blocked)", c.Status))
+
+	c.Document = strings.TrimSpace(c.Document)
+	if c.Document != "" {
+		if document, err := NormalizeDocument(c.Document); err != nil {
+			v.addErr("document", domain.CodeInvalid, err)
+		} else {
+			c.Document = document
+		}
+	}
+
+	for i := range c.Addresses {
+		validateAddress(&v, i, &c.Addresses[i])
+	}
+	for i := range c.Contacts {
+		contact := &c.Contacts[i]
+		contact.Name = strings.TrimSpace(contact.Name)
+		v.check(contact.Name != "", indexed("contacts", i, "name"), domain.CodeRequired, "o nome do contato é obrigatório")
+		v.maxLength(indexed("contacts", i, "name"), contact.Name, maxClientNameLength)
+		v.email(indexed("contacts", i, "email"), contact.Email)
+	}
+	return v.err(domain.ErrInvalidClientData)
+}
+
+// validateAddress verifica o tipo, os campos obrigatórios, a UF e o CEP do i-ésimo endereço.
+func validateAddress(v *validator, i int, a *domain.Address) {
+	v.check(a.Type == domain.AddressBilling || a.Type == domain.AddressDelivery,
+		indexed("addresses", i, "type"), domain.CodeInvalid, fmt.Sprintf("tipo de endereço %q inválido (use billing ou delivery)", a.Type))
+
+	a.Street = strings.TrimSpace(a.Street)
+	a.City = strings.TrimSpace(a.City)
+	v.check(a.Street != "", indexed("addresses", i, "street"), domain.CodeRequired, "o logradouro é obrigatório")
+	v.check(a.City != "", indexed("addresses", i, "city"), domain.CodeRequired, "a cidade é obrigatória")
+
+	a.State = strings.ToUpper(strings.TrimSpace(a.State))
+	_, ok := brazilianStates[a.State]
+	v.check(ok, indexed("addresses", i, "state"), domain.CodeInvalid, fmt.Sprintf("UF %q inválida", a.State))
+
+	if cep, err := NormalizeCEP(a.CEP); err != nil {
+		v.addErr(indexed("addresses", i, "cep"), domain.CodeInvalid, err)
+	} else {
+		a.CEP = cep
+	}
+}
+
 // Delete remove um cliente pelo ID.
 func (s *ClientService) Delete(ctx context.Context, clientID uuid.UUID) error {
 	return s.repo.DeleteClient(ctx, clientID)
 }
 
-// ListStockByClientID retorna os dados de estoque de um cliente específico.
-func (s *ClientService) ListStockByClientID(ctx context.Context, clientID uuid.UUID) ([]domain.ClientStockDetails, error) {
-	return s.stockRepo.ListStockByClientID(ctx, clientID)
+// ListStockByClientID retorna uma página do estoque de um cliente específico.
+func (s *ClientService) ListStockByClientID(ctx context.Context, clientID uuid.UUID, page domain.PageRequest) (*domain.PaginatedResponse, error) {
+	stocks, total, next, err := s.stockRepo.ListStockByClientID(ctx, clientID, page)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.PaginatedResponse{Data: stocks, Metadata: newPageMetadata(page, total, next)}, nil
+}
+
+// ExportHoldings percorre o estoque de todos os clientes que atende ao filtro, chamando
+// fn para cada saldo.
+func (s *ClientService) ExportHoldings(ctx context.Context, filter domain.HoldingFilter, fn func(domain.ClientHolding) error) error {
+	return s.stockRepo.ExportHoldings(ctx, filter, fn)
+}
+
+// ListStockByClientIDs retorna os saldos positivos de vários clientes de uma vez.
+func (s *ClientService) ListStockByClientIDs(ctx context.Context, clientIDs []uuid.UUID) ([]domain.ClientStock, error) {
+	return s.stockRepo.ListByClientIDs(ctx, clientIDs)
+}
+
+// ListHoldersByProductIDs retorna os saldos positivos que os clientes possuem de vários
+// produtos de uma vez.
+func (s *ClientService) ListHoldersByProductIDs(ctx context.Context, productIDs []uuid.UUID) ([]domain.ClientStock, error) {
+	return s.stockRepo.ListByProductIDs(ctx, productIDs)
 }
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"controle-de-estoque/backend/internal/domain"
+)
+
+// brazilianStates lista as UFs aceitas nos endereços dos clientes.
+var brazilianStates = map[string]struct{}{
+	"AC": {}, "AL": {}, "AP": {}, "AM": {}, "BA": {}, "CE": {}, "DF": {}, "ES": {}, "GO": {},
+	"MA": {}, "MT": {}, "MS": {}, "MG": {}, "PA": {}, "PB": {}, "PR": {}, "PE": {}, "PI": {},
+	"RJ": {}, "RN": {}, "RS": {}, "RO": {}, "RR": {}, "SC": {}, "SP": {}, "SE": {}, "TO": {},
+}
+
+// NormalizeDocument remove a pontuação de um CPF ou CNPJ e valida os dígitos
+// verificadores. Retorna apenas os dígitos.
+func NormalizeDocument(document string) (string, error) {
+	digits := stripFormatting(document, ".-/ ")
+	if !isDigits(digits) {
+		return "", fmt.Errorf("%w: CPF/CNPJ %q deve conter apenas dígitos", domain.ErrInvalidClientData, document)
+	}
+
+	switch len(digits) {
+	case 11:
+		if !validCPF(digits) {
+			return "", fmt.Errorf("%w: CPF %q inválido", domain.ErrInvalidClientData, document)
+		}
+	case 14:
+		if !validCNPJ(digits) {
+			return "", fmt.Errorf("%w: CNPJ %q inválido", domain.ErrInvalidClientData, document)
+		}
+	default:
+		return "", fmt.Errorf("%w: o documento deve ser um CPF (11 dígitos) ou CNPJ (14 dígitos)", domain.ErrInvalidClientData)
+	}
+	return digits, nil
+}
+
+// validCPF confere os dois dígitos verificadores do CPF (módulo 11, pesos decrescentes
+// a partir de 10 e 11). Sequências de um só dígito, como 111.111.111-11, são rejeitadas.
+func validCPF(cpf string) bool {
+	if strings.Count(cpf, cpf[:1]) == len(cpf) {
+		return false
+	}
+	return mod11Digit(cpf[:9], []int{10, 9, 8, 7, 6, 5, 4, 3, 2}) == cpf[9] &&
+		mod11Digit(cpf[:10], []int{11, 10, 9, 8, 7, 6, 5, 4, 3, 2}) == cpf[10]
+}
+
+// validCNPJ confere os dois dígitos verificadores do CNPJ (módulo 11, pesos 2 a 9
+// aplicados da direita para a esquerda).
+func validCNPJ(cnpj string) bool {
+	if strings.Count(cnpj, cnpj[:1]) == len(cnpj) {
+		return false
+	}
+	return mod11Digit(cnpj[:12], []int{5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}) == cnpj[12] &&
+		mod11Digit(cnpj[:13], []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}) == cnpj[13]
+}
+
+// mod11Digit calcula um dígito verificador módulo 11: restos menores que 2 resultam em 0.
+func mod11Digit(payload string, weights []int) byte {
+	sum := 0
+	for i := 0; i < len(payload); i++ {
+		sum += int(payload[i]-'0') * weights[i]
+	}
+	rest := sum % 11
+	if rest < 2 {
+		return '0'
+	}
+	return byte('0' + 11 - rest)
+}
+
+// NormalizeCEP aceita CEPs nos formatos 12345678 e 12345-678 e retorna apenas os dígitos.
+func NormalizeCEP(cep string) (string, error) {
+	digits := stripFormatting(cep, "- ")
+	if len(digits) != 8 || !isDigits(digits) {
+		return "", fmt.Errorf("%w: CEP %q deve estar no formato 00000-000", domain.ErrInvalidClientData, cep)
+	}
+	return digits, nil
+}
+
+// stripFormatting remove de value os caracteres de pontuação informados.
+func stripFormatting(value, punctuation string) string {
+	return strings.Map(func(r rune) rune {
+		if strings.ContainsRune(punctuation, r) {
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(value))
+}
+
+func isDigits(value string) bool {
+	for _, c := range value {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
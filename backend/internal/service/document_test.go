@@ -0,0 +1,92 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"controle-de-estoque/backend/internal/domain"
+)
+
+func TestNormalizeDocument(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		want     string
+		wantErr  bool
+	}{
+		{"CPF formatado", "529.982.247-25", "52998224725", false},
+		{"CPF só dígitos", "52998224725", "52998224725", false},
+		{"CPF com espaços nas pontas", "  529.982.247-25 ", "52998224725", false},
+		{"CPF com primeiro dígito errado", "529.982.247-35", "", true},
+		{"CPF com segundo dígito errado", "529.982.247-26", "", true},
+		{"CPF com dígitos repetidos", "111.111.111-11", "", true},
+		{"CPF com resto menor que 2", "000.000.001-91", "00000000191", false},
+		{"CNPJ formatado", "11.222.333/0001-81", "11222333000181", false},
+		{"CNPJ só dígitos", "11222333000181", "11222333000181", false},
+		{"CNPJ com dígito errado", "11.222.333/0001-82", "", true},
+		{"CNPJ com dígitos repetidos", "00.000.000/0000-00", "", true},
+		{"letras", "529.982.247-2X", "", true},
+		{"tamanho inválido", "1234567890", "", true},
+		{"vazio", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeDocument(tt.document)
+			if tt.wantErr {
+				if !errors.Is(err, domain.ErrInvalidClientData) {
+					t.Fatalf("NormalizeDocument(%q) erro = %v, esperado ErrInvalidClientData", tt.document, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NormalizeDocument(%q) erro inesperado: %v", tt.document, err)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeDocument(%q) = %q, esperado %q", tt.document, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMod11Digit(t *testing.T) {
+	cpfWeights := []int{10, 9, 8, 7, 6, 5, 4, 3, 2}
+	tests := []struct {
+		payload string
+		want    byte
+	}{
+		{"529982247", '2'}, // soma 295, resto 9: 11 - 9
+		{"000000001", '9'}, // soma 2, resto 2: 11 - 2
+		{"000000000", '0'}, // resto 0
+		{"000000006", '0'}, // soma 12, resto 1
+	}
+	for _, tt := range tests {
+		if got := mod11Digit(tt.payload, cpfWeights); got != tt.want {
+			t.Errorf("mod11Digit(%q) = %c, esperado %c", tt.payload, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeCEP(t *testing.T) {
+	tests := []struct {
+		cep     string
+		want    string
+		wantErr bool
+	}{
+		{"01310-100", "01310100", false},
+		{"01310100", "01310100", false},
+		{" 01310 100 ", "01310100", false},
+		{"0131-0100", "01310100", false},
+		{"1310-100", "", true},
+		{"01310-10a", "", true},
+	}
+	for _, tt := range tests {
+		got, err := NormalizeCEP(tt.cep)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("NormalizeCEP(%q) erro = %v, esperado erro: %t", tt.cep, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("NormalizeCEP(%q) = %q, esperado %q", tt.cep, got, tt.want)
+		}
+	}
+}
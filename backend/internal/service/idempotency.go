@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"controle-de-estoque/backend/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// DefaultIdempotencyTTL é o tempo padrão durante o qual uma chave de idempotência é lembrada.
+const DefaultIdempotencyTTL = 24 * time.Hour
+
+// IIdempotencyRepository define a interface para o repositório de chaves de idempotência.
+type IIdempotencyRepository interface {
+	Get(ctx context.Context, scope, key string) (*domain.IdempotencyRecord, error)
+	Lock(ctx context.Context, tx pgx.Tx, scope, key string) (*domain.IdempotencyRecord, error)
+	Save(ctx context.Context, tx pgx.Tx, record *domain.IdempotencyRecord) error
+	DeleteExpired(ctx context.Context) (int64, error)
+}
+
+// IdempotencyRequest identifica uma requisição com o cabeçalho Idempotency-Key.
+// Respond monta, a partir do resultado da operação, o status e o corpo da resposta
+// que serão gravados e repetidos nas novas tentativas.
+type IdempotencyRequest struct {
+	Scope       string
+	Key         string
+	RequestHash string
+	Respond     func(result any) (status int, body []byte, err error)
+}
+
+type idempotencyContextKey struct{}
+
+// WithIdempotency associa a requisição idempotente ao contexto. As operações que a
+// suportam gravam a resposta na mesma transação em que alteram os dados.
+func WithIdempotency(ctx context.Context, req *IdempotencyRequest) context.Context {
+	return context.WithValue(ctx, idempotencyContextKey{}, req)
+}
+
+func idempotencyFrom(ctx context.Context) *IdempotencyRequest {
+	req, _ := ctx.Value(idempotencyContextKey{}).(*IdempotencyRequest)
+	return req
+}
+
+// IdempotencyService grava e recupera as respostas das requisições idempotentes.
+type IdempotencyService struct {
+	repo IIdempotencyRepository
+	ttl  time.Duration
+}
+
+// NewIdempotencyService cria uma nova instância de IdempotencyService. As chaves
+// expiram após ttl.
+func NewIdempotencyService(repo IIdempotencyRepository, ttl time.Duration) *IdempotencyService {
+	return &IdempotencyService{repo: repo, ttl: ttl}
+}
+
+// Lookup busca a resposta já gravada para a requisição. Retorna nil se a chave ainda
+// não foi usada e ErrIdempotencyKeyReuse se foi usada com outra requisição.
+func (s *IdempotencyService) Lookup(ctx context.Context, req *IdempotencyRequest) (*domain.IdempotencyRecord, error) {
+	record, err := s.repo.Get(ctx, req.Scope, req.Key)
+	if err != nil || record == nil {
+		return nil, err
+	}
+	return record, checkRequestHash(record, req)
+}
+
+// PurgeExpired remove as chaves expiradas.
+func (s *IdempotencyService) PurgeExpired(ctx context.Context) (int64, error) {
+	return s.repo.DeleteExpired(ctx)
+}
+
+// guard deve ser chamado logo após abrir a transação da operação. Aguarda tentativas
+// concorrentes com a mesma chave e, se alguma já concluiu, interrompe a operação com
+// um *domain.IdempotentReplayError. Sem chave no contexto, não faz nada.
+func (s *IdempotencyService) guard(ctx context.Context, tx pgx.Tx) error {
+	req := idempotencyFrom(ctx)
+	if req == nil {
+		return nil
+	}
+	record, err := s.repo.Lock(ctx, tx, req.Scope, req.Key)
+	if err != nil || record == nil {
+		return err
+	}
+	if err := checkRequestHash(record, req); err != nil {
+		return err
+	}
+	return &domain.IdempotentReplayError{Record: record}
+}
+
+// record grava a resposta da operação na transação, antes do commit. Sem chave no
+// contexto, não faz nada.
+func (s *IdempotencyService) record(ctx context.Context, tx pgx.Tx, result any) error {
+	req := idempotencyFrom(ctx)
+	if req == nil {
+		return nil
+	}
+	status, body, err := req.Respond(result)
+	if err != nil {
+		return fmt.Errorf("erro ao montar resposta idempotente: %w", err)
+	}
+	return s.repo.Save(ctx, tx, &domain.IdempotencyRecord{
+		Scope:        req.Scope,
+		Key:          req.Key,
+		RequestHash:  req.RequestHash,
+		StatusCode:   status,
+		ResponseBody: body,
+		ExpiresAt:    time.Now().Add(s.ttl),
+	})
+}
+
+func checkRequestHash(record *domain.IdempotencyRecord, req *IdempotencyRequest) error {
+	if record.RequestHash != req.RequestHash {
+		return fmt.Errorf("%w: %q", domain.ErrIdempotencyKeyReuse, req.Key)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"controle-de-estoque/backend/internal/domain"
+)
+
+// applyMergePatch aplica um JSON Merge Patch (RFC 7396) sobre o documento original.
+// Campos ausentes no patch são preservados, campos com `null` são removidos
+// e objetos aninhados são mesclados recursivamente.
+func applyMergePatch(original, patch []byte) ([]byte, error) {
+	var patchDoc any
+	if err := json.Unmarshal(patch, &patchDoc); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidMergePatch, err)
+	}
+	// Para os recursos da API o patch precisa ser um objeto; qualquer outro valor
+	// substituiria o documento inteiro, o que não é permitido.
+	if _, ok := patchDoc.(map[string]any); !ok {
+		return nil, fmt.Errorf("%w: o patch deve ser um objeto JSON", domain.ErrInvalidMergePatch)
+	}
+
+	var originalDoc any
+	if err := json.Unmarshal(original, &originalDoc); err != nil {
+		return nil, fmt.Errorf("erro ao decodificar documento original: %w", err)
+	}
+
+	return json.Marshal(mergePatch(originalDoc, patchDoc))
+}
+
+// decodeMerged decodifica o documento mesclado em dst. Campos que não existem no
+// recurso são rejeitados como erro de validação, assim como nas requisições PUT e POST.
+func decodeMerged(merged []byte, dst any) error {
+	decoder := json.NewDecoder(bytes.NewReader(merged))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(dst); err != nil {
+		if field, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+			return &domain.ValidationError{
+				Err:    domain.ErrInvalidMergePatch,
+				Fields: []domain.FieldError{{Field: strings.Trim(field, `"`), Code: domain.CodeUnknownField, Message: "campo não reconhecido"}},
+			}
+		}
+		return fmt.Errorf("%w: %v", domain.ErrInvalidMergePatch, err)
+	}
+	return nil
+}
+
+// mergePatch implementa o algoritmo MergePatch descrito na seção 2 da RFC 7396.
+func mergePatch(target, patch any) any {
+	patchObj, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	targetObj, ok := target.(map[string]any)
+	if !ok {
+		targetObj = map[string]any{}
+	}
+
+	for key, value := range patchObj {
+		if value == nil {
+			delete(targetObj, key)
+			continue
+		}
+		targetObj[key] = mergePatch(targetObj[key], value)
+	}
+	return targetObj
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"controle-de-estoque/backend/internal/domain"
+)
+
+func TestApplyMergePatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		original string
+		patch    string
+		want     string
+	}{
+		{"patch vazio preserva tudo", `{"a":1,"b":"x"}`, `{}`, `{"a":1,"b":"x"}`},
+		{"substitui campo", `{"a":1,"b":"x"}`, `{"b":"y"}`, `{"a":1,"b":"y"}`},
+		{"adiciona campo", `{"a":1}`, `{"b":true}`, `{"a":1,"b":true}`},
+		{"null remove campo", `{"a":1,"b":"x"}`, `{"b":null}`, `{"a":1}`},
+		{"null em campo ausente", `{"a":1}`, `{"z":null}`, `{"a":1}`},
+		{"objeto aninhado é mesclado", `{"o":{"x":1,"y":2}}`, `{"o":{"y":3,"z":4}}`, `{"o":{"x":1,"y":3,"z":4}}`},
+		{"null aninhado remove só o campo interno", `{"o":{"x":1,"y":2}}`, `{"o":{"x":null}}`, `{"o":{"y":2}}`},
+		{"lista é substituída", `{"l":[1,2,3]}`, `{"l":[4]}`, `{"l":[4]}`},
+		{"objeto sobre escalar", `{"o":"texto"}`, `{"o":{"x":1}}`, `{"o":{"x":1}}`},
+		{"null dentro de objeto novo é descartado", `{}`, `{"o":{"x":null,"y":1}}`, `{"o":{"y":1}}`},
+		{"escalar sobre objeto", `{"o":{"x":1}}`, `{"o":5}`, `{"o":5}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := applyMergePatch([]byte(tt.original), []byte(tt.patch))
+			if err != nil {
+				t.Fatalf("applyMergePatch erro inesperado: %v", err)
+			}
+			var gotDoc, wantDoc any
+			if err := json.Unmarshal(got, &gotDoc); err != nil {
+				t.Fatalf("resultado não é JSON: %v", err)
+			}
+			if err := json.Unmarshal([]byte(tt.want), &wantDoc); err != nil {
+				t.Fatalf("esperado não é JSON: %v", err)
+			}
+			if !reflect.DeepEqual(gotDoc, wantDoc) {
+				t.Errorf("applyMergePatch(%s, %s) = %s, esperado %s", tt.original, tt.patch, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyMergePatchRejectsNonObject(t *testing.T) {
+	for _, patch := range []string{`[1,2]`, `"texto"`, `null`, `42`, `{`} {
+		if _, err := applyMergePatch([]byte(`{"a":1}`), []byte(patch)); !errors.Is(err, domain.ErrInvalidMergePatch) {
+			t.Errorf("applyMergePatch com patch %s: erro = %v, esperado ErrInvalidMergePatch", patch, err)
+		}
+	}
+}
+
+func TestDecodeMerged(t *testing.T) {
+	var dst struct {
+		Name string `json:"name"`
+	}
+	if err := decodeMerged([]byte(`{"name":"x"}`), &dst); err != nil || dst.Name != "x" {
+		t.Fatalf("decodeMerged = %v, nome %q", err, dst.Name)
+	}
+
+	err := decodeMerged([]byte(`{"name":"x","extra":1}`), &dst)
+	var validationErr *domain.ValidationError
+	if !errors.As(err, &validationErr) || !errors.Is(err, domain.ErrInvalidMergePatch) {
+		t.Fatalf("campo desconhecido: erro = %v, esperado ValidationError de ErrInvalidMergePatch", err)
+	}
+	if len(validationErr.Fields) != 1 || validationErr.Fields[0].Field != "extra" || validationErr.Fields[0].Code != domain.CodeUnknownField {
+		t.Errorf("campos com erro = %+v, esperado extra com %s", validationErr.Fields, domain.CodeUnknownField)
+	}
+
+	if err := decodeMerged([]byte(`{"name":1}`), &dst); !errors.Is(err, domain.ErrInvalidMergePatch) {
+		t.Errorf("tipo inválido: erro = %v, esperado ErrInvalidMergePatch", err)
+	}
+}
@@ -0,0 +1,21 @@
+package service
+
+import "controle-de-estoque/backend/internal/domain"
+
+// newPageMetadata monta os metadados de uma página. Os totais só aparecem quando foram
+// calculados, e a página atual apenas na paginação por deslocamento.
+func newPageMetadata(page domain.PageRequest, total *int, nextCursor string) domain.Metadata {
+	metadata := domain.Metadata{
+		PageSize:     page.Limit,
+		TotalRecords: total,
+		NextCursor:   nextCursor,
+	}
+	if page.Cursor == "" {
+		metadata.CurrentPage = page.Page
+	}
+	if total != nil {
+		totalPages := (*total + page.Limit - 1) / page.Limit
+		metadata.TotalPages = &totalPages
+	}
+	return metadata
+}
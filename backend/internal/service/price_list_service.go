@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"controle-de-estoque/backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// IPriceListRepository define a interface para o repositório de tabelas de preços.
+type IPriceListRepository interface {
+	Create(ctx context.Context, list *domain.PriceList) error
+	List(ctx context.Context) ([]domain.PriceList, error)
+	GetByID(ctx context.Context, listID uuid.UUID) (*domain.PriceList, error)
+	Update(ctx context.Context, list *domain.PriceList) error
+	Delete(ctx context.Context, listID uuid.UUID) error
+	ListForClient(ctx context.Context, clientID uuid.UUID) ([]domain.PriceList, error)
+	ReplaceForClient(ctx context.Context, clientID uuid.UUID, listIDs []uuid.UUID) error
+}
+
+// PriceListService contém a lógica de negócio das tabelas de preços por cliente.
+type PriceListService struct {
+	repo IPriceListRepository
+}
+
+// NewPriceListService cria uma nova instância de PriceListService.
+func NewPriceListService(repo IPriceListRepository) *PriceListService {
+	return &PriceListService{repo: repo}
+}
+
+// maxPriceListNameLength limita o tamanho do nome de uma tabela de preços.
+const maxPriceListNameLength = 100
+
+// Create cria uma tabela de preços com os seus itens.
+func (s *PriceListService) Create(ctx context.Context, list *domain.PriceList) error {
+	if err := validatePriceList(list); err != nil {
+		return err
+	}
+	return s.repo.Create(ctx, list)
+}
+
+// List retorna as tabelas de preços, sem os itens.
+func (s *PriceListService) List(ctx context.Context) ([]domain.PriceList, error) {
+	return s.repo.List(ctx)
+}
+
+// GetByID retorna uma tabela de preços com os seus itens.
+func (s *PriceListService) GetByID(ctx context.Context, listID uuid.UUID) (*domain.PriceList, error) {
+	return s.repo.GetByID(ctx, listID)
+}
+
+// Update substitui os dados e os itens de uma tabela de preços.
+func (s *PriceListService) Update(ctx context.Context, list *domain.PriceList) error {
+	if err := validatePriceList(list); err != nil {
+		return err
+	}
+	return s.repo.Update(ctx, list)
+}
+
+// Delete remove uma tabela de preços.
+func (s *PriceListService) Delete(ctx context.Context, listID uuid.UUID) error {
+	return s.repo.Delete(ctx, listID)
+}
+
+// ListForClient retorna as tabelas de preços atribuídas ao cliente.
+func (s *PriceListService) ListForClient(ctx context.Context, clientID uuid.UUID) ([]domain.PriceList, error) {
+	return s.repo.ListForClient(ctx, clientID)
+}
+
+// AssignToClient substitui as tabelas de preços do cliente pelas informadas e retorna
+// as atribuídas. Uma lista vazia remove todas, e o cliente volta aos preços de cadastro.
+func (s *PriceListService) AssignToClient(ctx context.Context, clientID uuid.UUID, listIDs []uuid.UUID) ([]domain.PriceList, error) {
+	var v validator
+	seen := make(map[uuid.UUID]struct{}, len(listIDs))
+	for i, id := range listIDs {
+		field := fmt.Sprintf("price_list_ids[%d]", i)
+		v.check(id != uuid.Nil, field, domain.CodeRequired, "o ID da tabela é obrigatório")
+		if _, dup := seen[id]; dup {
+			v.add(field, domain.CodeDuplicate, fmt.Sprintf("tabela %s repetida", id))
+		}
+		seen[id] = struct{}{}
+	}
+	if err := v.err(domain.ErrInvalidPriceList); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.ReplaceForClient(ctx, clientID, listIDs); err != nil {
+		return nil, err
+	}
+	return s.repo.ListForClient(ctx, clientID)
+}
+
+// validatePriceList normaliza o nome e a moeda e verifica o período e os itens,
+// acumulando os erros por campo.
+func validatePriceList(l *domain.PriceList) error {
+	l.Name = strings.TrimSpace(l.Name)
+	l.Currency = domain.Currency(strings.ToUpper(strings.TrimSpace(string(l.Currency))))
+
+	var v validator
+	v.check(l.Name != "", "name", domain.CodeRequired, "o nome é obrigatório")
+	v.maxLength("name", l.Name, maxPriceListNameLength)
+	v.check(l.Currency.Valid(), "currency", domain.CodeInvalid, fmt.Sprintf("moeda %q não é um código ISO 4217 válido", l.Currency))
+	if l.ValidFrom != nil && l.ValidTo != nil {
+		v.check(l.ValidTo.After(*l.ValidFrom), "valid_to", domain.CodeOutOfRange, "o fim da vigência deve ser posterior ao início")
+	}
+
+	seen := make(map[uuid.UUID]struct{}, len(l.Items))
+	for i, item := range l.Items {
+		productID := indexed("items", i, "product_id")
+		v.check(item.ProductID != uuid.Nil, productID, domain.CodeRequired, "o produto é obrigatório")
+		if _, dup := seen[item.ProductID]; dup {
+			v.add(productID, domain.CodeDuplicate, fmt.Sprintf("produto %s repetido", item.ProductID))
+		}
+		seen[item.ProductID] = struct{}{}
+		v.check(item.PriceInCents >= 0, indexed("items", i, "price_in_cents"), domain.CodeOutOfRange, "o preço não pode ser negativo")
+	}
+	return v.err(domain.ErrInvalidPriceList)
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"controle-de-estoque/backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// IAttributeDefinitionRepository fornece as definições de atributos válidas para uma categoria.
+type IAttributeDefinitionRepository interface {
+	ListAttributes(ctx context.Context, categoryID uuid.UUID) ([]domain.AttributeDefinition, error)
+}
+
+const (
+	maxTagLength   = 50
+	maxTagsPerItem = 50
+)
+
+// attributeKeyPattern restringe as chaves de atributos a identificadores simples em minúsculas.
+var attributeKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// ValidAttributeKey informa se a chave pode ser usada como atributo personalizado.
+func ValidAttributeKey(key string) bool {
+	return len(key) <= 64 && attributeKeyPattern.MatchString(key)
+}
+
+// NormalizeTags remove espaços e duplicatas e converte as tags para minúsculas.
+func NormalizeTags(tags []string) []string {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" {
+			continue
+		}
+		if _, dup := seen[tag]; dup {
+			continue
+		}
+		seen[tag] = struct{}{}
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
+
+// validateAttributes normaliza as tags e valida os atributos do produto contra as
+// definições da sua categoria (incluindo as herdadas dos ancestrais).
+func (s *ProductService) validateAttributes(ctx context.Context, p *domain.Produto) error {
+	p.Tags = NormalizeTags(p.Tags)
+	if len(p.Tags) > maxTagsPerItem {
+		return fmt.Errorf("%w: no máximo %d tags por produto", domain.ErrInvalidProductData, maxTagsPerItem)
+	}
+	for _, tag := range p.Tags {
+		if len(tag) > maxTagLength {
+			return fmt.Errorf("%w: a tag %q excede %d caracteres", domain.ErrInvalidProductData, tag, maxTagLength)
+		}
+	}
+
+	if p.Attributes == nil {
+		p.Attributes = map[string]any{}
+	}
+	if p.CategoryID == nil {
+		if len(p.Attributes) > 0 {
+			return fmt.Errorf("%w: produtos sem categoria não aceitam atributos", domain.ErrInvalidAttribute)
+		}
+		return nil
+	}
+
+	definitions, err := s.attributeRepo.ListAttributes(ctx, *p.CategoryID)
+	if err != nil {
+		return err
+	}
+	byKey := make(map[string]domain.AttributeDefinition, len(definitions))
+	for _, d := range definitions {
+		byKey[d.Key] = d
+	}
+
+	for key, value := range p.Attributes {
+		def, ok := byKey[key]
+		if !ok {
+			return fmt.Errorf("%w: %q não está definido para a categoria", domain.ErrInvalidAttribute, key)
+		}
+		if err := checkAttributeValue(def, value); err != nil {
+			return err
+		}
+	}
+	for _, def := range definitions {
+		if _, ok := p.Attributes[def.Key]; def.Required && !ok {
+			return fmt.Errorf("%w: %q é obrigatório", domain.ErrInvalidAttribute, def.Key)
+		}
+	}
+	return nil
+}
+
+// checkAttributeValue verifica se o valor decodificado do JSON corresponde ao tipo definido.
+func checkAttributeValue(def domain.AttributeDefinition, value any) error {
+	switch def.Type {
+	case domain.AttributeString:
+		if _, ok := value.(string); ok {
+			return nil
+		}
+	case domain.AttributeNumber:
+		if _, ok := value.(float64); ok {
+			return nil
+		}
+	case domain.AttributeBoolean:
+		if _, ok := value.(bool); ok {
+			return nil
+		}
+	case domain.AttributeEnum:
+		if str, ok := value.(string); ok {
+			for _, option := range def.Options {
+				if option == str {
+					return nil
+				}
+			}
+			return fmt.Errorf("%w: %q deve ser um de %v", domain.ErrInvalidAttribute, def.Key, def.Options)
+		}
+	}
+	return fmt.Errorf("%w: %q deve ser do tipo %s", domain.ErrInvalidAttribute, def.Key, def.Type)
+}
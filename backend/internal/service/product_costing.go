@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"controle-de-estoque/backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// ICostLayerRepository define a interface para as camadas de custo PEPS (FIFO).
+type ICostLayerRepository interface {
+	AddLayer(ctx context.Context, tx pgx.Tx, productID uuid.UUID, unitCost int64, quantity int) error
+	ConsumeLayers(ctx context.Context, tx pgx.Tx, productID uuid.UUID, quantity int) (int, int64, error)
+	DeleteLayers(ctx context.Context, tx pgx.Tx, productID uuid.UUID) error
+}
+
+// applyCost valoriza uma variação de quantity unidades no estoque global do produto
+// (bloqueado na transação), grava o novo custo e atualiza a quantidade e o custo em
+// product, retornando o custo da movimentação com o sinal da quantidade. As entradas usam
+// unitCost ou, se nulo, o custo médio atual. As saídas usam o custo médio ou, no PEPS, as
+// camadas mais antigas; a parte não coberta por camadas sai pelo custo médio. A última
+// unidade leva o valor restante.
+func (s *ProductService) applyCost(ctx context.Context, tx pgx.Tx, product *domain.Produto, quantity int, unitCost *int64) (int64, error) {
+	if quantity == 0 {
+		return 0, nil
+	}
+	current := int64(product.Quantity)
+	value := product.StockValueInCents
+	var cost int64
+
+	if quantity > 0 {
+		unit := product.AverageCostInCents
+		if unitCost != nil {
+			unit = *unitCost
+		}
+		if unit > math.MaxInt64/int64(quantity) || value > math.MaxInt64-unit*int64(quantity) {
+			return 0, fmt.Errorf("%w: o custo da movimentação excede o limite suportado", domain.ErrInvalidQuantity)
+		}
+		cost = unit * int64(quantity)
+		if product.CostingMethod == domain.CostingFIFO {
+			if err := s.costRepo.AddLayer(ctx, tx, product.ID, unit, quantity); err != nil {
+				return 0, err
+			}
+		}
+	} else {
+		out := int64(-quantity)
+		switch {
+		case out >= current:
+			cost = value
+			if product.CostingMethod == domain.CostingFIFO {
+				if _, _, err := s.costRepo.ConsumeLayers(ctx, tx, product.ID, -quantity); err != nil {
+					return 0, err
+				}
+			}
+		case product.CostingMethod == domain.CostingFIFO:
+			consumed, layersCost, err := s.costRepo.ConsumeLayers(ctx, tx, product.ID, -quantity)
+			if err != nil {
+				return 0, err
+			}
+			cost = min(layersCost+product.AverageCostInCents*(out-int64(consumed)), value)
+		default:
+			cost = mulDivRound(value, out, current)
+		}
+		cost = -cost
+	}
+
+	product.Quantity += quantity
+	product.StockValueInCents = value + cost
+	if product.Quantity > 0 {
+		product.AverageCostInCents = mulDivRound(product.StockValueInCents, 1, int64(product.Quantity))
+	} else {
+		product.StockValueInCents = 0
+	}
+	if err := s.repo.UpdateCost(ctx, tx, product.ID, product.StockValueInCents, product.AverageCostInCents); err != nil {
+		return 0, err
+	}
+	return cost, nil
+}
+
+// mulDivRound calcula a*b/c arredondado, sem estourar o produto intermediário quando
+// b <= c (a, b >= 0 e c > 0).
+func mulDivRound(a, b, c int64) int64 {
+	q, r := a/c, a%c
+	return q*b + (r*b+c/2)/c
+}
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"controle-de-estoque/backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// costProductRepo registra as gravações de custo; os demais métodos não são usados.
+type costProductRepo struct {
+	IProductRepository
+	updates int
+}
+
+func (r *costProductRepo) UpdateCost(context.Context, pgx.Tx, uuid.UUID, int64, int64) error {
+	r.updates++
+	return nil
+}
+
+type memoryCostLayer struct {
+	unitCost  int64
+	remaining int
+}
+
+// memoryCostLayers guarda as camadas PEPS em memória, da mais antiga para a mais nova.
+type memoryCostLayers struct {
+	layers []memoryCostLayer
+}
+
+func (m *memoryCostLayers) AddLayer(_ context.Context, _ pgx.Tx, _ uuid.UUID, unitCost int64, quantity int) error {
+	m.layers = append(m.layers, memoryCostLayer{unitCost: unitCost, remaining: quantity})
+	return nil
+}
+
+func (m *memoryCostLayers) ConsumeLayers(_ context.Context, _ pgx.Tx, _ uuid.UUID, quantity int) (int, int64, error) {
+	consumed, cost := 0, int64(0)
+	for i := range m.layers {
+		if consumed == quantity {
+			break
+		}
+		n := min(m.layers[i].remaining, quantity-consumed)
+		m.layers[i].remaining -= n
+		consumed += n
+		cost += m.layers[i].unitCost * int64(n)
+	}
+	return consumed, cost, nil
+}
+
+func (m *memoryCostLayers) DeleteLayers(context.Context, pgx.Tx, uuid.UUID) error {
+	m.layers = nil
+	return nil
+}
+
+func TestApplyCost(t *testing.T) {
+	type step struct {
+		quantity  int
+		unitCost  *int64
+		wantCost  int64
+		wantValue int64
+		wantAvg   int64
+	}
+	cost := func(v int64) *int64 { return &v }
+	tests := []struct {
+		name    string
+		method  domain.CostingMethod
+		initial domain.Produto
+		steps   []step
+	}{
+		{
+			name:   "média ponderada com saída parcial e total",
+			method: domain.CostingAverage,
+			steps: []step{
+				{quantity: 10, unitCost: cost(100), wantCost: 1000, wantValue: 1000, wantAvg: 100},
+				{quantity: 10, unitCost: cost(200), wantCost: 2000, wantValue: 3000, wantAvg: 150},
+				{quantity: -15, wantCost: -2250, wantValue: 750, wantAvg: 150},
+				{quantity: -5, wantCost: -750, wantValue: 0, wantAvg: 150},
+			},
+		},
+		{
+			name:   "PEPS com saída parcial e total",
+			method: domain.CostingFIFO,
+			steps: []step{
+				{quantity: 10, unitCost: cost(100), wantCost: 1000, wantValue: 1000, wantAvg: 100},
+				{quantity: 10, unitCost: cost(200), wantCost: 2000, wantValue: 3000, wantAvg: 150},
+				{quantity: -15, wantCost: -2000, wantValue: 1000, wantAvg: 200},
+				{quantity: -5, wantCost: -1000, wantValue: 0, wantAvg: 200},
+			},
+		},
+		{
+			name:    "média arredonda e a última unidade leva o resto",
+			method:  domain.CostingAverage,
+			initial: domain.Produto{Quantity: 3, StockValueInCents: 1000, AverageCostInCents: 333},
+			steps: []step{
+				{quantity: -1, wantCost: -333, wantValue: 667, wantAvg: 334},
+				{quantity: -2, wantCost: -667, wantValue: 0, wantAvg: 334},
+			},
+		},
+		{
+			name:    "entrada sem custo usa o custo médio",
+			method:  domain.CostingAverage,
+			initial: domain.Produto{Quantity: 2, StockValueInCents: 300, AverageCostInCents: 150},
+			steps: []step{
+				{quantity: 2, wantCost: 300, wantValue: 600, wantAvg: 150},
+			},
+		},
+		{
+			name:    "PEPS sem camadas suficientes completa pelo custo médio",
+			method:  domain.CostingFIFO,
+			initial: domain.Produto{Quantity: 10, StockValueInCents: 1000, AverageCostInCents: 100},
+			steps: []step{
+				{quantity: -4, wantCost: -400, wantValue: 600, wantAvg: 100},
+				{quantity: 3, unitCost: cost(300), wantCost: 900, wantValue: 1500, wantAvg: 167},
+				{quantity: -5, wantCost: -1234, wantValue: 266, wantAvg: 67}, // 3 × 300 da camada + 2 × 167
+				{quantity: -4, wantCost: -266, wantValue: 0, wantAvg: 67},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &costProductRepo{}
+			s := &ProductService{repo: repo, costRepo: &memoryCostLayers{}}
+			product := tt.initial
+			product.CostingMethod = tt.method
+			for i, st := range tt.steps {
+				got, err := s.applyCost(context.Background(), nil, &product, st.quantity, st.unitCost)
+				if err != nil {
+					t.Fatalf("passo %d: erro inesperado: %v", i, err)
+				}
+				if got != st.wantCost || product.StockValueInCents != st.wantValue || product.AverageCostInCents != st.wantAvg {
+					t.Errorf("passo %d (%+d): custo %d, valor %d, médio %d; esperado %d, %d, %d", i, st.quantity,
+						got, product.StockValueInCents, product.AverageCostInCents, st.wantCost, st.wantValue, st.wantAvg)
+				}
+			}
+			if repo.updates != len(tt.steps) {
+				t.Errorf("UpdateCost chamado %d vezes, esperado %d", repo.updates, len(tt.steps))
+			}
+		})
+	}
+}
+
+func TestApplyCostLimits(t *testing.T) {
+	repo := &costProductRepo{}
+	s := &ProductService{repo: repo, costRepo: &memoryCostLayers{}}
+
+	product := domain.Produto{CostingMethod: domain.CostingAverage}
+	if got, err := s.applyCost(context.Background(), nil, &product, 0, nil); got != 0 || err != nil || repo.updates != 0 {
+		t.Errorf("quantidade zero: custo %d, erro %v, %d gravações", got, err, repo.updates)
+	}
+
+	huge := int64(math.MaxInt64 / 2)
+	if _, err := s.applyCost(context.Background(), nil, &product, 3, &huge); !errors.Is(err, domain.ErrInvalidQuantity) {
+		t.Errorf("custo acima do limite: erro = %v, esperado ErrInvalidQuantity", err)
+	}
+}
+
+func TestMulDivRound(t *testing.T) {
+	tests := []struct {
+		a, b, c int64
+		want    int64
+	}{
+		{10, 1, 3, 3},
+		{10, 2, 3, 7},
+		{5, 1, 2, 3},
+		{3000, 15, 20, 2250},
+		{0, 5, 7, 0},
+		{math.MaxInt64, 2, 3, 6148914691236517205},
+		{math.MaxInt64, 1, 1, math.MaxInt64},
+	}
+	for _, tt := range tests {
+		if got := mulDivRound(tt.a, tt.b, tt.c); got != tt.want {
+			t.Errorf("mulDivRound(%d, %d, %d) = %d, esperado %d", tt.a, tt.b, tt.c, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,476 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"controle-de-estoque/backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// IImportJobRepository define a interface para o repositório de jobs de importação.
+type IImportJobRepository interface {
+	Create(ctx context.Context, job *domain.ImportJob) error
+	GetByID(ctx context.Context, jobID uuid.UUID) (*domain.ImportJob, error)
+	Claim(ctx context.Context, staleAfter time.Duration) (*domain.ImportJob, error)
+	SaveProgress(ctx context.Context, job *domain.ImportJob, rows []domain.ImportRow) error
+	Finish(ctx context.Context, job *domain.ImportJob) error
+	ListRows(ctx context.Context, jobID uuid.UUID, action domain.ImportAction, page domain.PageRequest) ([]domain.ImportRow, *int, string, error)
+	EachErrorRow(ctx context.Context, jobID uuid.UUID, fn func(domain.ImportRow) error) error
+}
+
+const (
+	// importBatchSize é a quantidade de linhas gravadas de uma vez, o que também define
+	// a frequência com que o progresso do job é atualizado.
+	importBatchSize = 200
+	// importStaleAfter é o tempo sem progresso após o qual um job em processamento é
+	// considerado abandonado (ex.: a instância foi encerrada) e pode ser retomado.
+	importStaleAfter = 5 * time.Minute
+)
+
+// ProductImportService importa produtos em lote a partir de arquivos CSV ou XLSX. Os
+// jobs ficam em uma fila no banco e são processados em segundo plano por ProcessNext,
+// criando ou atualizando (pelo SKU) um produto por linha.
+type ProductImportService struct {
+	products     *ProductService
+	categoryRepo ICategoryRepository
+	repo         IImportJobRepository
+	submitted    chan struct{}
+}
+
+// NewProductImportService cria uma nova instância de ProductImportService.
+func NewProductImportService(products *ProductService, categoryRepo ICategoryRepository, repo IImportJobRepository) *ProductImportService {
+	return &ProductImportService{
+		products:     products,
+		categoryRepo: categoryRepo,
+		repo:         repo,
+		submitted:    make(chan struct{}, 1),
+	}
+}
+
+// ImportRequest descreve um arquivo enviado para importação. Mapping associa os campos
+// do produto aos cabeçalhos do arquivo; vazio, os cabeçalhos devem ter o nome dos campos.
+type ImportRequest struct {
+	FileName  string
+	Data      []byte
+	Mapping   map[string]string
+	DryRun    bool
+	CreatedBy uuid.UUID
+}
+
+// Submit valida o formato, o cabeçalho e o mapeamento do arquivo e coloca o job na
+// fila. As linhas só são validadas durante o processamento.
+func (s *ProductImportService) Submit(ctx context.Context, req ImportRequest) (*domain.ImportJob, error) {
+	format, err := ImportFormatFromFileName(req.FileName)
+	if err != nil {
+		return nil, err
+	}
+	sheet, err := parseImportFile(format, req.Data)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := resolveImportColumns(sheet.header, req.Mapping); err != nil {
+		return nil, err
+	}
+
+	job := &domain.ImportJob{
+		CreatedBy: req.CreatedBy,
+		DryRun:    req.DryRun,
+		FileName:  filepath.Base(req.FileName),
+		Format:    format,
+		Mapping:   req.Mapping,
+		File:      req.Data,
+		TotalRows: len(sheet.lines),
+	}
+	if job.Mapping == nil {
+		job.Mapping = map[string]string{}
+	}
+	if err := s.repo.Create(ctx, job); err != nil {
+		return nil, err
+	}
+
+	select {
+	case s.submitted <- struct{}{}:
+	default:
+	}
+	return job, nil
+}
+
+// Submitted é sinalizado quando um job é enviado nesta instância, para que o
+// processamento em segundo plano não precise esperar a próxima verificação da fila.
+func (s *ProductImportService) Submitted() <-chan struct{} {
+	return s.submitted
+}
+
+// Get busca um job de importação.
+func (s *ProductImportService) Get(ctx context.Context, jobID uuid.UUID) (*domain.ImportJob, error) {
+	return s.repo.GetByID(ctx, jobID)
+}
+
+// ListRows busca uma página do resultado das linhas do job, opcionalmente apenas as
+// de uma ação. No dry-run, é o relatório do que seria alterado.
+func (s *ProductImportService) ListRows(ctx context.Context, jobID uuid.UUID, action domain.ImportAction, page domain.PageRequest) (*domain.PaginatedResponse, error) {
+	switch action {
+	case "", domain.ImportCreate, domain.ImportUpdate, domain.ImportUnchanged, domain.ImportError:
+	default:
+		return nil, fmt.Errorf("%w: ação %q", domain.ErrInvalidFilter, action)
+	}
+	if _, err := s.repo.GetByID(ctx, jobID); err != nil {
+		return nil, err
+	}
+
+	rows, total, next, err := s.repo.ListRows(ctx, jobID, action, page)
+	if err != nil {
+		return nil, err
+	}
+	metadata := newPageMetadata(page, total, next)
+	if action != "" {
+		metadata.AppliedFilters = map[string]any{"action": action}
+	}
+	return &domain.PaginatedResponse{Data: rows, Metadata: metadata}, nil
+}
+
+// EachError percorre as linhas rejeitadas do job, na ordem do arquivo.
+func (s *ProductImportService) EachError(ctx context.Context, jobID uuid.UUID, fn func(domain.ImportRow) error) error {
+	if _, err := s.repo.GetByID(ctx, jobID); err != nil {
+		return err
+	}
+	return s.repo.EachErrorRow(ctx, jobID, fn)
+}
+
+// ProcessNext assume e processa o próximo job da fila, retornando false se não havia
+// nenhum. Um arquivo inválido encerra o job como falho; erros inesperados também, mas
+// são retornados para registro. Se ctx for cancelado, o job é deixado como está e será
+// retomado do início quando seu sinal de vida expirar.
+func (s *ProductImportService) ProcessNext(ctx context.Context) (bool, error) {
+	job, err := s.repo.Claim(ctx, importStaleAfter)
+	if err != nil || job == nil {
+		return false, err
+	}
+
+	err = s.process(ctx, job)
+	if ctx.Err() != nil {
+		return true, ctx.Err()
+	}
+	job.Status = domain.ImportCompleted
+	var domainErr *domain.Error
+	switch {
+	case err == nil:
+	case errors.As(err, &domainErr):
+		job.Status, job.Failure = domain.ImportFailed, err.Error()
+		err = nil
+	default:
+		job.Status, job.Failure = domain.ImportFailed, "erro interno ao processar a importação"
+		err = fmt.Errorf("importação %s: %w", job.ID, err)
+	}
+	if finishErr := s.repo.Finish(ctx, job); finishErr != nil {
+		return true, errors.Join(err, finishErr)
+	}
+	return true, err
+}
+
+// process importa as linhas do job, gravando o resultado e o progresso a cada lote.
+func (s *ProductImportService) process(ctx context.Context, job *domain.ImportJob) error {
+	sheet, err := parseImportFile(job.Format, job.File)
+	if err != nil {
+		return err
+	}
+	columns, err := resolveImportColumns(sheet.header, job.Mapping)
+	if err != nil {
+		return err
+	}
+	job.TotalRows = len(sheet.lines)
+
+	run := &importRun{
+		service:    s,
+		dryRun:     job.DryRun,
+		columns:    columns,
+		seen:       make(map[string]int),
+		categories: make(map[uuid.UUID]bool),
+	}
+	batch := make([]domain.ImportRow, 0, importBatchSize)
+	for i, line := range sheet.lines {
+		row, err := run.importLine(ctx, line)
+		if err != nil {
+			return err
+		}
+		job.Count(row.Action)
+		batch = append(batch, row)
+		if len(batch) == importBatchSize || i == len(sheet.lines)-1 {
+			if err := s.repo.SaveProgress(ctx, job, batch); err != nil {
+				return err
+			}
+			batch = batch[:0]
+		}
+	}
+	return nil
+}
+
+// importRun guarda o estado do processamento de um job.
+type importRun struct {
+	service    *ProductImportService
+	dryRun     bool
+	columns    map[string]int
+	seen       map[string]int     // SKU -> primeira linha em que apareceu
+	categories map[uuid.UUID]bool // Categorias já consultadas -> existe
+}
+
+// importLine cria ou atualiza o produto de uma linha. Problemas da linha são
+// devolvidos em row.Errors; o erro retornado indica uma falha que interrompe o job.
+func (r *importRun) importLine(ctx context.Context, line importLine) (domain.ImportRow, error) {
+	cells := importCells{columns: r.columns, cells: line.cells}
+	row := domain.ImportRow{RowNumber: line.number, Action: domain.ImportError}
+
+	sku, _ := cells.get("sku")
+	row.SKU = strings.ToUpper(sku)
+	if row.SKU == "" {
+		row.Errors = []domain.FieldError{{Field: "sku", Code: domain.CodeRequired, Message: "o SKU é obrigatório"}}
+		return row, nil
+	}
+	if first, dup := r.seen[row.SKU]; dup {
+		row.Errors = []domain.FieldError{{Field: "sku", Code: domain.CodeDuplicate, Message: fmt.Sprintf("SKU repetido no arquivo (linha %d)", first)}}
+		return row, nil
+	}
+	r.seen[row.SKU] = line.number
+
+	var existing *domain.Produto
+	current, err := r.service.products.repo.GetProductBySKU(ctx, row.SKU)
+	switch {
+	case err == nil:
+		existing = &current
+		row.ProductID = &current.ID
+	case !errors.Is(err, domain.ErrProductNotFound):
+		return row, err
+	}
+
+	next := domain.Produto{SKU: row.SKU}
+	if existing != nil {
+		next = cloneProduct(*existing)
+	}
+	fields, err := r.apply(ctx, cells, &next)
+	if err != nil {
+		return row, err
+	}
+	if len(fields) > 0 {
+		row.Errors = fields
+		return row, nil
+	}
+	if err := r.validate(ctx, existing, &next); err != nil {
+		return rejectRow(row, err)
+	}
+
+	row.Changes = diffProduct(existing, &next)
+	switch {
+	case existing == nil:
+		row.Action = domain.ImportCreate
+	case len(row.Changes) == 0:
+		row.Action = domain.ImportUnchanged
+		return row, nil
+	default:
+		row.Action = domain.ImportUpdate
+	}
+	if r.dryRun {
+		return row, nil
+	}
+
+	if existing == nil {
+		err = r.service.products.CreateProduct(ctx, &next)
+	} else {
+		_, err = r.service.products.UpdateProduct(ctx, existing.ID, next)
+	}
+	if err != nil {
+		row.Changes = nil
+		return rejectRow(row, err)
+	}
+	row.ProductID = &next.ID
+	return row, nil
+}
+
+// apply copia para o produto os campos preenchidos na linha. Células vazias mantêm o
+// valor atual (ou o padrão, em produtos novos).
+func (r *importRun) apply(ctx context.Context, cells importCells, p *domain.Produto) ([]domain.FieldError, error) {
+	var v validator
+	if value, ok := cells.get("name"); ok {
+		p.Name = value
+	}
+	if value, ok := cells.get("description"); ok {
+		p.Description = value
+	}
+	if value, ok := cells.get("price_in_cents"); ok {
+		price, err := strconv.ParseInt(value, 10, 64)
+		v.check(err == nil, "price_in_cents", domain.CodeInvalidType, "deve ser um número inteiro de centavos")
+		p.PriceInCents = price
+	}
+	// A moeda vem antes do preço decimal, que é convertido pelas casas decimais dela.
+	if value, ok := cells.get("currency"); ok {
+		p.Currency = domain.Currency(strings.ToUpper(value))
+	}
+	if value, ok := cells.get("price"); ok {
+		price, valid := parsePriceInCents(value, p.Currency.MinorUnits())
+		v.check(valid, "price", domain.CodeInvalidType, "preço inválido; use, por exemplo, 12,50")
+		p.PriceInCents = price
+	}
+	if value, ok := cells.get("quantity"); ok {
+		quantity, err := strconv.Atoi(value)
+		v.check(err == nil, "quantity", domain.CodeInvalidType, "deve ser um número inteiro")
+		p.Quantity = quantity
+	}
+	if value, ok := cells.get("unit"); ok {
+		p.Unit = domain.UnitOfMeasure(value)
+	}
+	if value, ok := cells.get("category_id"); ok {
+		categoryID, err := uuid.Parse(value)
+		if err != nil {
+			v.add("category_id", domain.CodeInvalid, "ID de categoria inválido")
+		} else if exists, err := r.categoryExists(ctx, categoryID); err != nil {
+			return nil, err
+		} else {
+			v.check(exists, "category_id", domain.CodeInvalid, "categoria não encontrada")
+			p.CategoryID = &categoryID
+		}
+	}
+	if value, ok := cells.get("barcodes"); ok {
+		p.Barcodes = parseImportList(value)
+	}
+	if value, ok := cells.get("tags"); ok {
+		p.Tags = parseImportList(value)
+	}
+	for field, target := range map[string]*bool{"track_lots": &p.TrackLots, "serialized": &p.Serialized} {
+		if value, ok := cells.get(field); ok {
+			flag, valid := parseImportBool(value)
+			v.check(valid, field, domain.CodeInvalid, "use sim ou não")
+			*target = flag
+		}
+	}
+	for field := range r.columns {
+		key, isAttr := strings.CutPrefix(field, "attr.")
+		if !isAttr {
+			continue
+		}
+		if value, ok := cells.get(field); ok {
+			if p.Attributes == nil {
+				p.Attributes = make(map[string]any)
+			}
+			p.Attributes[key] = parseImportAttribute(value)
+		}
+	}
+	return v.fields, nil
+}
+
+// validate aplica ao produto as mesmas regras da criação e da atualização, para que o
+// dry-run rejeite as mesmas linhas que a importação real.
+func (r *importRun) validate(ctx context.Context, existing, next *domain.Produto) error {
+	if existing == nil {
+		if err := checkInitialStock(*next); err != nil {
+			return err
+		}
+	} else if err := checkTrackingChange(*existing, *next); err != nil {
+		return err
+	}
+	if err := validateProduct(next); err != nil {
+		return err
+	}
+	return r.service.products.validateAttributes(ctx, next)
+}
+
+func (r *importRun) categoryExists(ctx context.Context, categoryID uuid.UUID) (bool, error) {
+	if exists, ok := r.categories[categoryID]; ok {
+		return exists, nil
+	}
+	_, err := r.service.categoryRepo.GetCategoryByID(ctx, categoryID)
+	if err != nil && !errors.Is(err, domain.ErrCategoryNotFound) {
+		return false, err
+	}
+	r.categories[categoryID] = err == nil
+	return err == nil, nil
+}
+
+// parseImportAttribute interpreta o valor de um atributo como JSON (números, booleanos,
+// listas) e, se não for JSON válido, como texto.
+func parseImportAttribute(value string) any {
+	var decoded any
+	if err := json.Unmarshal([]byte(value), &decoded); err != nil {
+		return value
+	}
+	return decoded
+}
+
+// importErrorFields associa os erros de domínio sem campo próprio à coluna que os causou.
+var importErrorFields = map[*domain.Error]string{
+	domain.ErrSKUAlreadyExists: "sku",
+	domain.ErrBarcodeInUse:     "barcodes",
+	domain.ErrCategoryNotFound: "category_id",
+	domain.ErrInvalidAttribute: "attributes",
+}
+
+// rejectRow registra na linha o erro de validação ou de domínio. Outros erros
+// interrompem o job.
+func rejectRow(row domain.ImportRow, err error) (domain.ImportRow, error) {
+	var validationErr *domain.ValidationError
+	var domainErr *domain.Error
+	switch {
+	case errors.As(err, &validationErr):
+		row.Errors = validationErr.Fields
+	case errors.As(err, &domainErr):
+		row.Errors = []domain.FieldError{{Field: importErrorFields[domainErr], Code: domainErr.Code, Message: err.Error()}}
+	default:
+		return row, err
+	}
+	row.Action = domain.ImportError
+	return row, nil
+}
+
+// cloneProduct copia o produto sem compartilhar listas e atributos com o original.
+func cloneProduct(p domain.Produto) domain.Produto {
+	p.Barcodes = slices.Clone(p.Barcodes)
+	p.Tags = slices.Clone(p.Tags)
+	p.Attributes = maps.Clone(p.Attributes)
+	return p
+}
+
+// diffProduct lista os campos de next que diferem do produto atual. Para um produto
+// novo, lista os campos preenchidos, sem o valor anterior.
+func diffProduct(current, next *domain.Produto) []domain.ImportChange {
+	isNew := current == nil
+	if isNew {
+		current = &domain.Produto{}
+	}
+	var changes []domain.ImportChange
+	add := func(field string, from, to any, equal bool) {
+		if equal {
+			return
+		}
+		if isNew {
+			from = nil
+		}
+		changes = append(changes, domain.ImportChange{Field: field, From: from, To: to})
+	}
+
+	add("sku", current.SKU, next.SKU, current.SKU == next.SKU)
+	add("name", current.Name, next.Name, current.Name == next.Name)
+	add("description", current.Description, next.Description, current.Description == next.Description)
+	add("price_in_cents", current.PriceInCents, next.PriceInCents, current.PriceInCents == next.PriceInCents)
+	add("currency", current.Currency, next.Currency, current.Currency == next.Currency)
+	add("quantity", current.Quantity, next.Quantity, current.Quantity == next.Quantity)
+	add("unit", current.Unit, next.Unit, current.Unit == next.Unit)
+	add("category_id", current.CategoryID, next.CategoryID, reflect.DeepEqual(current.CategoryID, next.CategoryID))
+	add("barcodes", current.Barcodes, next.Barcodes, slices.Equal(current.Barcodes, next.Barcodes))
+	add("tags", current.Tags, next.Tags, slices.Equal(current.Tags, next.Tags))
+	add("track_lots", current.TrackLots, next.TrackLots, current.TrackLots == next.TrackLots)
+	add("serialized", current.Serialized, next.Serialized, current.Serialized == next.Serialized)
+	for _, key := range slices.Sorted(maps.Keys(next.Attributes)) {
+		from, had := current.Attributes[key]
+		add("attr."+key, from, next.Attributes[key], had && reflect.DeepEqual(from, next.Attributes[key]))
+	}
+	return changes
+}
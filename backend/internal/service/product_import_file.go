@@ -0,0 +1,277 @@
+package service
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"controle-de-estoque/backend/internal/domain"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// MaxImportRows limita as linhas de dados de um arquivo de importação.
+const MaxImportRows = 50000
+
+// importFields lista os campos do produto aceitos como colunas, além de attr.<chave>
+// para os atributos personalizados. Listas (barcodes, tags) usam "|" como separador.
+var importFields = []string{
+	"sku", "name", "description", "price_in_cents", "price", "currency", "quantity", "unit",
+	"category_id", "barcodes", "tags", "track_lots", "serialized",
+}
+
+// ImportListSeparator separa os itens das colunas de lista. Não pode ser "," nem ";",
+// que são os delimitadores comuns de CSV.
+const ImportListSeparator = "|"
+
+// validImportField informa se o campo pode ser mapeado a uma coluna.
+func validImportField(field string) bool {
+	if key, ok := strings.CutPrefix(field, "attr."); ok {
+		return ValidAttributeKey(key)
+	}
+	return slices.Contains(importFields, field)
+}
+
+// ImportFormatFromFileName identifica o formato do arquivo pela extensão.
+func ImportFormatFromFileName(name string) (domain.ImportFormat, error) {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv", ".txt":
+		return domain.ImportCSV, nil
+	case ".xlsx":
+		return domain.ImportXLSX, nil
+	}
+	return "", fmt.Errorf("%w: use um arquivo .csv ou .xlsx", domain.ErrInvalidImportFile)
+}
+
+// utf8BOM é a marca de ordem de bytes que o Excel grava no início dos CSV em UTF-8.
+var utf8BOM = []byte("\xef\xbb\xbf")
+
+// importLine é uma linha de dados do arquivo, com o número da linha contando o cabeçalho.
+type importLine struct {
+	number int
+	cells  []string
+}
+
+// importSheet é o conteúdo de um arquivo de importação: o cabeçalho e as linhas de
+// dados não vazias.
+type importSheet struct {
+	header []string
+	lines  []importLine
+}
+
+// parseImportFile lê o arquivo no formato informado. Em planilhas XLSX, apenas a
+// primeira aba é lida.
+func parseImportFile(format domain.ImportFormat, data []byte) (*importSheet, error) {
+	var records [][]string
+	var numbers []int
+	switch format {
+	case domain.ImportCSV:
+		reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, utf8BOM)))
+		reader.Comma = detectCSVDelimiter(data)
+		reader.FieldsPerRecord = -1
+		for {
+			record, err := reader.Read()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("%w: %v", domain.ErrInvalidImportFile, err)
+			}
+			line, _ := reader.FieldPos(0)
+			records = append(records, record)
+			numbers = append(numbers, line)
+		}
+	case domain.ImportXLSX:
+		f, err := excelize.OpenReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("%w: não foi possível abrir a planilha", domain.ErrInvalidImportFile)
+		}
+		defer func() { _ = f.Close() }()
+		sheets := f.GetSheetList()
+		if len(sheets) == 0 {
+			return nil, fmt.Errorf("%w: a planilha não possui abas", domain.ErrInvalidImportFile)
+		}
+		// Valores brutos, para que números não dependam da formatação das células.
+		if records, err = f.GetRows(sheets[0], excelize.Options{RawCellValue: true}); err != nil {
+			return nil, fmt.Errorf("%w: não foi possível ler a planilha", domain.ErrInvalidImportFile)
+		}
+		for i := range records {
+			numbers = append(numbers, i+1)
+		}
+	default:
+		return nil, fmt.Errorf("%w: formato %q não suportado", domain.ErrInvalidImportFile, format)
+	}
+
+	sheet := &importSheet{}
+	for i, record := range records {
+		if blankRecord(record) {
+			continue
+		}
+		if sheet.header == nil {
+			sheet.header = record
+			continue
+		}
+		sheet.lines = append(sheet.lines, importLine{number: numbers[i], cells: record})
+	}
+	if sheet.header == nil {
+		return nil, fmt.Errorf("%w: o arquivo está vazio", domain.ErrInvalidImportFile)
+	}
+	if len(sheet.lines) > MaxImportRows {
+		return nil, fmt.Errorf("%w: o arquivo excede %d linhas", domain.ErrInvalidImportFile, MaxImportRows)
+	}
+	return sheet, nil
+}
+
+// detectCSVDelimiter escolhe entre vírgula, ponto e vírgula e tabulação pelo que
+// mais aparece na primeira linha. Planilhas exportadas em português usam ";".
+func detectCSVDelimiter(data []byte) rune {
+	first, _, _ := bytes.Cut(data, []byte("\n"))
+	delimiter, best := ',', bytes.Count(first, []byte(","))
+	for _, candidate := range []rune{';', '\t'} {
+		if n := bytes.Count(first, []byte(string(candidate))); n > best {
+			delimiter, best = candidate, n
+		}
+	}
+	return delimiter
+}
+
+func blankRecord(record []string) bool {
+	for _, cell := range record {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// resolveImportColumns associa cada campo do produto à posição da sua coluna. Sem
+// mapeamento, os cabeçalhos com o nome de um campo (sem diferenciar maiúsculas) são
+// usados e os demais, ignorados. Com mapeamento (campo -> cabeçalho), apenas os campos
+// mapeados são importados. A coluna do SKU é obrigatória, pois identifica o produto.
+func resolveImportColumns(header []string, mapping map[string]string) (map[string]int, error) {
+	positions := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if _, dup := positions[name]; !dup && name != "" {
+			positions[name] = i
+		}
+	}
+
+	var v validator
+	columns := make(map[string]int)
+	if len(mapping) == 0 {
+		for name, i := range positions {
+			if validImportField(name) {
+				columns[name] = i
+			}
+		}
+	}
+	for field, column := range mapping {
+		if !validImportField(field) {
+			v.add("mapping."+field, domain.CodeUnknownField, "campo de produto desconhecido")
+			continue
+		}
+		i, ok := positions[strings.ToLower(strings.TrimSpace(column))]
+		if !ok {
+			v.add("mapping."+field, domain.CodeInvalid, fmt.Sprintf("coluna %q não encontrada no cabeçalho", column))
+			continue
+		}
+		columns[field] = i
+	}
+	if err := v.err(domain.ErrInvalidImportFile); err != nil {
+		return nil, err
+	}
+
+	_, hasSKU := columns["sku"]
+	v.check(hasSKU, "mapping.sku", domain.CodeRequired, "o arquivo deve ter uma coluna de SKU")
+	_, hasPrice := columns["price"]
+	_, hasPriceInCents := columns["price_in_cents"]
+	v.check(!hasPrice || !hasPriceInCents, "mapping.price", domain.CodeMismatch, "use price ou price_in_cents, não ambos")
+	if err := v.err(domain.ErrInvalidImportFile); err != nil {
+		return nil, err
+	}
+	return columns, nil
+}
+
+// importCells dá acesso às células de uma linha pelo nome do campo.
+type importCells struct {
+	columns map[string]int
+	cells   []string
+}
+
+// get retorna o valor do campo sem espaços nas pontas. Células vazias e campos sem
+// coluna são tratados da mesma forma: ok é falso e o campo não é alterado.
+func (c importCells) get(field string) (value string, ok bool) {
+	i, mapped := c.columns[field]
+	if !mapped || i >= len(c.cells) {
+		return "", false
+	}
+	value = strings.TrimSpace(c.cells[i])
+	return value, value != ""
+}
+
+// parseImportList separa os itens de uma coluna de lista.
+func parseImportList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ImportListSeparator) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseImportBool aceita true/false, sim/não, yes/no e 1/0.
+func parseImportBool(value string) (bool, bool) {
+	switch strings.ToLower(value) {
+	case "true", "sim", "s", "yes", "y", "1":
+		return true, true
+	case "false", "não", "nao", "n", "no", "0":
+		return false, true
+	}
+	return false, false
+}
+
+// parsePriceInCents converte um preço decimal ("12,50", "1.234,56", "R$ 9.90") na menor
+// unidade da moeda, que tem digits casas decimais, sem passar por ponto flutuante. Quando
+// há vírgula e ponto, o último é o separador decimal e o outro, de milhar.
+func parsePriceInCents(value string, digits int) (int64, bool) {
+	value = strings.TrimSpace(strings.TrimPrefix(strings.ToUpper(value), "R$"))
+	value, negative := strings.CutPrefix(value, "-")
+	integer, fraction := value, ""
+	if separator := strings.LastIndexAny(value, ".,"); separator >= 0 {
+		integer, fraction = value[:separator], value[separator+1:]
+		thousands := ","
+		if value[separator] == ',' {
+			thousands = "."
+		}
+		integer = strings.ReplaceAll(integer, thousands, "")
+	}
+	if !digitsOnly(integer) || len(fraction) > digits || (fraction != "" && !digitsOnly(fraction)) {
+		return 0, false
+	}
+	units, err := strconv.ParseInt(integer, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	scale := int64(1)
+	for range digits {
+		scale *= 10
+	}
+	cents, _ := strconv.ParseInt(fraction+strings.Repeat("0", digits-len(fraction)), 10, 64)
+	cents += units * scale
+	if negative {
+		cents = -cents
+	}
+	return cents, true
+}
+
+func digitsOnly(s string) bool {
+	return s != "" && strings.Trim(s, "0123456789") == ""
+}
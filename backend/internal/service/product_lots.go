@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"controle-de-estoque/backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// ILotRepository define a interface para o repositório de lotes.
+type ILotRepository interface {
+	ListByProduct(ctx context.Context, productID uuid.UUID) ([]domain.Lot, error)
+	Receive(ctx context.Context, tx pgx.Tx, lot *domain.Lot) error
+	GetByBatchForUpdate(ctx context.Context, tx pgx.Tx, productID uuid.UUID, batchNumber string) (*domain.Lot, error)
+	ListAvailableForUpdate(ctx context.Context, tx pgx.Tx, productID uuid.UUID) ([]domain.Lot, error)
+	UpdateQuantity(ctx context.Context, tx pgx.Tx, lotID uuid.UUID, newQuantity int) error
+	ListExpiring(ctx context.Context, cutoff time.Time) ([]domain.ExpiringLot, error)
+}
+
+// maxExpiringWindowDays limita a janela do relatório de lotes a vencer.
+const maxExpiringWindowDays = 3650
+
+// ListLots retorna os lotes de um produto em ordem FEFO.
+func (s *ProductService) ListLots(ctx context.Context, productID uuid.UUID) ([]domain.Lot, error) {
+	if _, err := s.repo.GetProductByID(ctx, productID); err != nil {
+		return nil, err
+	}
+	return s.lotRepo.ListByProduct(ctx, productID)
+}
+
+// ListExpiringLots retorna os lotes com saldo que vencem nos próximos `within` dias,
+// incluindo os já vencidos.
+func (s *ProductService) ListExpiringLots(ctx context.Context, within int) ([]domain.ExpiringLot, error) {
+	if within < 0 || within > maxExpiringWindowDays {
+		return nil, fmt.Errorf("%w: a janela deve estar entre 0 e %d dias", domain.ErrInvalidLot, maxExpiringWindowDays)
+	}
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	return s.lotRepo.ListExpiring(ctx, today.AddDate(0, 0, within))
+}
+
+// ParseDaysWindow interpreta janelas como "30d" ou "30" em número de dias.
+func ParseDaysWindow(value string) (int, error) {
+	days, err := strconv.Atoi(strings.TrimSuffix(strings.TrimSpace(value), "d"))
+	if err != nil {
+		return 0, fmt.Errorf("%w: janela %q deve estar no formato <dias>d, ex.: 30d", domain.ErrInvalidLot, value)
+	}
+	return days, nil
+}
+
+// parseExpiryDate converte uma validade no formato AAAA-MM-DD; vazio significa sem validade.
+func parseExpiryDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	date, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: validade %q deve estar no formato AAAA-MM-DD", domain.ErrInvalidLot, value)
+	}
+	return &date, nil
+}
+
+// applyLotMovement reflete uma entrada, ajuste ou devolução no lote indicado, para
+// produtos com controle de lote. Retorna a alocação registrada na movimentação.
+func (s *ProductService) applyLotMovement(ctx context.Context, tx pgx.Tx, product *domain.Produto, in movementInput, baseQuantity int) ([]domain.LotAllocation, error) {
+	batch := strings.TrimSpace(in.BatchNumber)
+	if !product.TrackLots {
+		if batch != "" || in.ExpiryDate != nil {
+			return nil, fmt.Errorf("%w: o produto não possui controle de lote", domain.ErrInvalidLot)
+		}
+		return nil, nil
+	}
+	if batch == "" {
+		return nil, fmt.Errorf("%w: o número do lote é obrigatório para este produto", domain.ErrInvalidLot)
+	}
+
+	var lot *domain.Lot
+	if in.Type == domain.MovementReceipt {
+		lot = &domain.Lot{ProductID: product.ID, BatchNumber: batch, ExpiryDate: in.ExpiryDate, Quantity: baseQuantity}
+		if err := s.lotRepo.Receive(ctx, tx, lot); err != nil {
+			return nil, err
+		}
+	} else {
+		var err error
+		if lot, err = s.lotRepo.GetByBatchForUpdate(ctx, tx, product.ID, batch); err != nil {
+			return nil, err
+		}
+		if lot.Quantity+baseQuantity < 0 {
+			return nil, fmt.Errorf("%w no lote %s: disponível %d, solicitado %d", domain.ErrInsufficientStock, batch, lot.Quantity, -baseQuantity)
+		}
+		if err := s.lotRepo.UpdateQuantity(ctx, tx, lot.ID, lot.Quantity+baseQuantity); err != nil {
+			return nil, err
+		}
+	}
+
+	return []domain.LotAllocation{{
+		LotID:       lot.ID,
+		BatchNumber: lot.BatchNumber,
+		ExpiryDate:  lot.ExpiryDate,
+		Quantity:    baseQuantity,
+	}}, nil
+}
+
+// allocateFEFO consome a quantidade dos lotes disponíveis, do que vence primeiro ao
+// que vence por último. Lotes vencidos não são considerados.
+func (s *ProductService) allocateFEFO(ctx context.Context, tx pgx.Tx, productID uuid.UUID, quantity int) ([]domain.LotAllocation, error) {
+	lots, err := s.lotRepo.ListAvailableForUpdate(ctx, tx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	var allocations []domain.LotAllocation
+	remaining := quantity
+	for _, lot := range lots {
+		if remaining == 0 {
+			break
+		}
+		take := min(lot.Quantity, remaining)
+		if err := s.lotRepo.UpdateQuantity(ctx, tx, lot.ID, lot.Quantity-take); err != nil {
+			return nil, err
+		}
+		allocations = append(allocations, domain.LotAllocation{
+			LotID:       lot.ID,
+			BatchNumber: lot.BatchNumber,
+			ExpiryDate:  lot.ExpiryDate,
+			Quantity:    take,
+		})
+		remaining -= take
+	}
+
+	if remaining > 0 {
+		return nil, fmt.Errorf("%w em lotes válidos: disponível %d, solicitado %d", domain.ErrInsufficientStock, quantity-remaining, quantity)
+	}
+	return allocations, nil
+}
@@ -0,0 +1,318 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"controle-de-estoque/backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	// maxPackagingCodeLength limita o tamanho do código de uma embalagem (ex.: "CX", "PALETE").
+	maxPackagingCodeLength = 20
+	// maxMovementReasonLength limita o tamanho do motivo registrado em uma movimentação.
+	maxMovementReasonLength = 500
+)
+
+// ListPackagings retorna as embalagens cadastradas para um produto.
+func (s *ProductService) ListPackagings(ctx context.Context, productID uuid.UUID) ([]domain.Packaging, error) {
+	if _, err := s.repo.GetProductByID(ctx, productID); err != nil {
+		return nil, err
+	}
+	return s.packagingRepo.ListByProduct(ctx, productID)
+}
+
+// ReplacePackagings substitui as embalagens de um produto pelas informadas.
+// A unidade base do produto é implícita (fator 1) e não precisa ser cadastrada.
+func (s *ProductService) ReplacePackagings(ctx context.Context, productID uuid.UUID, packagings []domain.Packaging) ([]domain.Packaging, error) {
+	product, err := s.repo.GetProductByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	var v validator
+	seen := make(map[string]struct{}, len(packagings))
+	for i := range packagings {
+		p := &packagings[i]
+		p.ProductID = productID
+		p.Code = strings.ToUpper(strings.TrimSpace(p.Code))
+		p.Name = strings.TrimSpace(p.Name)
+
+		code, factor := indexed("", i, "code"), indexed("", i, "factor")
+		v.check(p.Code != "", code, domain.CodeRequired, "o código é obrigatório")
+		v.maxLength(code, p.Code, maxPackagingCodeLength)
+		v.check(p.Factor >= 1, factor, domain.CodeOutOfRange, fmt.Sprintf("o fator de %q deve ser maior que zero", p.Code))
+		v.check(p.Code != string(product.Unit) || p.Factor == 1, factor, domain.CodeInvalid,
+			fmt.Sprintf("%q é a unidade base do produto e deve ter fator 1", p.Code))
+		if _, dup := seen[p.Code]; dup {
+			v.add(code, domain.CodeDuplicate, fmt.Sprintf("código %q duplicado", p.Code))
+		}
+		seen[p.Code] = struct{}{}
+	}
+	if err := v.err(domain.ErrInvalidPackaging); err != nil {
+		return nil, err
+	}
+
+	if err := s.packagingRepo.ReplaceForProduct(ctx, productID, packagings); err != nil {
+		return nil, err
+	}
+	return s.packagingRepo.ListByProduct(ctx, productID)
+}
+
+// toBaseUnits converte uma quantidade expressa em uma embalagem para a unidade base do produto.
+// Um código vazio ou igual à unidade do produto corresponde à própria unidade base.
+func (s *ProductService) toBaseUnits(ctx context.Context, tx pgx.Tx, product *domain.Produto, code string, quantity int) (domain.Packaging, int, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" || code == string(product.Unit) {
+		return domain.Packaging{ProductID: product.ID, Code: string(product.Unit), Factor: 1}, quantity, nil
+	}
+
+	packaging, err := s.packagingRepo.GetPackaging(ctx, tx, product.ID, code)
+	if err != nil {
+		return domain.Packaging{}, 0, err
+	}
+
+	// As quantidades são gravadas como INTEGER no banco.
+	if quantity > math.MaxInt32/packaging.Factor || quantity < math.MinInt32/packaging.Factor {
+		return domain.Packaging{}, 0, fmt.Errorf("%w: %d %s excede o limite suportado", domain.ErrInvalidQuantity, quantity, code)
+	}
+	return *packaging, quantity * packaging.Factor, nil
+}
+
+// ReceiveStockRequest representa uma entrada de mercadoria no estoque global.
+// Para produtos com controle de lote, BatchNumber é obrigatório e ExpiryDate (AAAA-MM-DD)
+// registra a validade do lote. Para produtos serializados, Serials lista os números de
+// série recebidos, um por unidade base. UnitCostInCents é o custo de cada unidade base;
+// se omitido, a entrada é valorizada pelo custo médio atual.
+type ReceiveStockRequest struct {
+	Quantity        int      `json:"quantity"`
+	Packaging       string   `json:"packaging,omitempty"`
+	Reason          string   `json:"reason,omitempty"`
+	BatchNumber     string   `json:"batchNumber,omitempty"`
+	ExpiryDate      string   `json:"expiryDate,omitempty"`
+	Serials         []string `json:"serials,omitempty"`
+	UnitCostInCents *int64   `json:"unitCostInCents,omitempty"`
+}
+
+// ReceiveStock registra o recebimento de mercadoria, somando ao estoque global.
+func (s *ProductService) ReceiveStock(ctx context.Context, productID uuid.UUID, req ReceiveStockRequest) (*domain.StockMovement, error) {
+	var v validator
+	v.check(req.Quantity > 0, "quantity", domain.CodeOutOfRange, "a quantidade recebida deve ser positiva")
+	v.maxLength("reason", req.Reason, maxMovementReasonLength)
+	v.check(req.UnitCostInCents == nil || *req.UnitCostInCents >= 0, "unitCostInCents", domain.CodeOutOfRange, "o custo unitário não pode ser negativo")
+	expiry, err := parseExpiryDate(req.ExpiryDate)
+	if err != nil {
+		v.addErr("expiryDate", domain.CodeInvalid, err)
+	}
+	if err := v.err(domain.ErrInvalidQuantity); err != nil {
+		return nil, err
+	}
+	return s.applyMovement(ctx, productID, movementInput{
+		Type:        domain.MovementReceipt,
+		Packaging:   req.Packaging,
+		Quantity:    req.Quantity,
+		Reason:      req.Reason,
+		BatchNumber: req.BatchNumber,
+		ExpiryDate:  expiry,
+		Serials:     req.Serials,
+		UnitCost:    req.UnitCostInCents,
+	})
+}
+
+// AdjustStockRequest representa um ajuste manual do estoque global.
+// Quantity pode ser negativa para baixas (perdas, avarias, divergências de inventário).
+// Para produtos com controle de lote, BatchNumber indica o lote ajustado. Para produtos
+// serializados, Serials lista os itens baixados (quantidade negativa) ou encontrados
+// (quantidade positiva). UnitCostInCents valoriza os ajustes positivos, como no recebimento;
+// os negativos saem pelo custo do estoque.
+type AdjustStockRequest struct {
+	Quantity        int      `json:"quantity"`
+	Packaging       string   `json:"packaging,omitempty"`
+	Reason          string   `json:"reason"`
+	BatchNumber     string   `json:"batchNumber,omitempty"`
+	Serials         []string `json:"serials,omitempty"`
+	UnitCostInCents *int64   `json:"unitCostInCents,omitempty"`
+}
+
+// AdjustStock aplica um ajuste ao estoque global. O motivo é obrigatório para auditoria.
+func (s *ProductService) AdjustStock(ctx context.Context, productID uuid.UUID, req AdjustStockRequest) (*domain.StockMovement, error) {
+	var v validator
+	v.check(req.Quantity != 0, "quantity", domain.CodeOutOfRange, "a quantidade do ajuste não pode ser zero")
+	v.check(strings.TrimSpace(req.Reason) != "", "reason", domain.CodeRequired, "o motivo do ajuste é obrigatório")
+	v.maxLength("reason", req.Reason, maxMovementReasonLength)
+	if req.UnitCostInCents != nil {
+		v.check(*req.UnitCostInCents >= 0, "unitCostInCents", domain.CodeOutOfRange, "o custo unitário não pode ser negativo")
+		v.check(req.Quantity > 0, "unitCostInCents", domain.CodeInvalid, "o custo unitário só se aplica a ajustes positivos")
+	}
+	if err := v.err(domain.ErrInvalidQuantity); err != nil {
+		return nil, err
+	}
+	return s.applyMovement(ctx, productID, movementInput{
+		Type:        domain.MovementAdjustment,
+		Packaging:   req.Packaging,
+		Quantity:    req.Quantity,
+		Reason:      req.Reason,
+		BatchNumber: req.BatchNumber,
+		Serials:     req.Serials,
+		UnitCost:    req.UnitCostInCents,
+	})
+}
+
+// ReturnStockRequest representa a devolução de mercadoria de um cliente ao estoque global.
+// Para produtos com controle de lote, BatchNumber indica o lote devolvido; para produtos
+// serializados, Serials lista os itens devolvidos.
+type ReturnStockRequest struct {
+	ClientID    uuid.UUID `json:"clientId"`
+	Quantity    int       `json:"quantity"`
+	Packaging   string    `json:"packaging,omitempty"`
+	Reason      string    `json:"reason,omitempty"`
+	BatchNumber string    `json:"batchNumber,omitempty"`
+	Serials     []string  `json:"serials,omitempty"`
+}
+
+// ReturnStock retira a quantidade do estoque do cliente e a devolve ao estoque global.
+func (s *ProductService) ReturnStock(ctx context.Context, productID uuid.UUID, req ReturnStockRequest) (*domain.StockMovement, error) {
+	var v validator
+	v.check(req.Quantity > 0, "quantity", domain.CodeOutOfRange, "a quantidade devolvida deve ser positiva")
+	v.check(req.ClientID != uuid.Nil, "clientId", domain.CodeRequired, "o cliente da devolução é obrigatório")
+	v.maxLength("reason", req.Reason, maxMovementReasonLength)
+	if err := v.err(domain.ErrInvalidQuantity); err != nil {
+		return nil, err
+	}
+	clientID := req.ClientID
+	return s.applyMovement(ctx, productID, movementInput{
+		Type:        domain.MovementReturn,
+		ClientID:    &clientID,
+		Packaging:   req.Packaging,
+		Quantity:    req.Quantity,
+		Reason:      req.Reason,
+		BatchNumber: req.BatchNumber,
+		Serials:     req.Serials,
+	})
+}
+
+// movementInput reúne os dados de uma entrada, ajuste ou devolução a ser aplicado por applyMovement.
+type movementInput struct {
+	Type        domain.MovementType
+	ClientID    *uuid.UUID // Apenas em devoluções
+	Packaging   string
+	Quantity    int
+	Reason      string
+	BatchNumber string
+	ExpiryDate  *time.Time
+	Serials     []string
+	UnitCost    *int64 // Custo por unidade base das entradas; nulo usa o custo médio
+}
+
+// applyMovement altera o estoque global em uma transação e registra a movimentação correspondente.
+// Com uma chave de idempotência no contexto, a resposta é gravada na mesma transação.
+func (s *ProductService) applyMovement(ctx context.Context, productID uuid.UUID, in movementInput) (*domain.StockMovement, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao iniciar transação: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx) // rollback silencioso caso não tenha commit
+	}()
+
+	if err := s.idempotency.guard(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	product, err := s.repo.GetProductForUpdate(ctx, tx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if product.HasVariants() {
+		return nil, domain.ErrProductHasVariants
+	}
+
+	packaging, baseQuantity, err := s.toBaseUnits(ctx, tx, product, in.Packaging, in.Quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	newQuantity := product.Quantity + baseQuantity
+	if newQuantity < 0 {
+		return nil, fmt.Errorf("%w: disponível %d, solicitado %d", domain.ErrInsufficientStock, product.Quantity, -baseQuantity)
+	}
+	if newQuantity > math.MaxInt32 {
+		return nil, fmt.Errorf("%w: o estoque resultante excede o limite suportado", domain.ErrInvalidQuantity)
+	}
+
+	lots, err := s.applyLotMovement(ctx, tx, product, in, baseQuantity)
+	if err != nil {
+		return nil, err
+	}
+
+	// Devoluções saem do estoque do cliente, incluindo os lotes devolvidos.
+	if in.Type == domain.MovementReturn {
+		if err := s.stockRepo.Decrement(ctx, tx, *in.ClientID, productID, baseQuantity); err != nil {
+			return nil, err
+		}
+		for _, allocation := range lots {
+			if err := s.stockRepo.DecrementLot(ctx, tx, *in.ClientID, productID, allocation); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := s.repo.UpdateQuantity(ctx, tx, productID, newQuantity); err != nil {
+		return nil, err
+	}
+
+	// Devoluções desfazem as transferências ao cliente pelo custo e preço médios delas,
+	// na moeda de preço gravada nas transferências.
+	unitCost, unitPrice, currency := in.UnitCost, (*int64)(nil), product.Currency
+	if in.Type == domain.MovementReturn {
+		priceCurrency, quantity, cost, revenue, err := s.movementRepo.ClientCostBasis(ctx, tx, *in.ClientID, productID, product.Currency)
+		if err != nil {
+			return nil, err
+		}
+		if quantity > 0 && cost >= 0 && revenue >= 0 {
+			averageCost, averagePrice := mulDivRound(cost, 1, quantity), mulDivRound(revenue, 1, quantity)
+			unitCost, unitPrice, currency = &averageCost, &averagePrice, priceCurrency
+		}
+	}
+	cost, err := s.applyCost(ctx, tx, product, baseQuantity, unitCost)
+	if err != nil {
+		return nil, err
+	}
+
+	movement := &domain.StockMovement{
+		ProductID:         productID,
+		ClientID:          in.ClientID,
+		Type:              in.Type,
+		Packaging:         packaging.Code,
+		PackagingQuantity: in.Quantity,
+		PackagingFactor:   packaging.Factor,
+		Quantity:          baseQuantity,
+		Reason:            strings.TrimSpace(in.Reason),
+		CostInCents:       &cost,
+		UnitPriceInCents:  unitPrice,
+		Currency:          currency,
+		CostCurrency:      product.Currency,
+		Lots:              lots,
+	}
+	if err := s.movementRepo.Create(ctx, tx, movement); err != nil {
+		return nil, err
+	}
+
+	if err := s.applySerialMovement(ctx, tx, product, movement, in.Serials); err != nil {
+		return nil, err
+	}
+
+	if err := s.idempotency.record(ctx, tx, movement); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("erro ao confirmar transação: %w", err)
+	}
+	return movement, nil
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"controle-de-estoque/backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// ISerialRepository define a interface para o repositório de números de série.
+type ISerialRepository interface {
+	ListByProduct(ctx context.Context, productID uuid.UUID, page domain.PageRequest) ([]domain.SerialNumber, *int, string, error)
+	Register(ctx context.Context, tx pgx.Tx, productID uuid.UUID, serials []string, movementID uuid.UUID) ([]domain.SerialNumber, error)
+	ListForUpdate(ctx context.Context, tx pgx.Tx, productID uuid.UUID, serials []string) ([]domain.SerialNumber, error)
+	UpdateStatus(ctx context.Context, tx pgx.Tx, ids []uuid.UUID, status domain.SerialStatus, clientID *uuid.UUID, movementID uuid.UUID) error
+	GetHistory(ctx context.Context, serial string) (*domain.SerialHistory, error)
+}
+
+// maxSerialLength limita o tamanho de um número de série.
+const maxSerialLength = 100
+
+// ListSerials retorna uma página dos números de série de um produto.
+func (s *ProductService) ListSerials(ctx context.Context, productID uuid.UUID, page domain.PageRequest) (*domain.PaginatedResponse, error) {
+	if _, err := s.repo.GetProductByID(ctx, productID); err != nil {
+		return nil, err
+	}
+	serials, total, next, err := s.serialRepo.ListByProduct(ctx, productID, page)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.PaginatedResponse{Data: serials, Metadata: newPageMetadata(page, total, next)}, nil
+}
+
+// GetSerialHistory retorna um número de série com todo o seu histórico de localização.
+func (s *ProductService) GetSerialHistory(ctx context.Context, serial string) (*domain.SerialHistory, error) {
+	serial = strings.TrimSpace(serial)
+	if serial == "" {
+		return nil, domain.ErrSerialNotFound
+	}
+	return s.serialRepo.GetHistory(ctx, serial)
+}
+
+// normalizeSerials remove espaços e rejeita números vazios, longos demais ou repetidos.
+func normalizeSerials(serials []string) ([]string, error) {
+	normalized := make([]string, 0, len(serials))
+	seen := make(map[string]struct{}, len(serials))
+	for _, serial := range serials {
+		serial = strings.TrimSpace(serial)
+		if serial == "" || len(serial) > maxSerialLength {
+			return nil, fmt.Errorf("%w: cada número de série deve ter entre 1 e %d caracteres", domain.ErrInvalidSerials, maxSerialLength)
+		}
+		if _, dup := seen[serial]; dup {
+			return nil, fmt.Errorf("%w: %s informado mais de uma vez", domain.ErrInvalidSerials, serial)
+		}
+		seen[serial] = struct{}{}
+		normalized = append(normalized, serial)
+	}
+	return normalized, nil
+}
+
+// applySerialMovement reflete uma movimentação já registrada nos números de série do
+// produto. Para produtos serializados, a lista deve conter exatamente um número por
+// unidade base movimentada:
+//   - recebimentos e ajustes positivos cadastram novos itens no estoque;
+//   - ajustes negativos baixam itens disponíveis;
+//   - transferências entregam itens disponíveis ao cliente;
+//   - devoluções trazem de volta itens que estavam com o mesmo cliente.
+func (s *ProductService) applySerialMovement(ctx context.Context, tx pgx.Tx, product *domain.Produto, movement *domain.StockMovement, serials []string) error {
+	if !product.Serialized {
+		if len(serials) > 0 {
+			return fmt.Errorf("%w: o produto não possui controle de número de série", domain.ErrInvalidSerials)
+		}
+		return nil
+	}
+
+	serials, err := normalizeSerials(serials)
+	if err != nil {
+		return err
+	}
+	count := movement.Quantity
+	if count < 0 {
+		count = -count
+	}
+	if len(serials) != count {
+		return fmt.Errorf("%w: informe exatamente %d números de série, recebidos %d", domain.ErrInvalidSerials, count, len(serials))
+	}
+
+	switch {
+	case movement.Type == domain.MovementReceipt || (movement.Type == domain.MovementAdjustment && movement.Quantity > 0):
+		if _, err := s.serialRepo.Register(ctx, tx, product.ID, serials, movement.ID); err != nil {
+			return err
+		}
+	case movement.Type == domain.MovementAdjustment:
+		if err := s.moveSerials(ctx, tx, product.ID, serials, movement, nil, domain.SerialScrapped, nil); err != nil {
+			return err
+		}
+	case movement.Type == domain.MovementTransfer:
+		if err := s.moveSerials(ctx, tx, product.ID, serials, movement, nil, domain.SerialAtClient, movement.ClientID); err != nil {
+			return err
+		}
+	case movement.Type == domain.MovementReturn:
+		if err := s.moveSerials(ctx, tx, product.ID, serials, movement, movement.ClientID, domain.SerialReturned, nil); err != nil {
+			return err
+		}
+	}
+
+	movement.Serials = serials
+	return nil
+}
+
+// moveSerials bloqueia os itens informados, confere a situação atual de cada um e os
+// leva para a nova situação. Com from nil, os itens precisam estar no estoque global;
+// caso contrário, precisam estar com o cliente from.
+func (s *ProductService) moveSerials(ctx context.Context, tx pgx.Tx, productID uuid.UUID, serials []string, movement *domain.StockMovement, from *uuid.UUID, to domain.SerialStatus, clientID *uuid.UUID) error {
+	items, err := s.serialRepo.ListForUpdate(ctx, tx, productID, serials)
+	if err != nil {
+		return err
+	}
+
+	found := make(map[string]domain.SerialNumber, len(items))
+	for _, item := range items {
+		found[item.Serial] = item
+	}
+
+	ids := make([]uuid.UUID, 0, len(serials))
+	for _, serial := range serials {
+		item, ok := found[serial]
+		if !ok {
+			return fmt.Errorf("%w: %s", domain.ErrSerialNotFound, serial)
+		}
+		if from == nil && !item.Status.Available() {
+			return fmt.Errorf("%w: %s não está disponível no estoque (situação %s)", domain.ErrInvalidSerials, serial, item.Status)
+		}
+		if from != nil && (item.Status != domain.SerialAtClient || item.ClientID == nil || *item.ClientID != *from) {
+			return fmt.Errorf("%w: %s não está com este cliente", domain.ErrInvalidSerials, serial)
+		}
+		ids = append(ids, item.ID)
+	}
+
+	return s.serialRepo.UpdateStatus(ctx, tx, ids, to, clientID, movement.ID)
+}
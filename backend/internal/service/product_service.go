@@ -2,9 +2,10 @@ package service
 
 import (
 	"context"
-	"errors"
+	"encoding/json"
 	"fmt"
-	"math"
+	"slices"
+	"strings"
 
 	"controle-de-estoque/backend/internal/domain"
 
@@ -16,66 +17,190 @@ import (
 // IProductRepository define os métodos que o repositório de produtos deve implementar,
 // incluindo os métodos para uso dentro de transação.
 type IProductRepository interface {
-	CreateProduct(ctx context.Context, product *domain.Produto) error
-	ListProducts(ctx context.Context, search string, page, limit int) ([]domain.Produto, int, error)
+	ListProducts(ctx context.Context, filter domain.ProductFilter, page domain.PageRequest) ([]domain.Produto, *int, string, error)
+	ExportProducts(ctx context.Context, filter domain.ProductFilter, fn func(domain.Produto) error) error
 	GetProductByID(ctx context.Context, productID uuid.UUID) (domain.Produto, error)
-	UpdateProduct(ctx context.Context, product *domain.Produto) error
+	GetProductsByIDs(ctx context.Context, productIDs []uuid.UUID) ([]domain.Produto, error)
+	GetProductByBarcode(ctx context.Context, barcode string) (domain.Produto, error)
+	GetProductBySKU(ctx context.Context, sku string) (domain.Produto, error)
 	DeleteProduct(ctx context.Context, productID uuid.UUID) error
+	ListVariants(ctx context.Context, parentID uuid.UUID) ([]domain.Produto, error)
 
 	// Métodos para transação
+	CreateProduct(ctx context.Context, tx pgx.Tx, product *domain.Produto) error
 	GetProductForUpdate(ctx context.Context, tx pgx.Tx, productID uuid.UUID) (*domain.Produto, error)
+	UpdateProduct(ctx context.Context, tx pgx.Tx, product *domain.Produto) error
 	UpdateQuantity(ctx context.Context, tx pgx.Tx, productID uuid.UUID, newQuantity int) error
+	UpdateCost(ctx context.Context, tx pgx.Tx, productID uuid.UUID, stockValue, averageCost int64) error
+	CreateVariants(ctx context.Context, tx pgx.Tx, parentID uuid.UUID, axes []domain.VariantAxis, variants []domain.Produto) error
+}
+
+// IPackagingRepository define a interface para o repositório de embalagens de produtos.
+type IPackagingRepository interface {
+	ListByProduct(ctx context.Context, productID uuid.UUID) ([]domain.Packaging, error)
+	ReplaceForProduct(ctx context.Context, productID uuid.UUID, packagings []domain.Packaging) error
+	GetPackaging(ctx context.Context, tx pgx.Tx, productID uuid.UUID, code string) (*domain.Packaging, error)
+}
+
+// IStockMovementRepository define a interface para o histórico de movimentações de estoque.
+type IStockMovementRepository interface {
+	Create(ctx context.Context, tx pgx.Tx, movement *domain.StockMovement) error
+	ClientCostBasis(ctx context.Context, tx pgx.Tx, clientID, productID uuid.UUID, costCurrency domain.Currency) (currency domain.Currency, quantity, cost, revenue int64, err error)
 }
 
 // ProductService contém a lógica de negócio para produtos, incluindo transferências de estoque.
 type ProductService struct {
-	db        *pgxpool.Pool // Pool para iniciar transações
-	repo      IProductRepository
-	stockRepo IClientStockRepository
+	db            *pgxpool.Pool // Pool para iniciar transações
+	repo          IProductRepository
+	clientRepo    IClientRepository
+	stockRepo     IClientStockRepository
+	packagingRepo IPackagingRepository
+	movementRepo  IStockMovementRepository
+	attributeRepo IAttributeDefinitionRepository
+	lotRepo       ILotRepository
+	serialRepo    ISerialRepository
+	costRepo      ICostLayerRepository
+	idempotency   *IdempotencyService
 }
 
 // NewProductService cria uma instância de ProductService com as dependências necessárias.
-func NewProductService(db *pgxpool.Pool, repo IProductRepository, stockRepo IClientStockRepository) *ProductService {
+func NewProductService(
+	db *pgxpool.Pool,
+	repo IProductRepository,
+	clientRepo IClientRepository,
+	stockRepo IClientStockRepository,
+	packagingRepo IPackagingRepository,
+	movementRepo IStockMovementRepository,
+	attributeRepo IAttributeDefinitionRepository,
+	lotRepo ILotRepository,
+	serialRepo ISerialRepository,
+	costRepo ICostLayerRepository,
+	idempotency *IdempotencyService,
+) *ProductService {
 	return &ProductService{
-		db:        db,
-		repo:      repo,
-		stockRepo: stockRepo,
+		db:            db,
+		repo:          repo,
+		clientRepo:    clientRepo,
+		stockRepo:     stockRepo,
+		packagingRepo: packagingRepo,
+		movementRepo:  movementRepo,
+		attributeRepo: attributeRepo,
+		lotRepo:       lotRepo,
+		serialRepo:    serialRepo,
+		costRepo:      costRepo,
+		idempotency:   idempotency,
 	}
 }
 
-// CreateProduct cria um novo produto chamando o repositório.
+// CreateProduct cria um novo produto. Com uma chave de idempotência no contexto, a
+// resposta é gravada na mesma transação.
 func (s *ProductService) CreateProduct(ctx context.Context, product *domain.Produto) error {
-	return s.repo.CreateProduct(ctx, product)
-}
+	// Variantes são criadas apenas via GenerateVariants.
+	product.ParentID = nil
+	product.VariantAxes = nil
+	product.VariantOptions = nil
+	product.Variants = nil
+	product.StockValueInCents = 0
+	product.AverageCostInCents = 0
+
+	if err := validateProduct(product); err != nil {
+		return err
+	}
+	if err := checkInitialStock(*product); err != nil {
+		return err
+	}
+	if err := s.validateAttributes(ctx, product); err != nil {
+		return err
+	}
 
-// ListProducts busca produtos e retorna a resposta paginada.
-func (s *ProductService) ListProducts(ctx context.Context, search string, page, limit int) (*domain.PaginatedResponse, error) {
-	products, totalRecords, err := s.repo.ListProducts(ctx, search, page, limit)
+	tx, err := s.db.Begin(ctx)
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("erro ao iniciar transação: %w", err)
 	}
+	defer func() { _ = tx.Rollback(ctx) }()
 
-	totalPages := 0
-	if totalRecords > 0 {
-		totalPages = int(math.Ceil(float64(totalRecords) / float64(limit)))
+	if err := s.idempotency.guard(ctx, tx); err != nil {
+		return err
+	}
+	if err := s.repo.CreateProduct(ctx, tx, product); err != nil {
+		return err
+	}
+	if err := s.idempotency.record(ctx, tx, product); err != nil {
+		return err
 	}
 
-	metadata := domain.Metadata{
-		TotalRecords: totalRecords,
-		CurrentPage:  page,
-		PageSize:     limit,
-		TotalPages:   totalPages,
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("erro ao confirmar transação: %w", err)
+	}
+	return nil
+}
+
+// ListProducts busca uma página de produtos, com os filtros aplicados nos metadados.
+func (s *ProductService) ListProducts(ctx context.Context, filter domain.ProductFilter, page domain.PageRequest) (*domain.PaginatedResponse, error) {
+	if err := validateProductFilter(filter); err != nil {
+		return nil, err
 	}
 
+	products, total, next, err := s.repo.ListProducts(ctx, filter, page)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := newPageMetadata(page, total, next)
+	metadata.AppliedFilters = filter.Applied()
+
 	return &domain.PaginatedResponse{
 		Data:     products,
 		Metadata: metadata,
 	}, nil
 }
 
-// GetProductByID busca um produto pelo ID.
+// ExportProducts percorre todos os produtos que atendem ao filtro, na ordem da listagem,
+// chamando fn para cada um.
+func (s *ProductService) ExportProducts(ctx context.Context, filter domain.ProductFilter, fn func(domain.Produto) error) error {
+	if err := validateProductFilter(filter); err != nil {
+		return err
+	}
+	return s.repo.ExportProducts(ctx, filter, fn)
+}
+
+// validateProductFilter rejeita faixas invertidas ou negativas na listagem de produtos.
+func validateProductFilter(f domain.ProductFilter) error {
+	if (f.MinPriceInCents != nil && *f.MinPriceInCents < 0) || (f.MinQuantity != nil && *f.MinQuantity < 0) {
+		return fmt.Errorf("%w: os limites mínimos não podem ser negativos", domain.ErrInvalidFilter)
+	}
+	if f.MinPriceInCents != nil && f.MaxPriceInCents != nil && *f.MinPriceInCents > *f.MaxPriceInCents {
+		return fmt.Errorf("%w: min_price maior que max_price", domain.ErrInvalidFilter)
+	}
+	if f.MinQuantity != nil && f.MaxQuantity != nil && *f.MinQuantity > *f.MaxQuantity {
+		return fmt.Errorf("%w: min_qty maior que max_qty", domain.ErrInvalidFilter)
+	}
+	for _, field := range f.Sort {
+		if !slices.Contains(domain.ProductSortFields, field.Field) {
+			return fmt.Errorf("%w: campo de ordenação %q", domain.ErrInvalidFilter, field.Field)
+		}
+	}
+	return nil
+}
+
+// GetProductByID busca um produto pelo ID. Para produtos pai, as variantes são incluídas na resposta.
 func (s *ProductService) GetProductByID(ctx context.Context, productID uuid.UUID) (domain.Produto, error) {
-	return s.repo.GetProductByID(ctx, productID)
+	product, err := s.repo.GetProductByID(ctx, productID)
+	if err != nil {
+		return domain.Produto{}, err
+	}
+	if product.HasVariants() {
+		if product.Variants, err = s.repo.ListVariants(ctx, productID); err != nil {
+			return domain.Produto{}, err
+		}
+	}
+	return product, nil
+}
+
+// GetProductsByIDs busca vários produtos de uma vez, em qualquer ordem. IDs inexistentes
+// são ignorados.
+func (s *ProductService) GetProductsByIDs(ctx context.Context, productIDs []uuid.UUID) ([]domain.Produto, error) {
+	return s.repo.GetProductsByIDs(ctx, productIDs)
 }
 
 // UpdateProduct atualiza um produto.
@@ -85,76 +210,393 @@ func (s *ProductService) UpdateProduct(ctx context.Context, productID uuid.UUID,
 		return nil, err
 	}
 
+	if input.CostingMethod == "" {
+		input.CostingMethod = product.CostingMethod
+	}
+	if input.Currency == "" || product.ParentID != nil {
+		input.Currency = product.Currency
+	}
+	if err := checkTrackingChange(product, input); err != nil {
+		return nil, err
+	}
+
+	product.SKU = input.SKU
+	product.CategoryID = input.CategoryID
 	product.Name = input.Name
 	product.Description = input.Description
 	product.PriceInCents = input.PriceInCents
 	product.Quantity = input.Quantity
+	product.Unit = input.Unit
+	product.Barcodes = input.Barcodes
+	product.Attributes = input.Attributes
+	product.Tags = input.Tags
+	product.PriceOverrideInCents = input.PriceOverrideInCents
+	product.TrackLots = input.TrackLots
+	product.Serialized = input.Serialized
+	product.CostingMethod = input.CostingMethod
+	product.Currency = input.Currency
 
-	if err := s.repo.UpdateProduct(ctx, &product); err != nil {
+	if err := validateProduct(&product); err != nil {
+		return nil, err
+	}
+	if err := s.validateAttributes(ctx, &product); err != nil {
+		return nil, err
+	}
+
+	if err := s.saveProduct(ctx, &product); err != nil {
 		return nil, err
 	}
 
 	return &product, nil
 }
 
+// PatchProduct aplica um JSON Merge Patch (RFC 7396) sobre um produto existente.
+// Apenas os campos presentes no patch são alterados; o resultado mesclado é validado
+// antes de ser persistido.
+func (s *ProductService) PatchProduct(ctx context.Context, productID uuid.UUID, patch []byte) (*domain.Produto, error) {
+	product, err := s.repo.GetProductByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	original, err := json.Marshal(product)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao serializar produto: %w", err)
+	}
+	merged, err := applyMergePatch(original, patch)
+	if err != nil {
+		return nil, err
+	}
+
+	var patched domain.Produto
+	if err := decodeMerged(merged, &patched); err != nil {
+		return nil, err
+	}
+
+	// Campos controlados pelo servidor não podem ser alterados pelo patch.
+	patched.ID = product.ID
+	patched.ParentID = product.ParentID
+	patched.VariantAxes = product.VariantAxes
+	patched.VariantOptions = product.VariantOptions
+	patched.Variants = nil
+	patched.CreatedAt = product.CreatedAt
+	patched.UpdatedAt = product.UpdatedAt
+	if patched.CostingMethod == "" {
+		patched.CostingMethod = product.CostingMethod
+	}
+	if patched.Currency == "" || product.ParentID != nil {
+		patched.Currency = product.Currency
+	}
+
+	if err := checkTrackingChange(product, patched); err != nil {
+		return nil, err
+	}
+
+	if err := validateProduct(&patched); err != nil {
+		return nil, err
+	}
+	if err := s.validateAttributes(ctx, &patched); err != nil {
+		return nil, err
+	}
+
+	if err := s.saveProduct(ctx, &patched); err != nil {
+		return nil, err
+	}
+
+	return &patched, nil
+}
+
+// saveProduct grava a edição de um produto em uma transação. Uma alteração da quantidade
+// é registrada como ajuste, valorizado pelo custo médio, para que o histórico de
+// movimentações continue reconstituindo o estoque; ao trocar o método de custeio, com o
+// estoque zerado, as camadas PEPS são descartadas.
+func (s *ProductService) saveProduct(ctx context.Context, product *domain.Produto) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("erro ao iniciar transação: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	current, err := s.repo.GetProductForUpdate(ctx, tx, product.ID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.UpdateProduct(ctx, tx, product); err != nil {
+		return err
+	}
+	if current.CostingMethod != product.CostingMethod {
+		if err := s.costRepo.DeleteLayers(ctx, tx, product.ID); err != nil {
+			return err
+		}
+		current.CostingMethod = product.CostingMethod
+	}
+
+	if delta := product.Quantity - current.Quantity; delta != 0 && !current.HasVariants() {
+		cost, err := s.applyCost(ctx, tx, current, delta, nil)
+		if err != nil {
+			return err
+		}
+		movement := &domain.StockMovement{
+			ProductID:         product.ID,
+			Type:              domain.MovementAdjustment,
+			Packaging:         string(product.Unit),
+			PackagingQuantity: delta,
+			PackagingFactor:   1,
+			Quantity:          delta,
+			Reason:            "edição do cadastro do produto",
+			CostInCents:       &cost,
+			Currency:          current.Currency,
+			CostCurrency:      current.Currency,
+		}
+		if err := s.movementRepo.Create(ctx, tx, movement); err != nil {
+			return err
+		}
+	}
+	product.StockValueInCents = current.StockValueInCents
+	product.AverageCostInCents = current.AverageCostInCents
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("erro ao confirmar transação: %w", err)
+	}
+	return nil
+}
+
+// checkInitialStock impede que um produto com controle de lote ou número de série, ou
+// custeado por PEPS, seja criado com estoque, que nesse caso só entra por recebimentos.
+func checkInitialStock(p domain.Produto) error {
+	if (p.TrackLots || p.Serialized) && p.Quantity != 0 {
+		return fmt.Errorf("%w: produtos com controle de lote ou número de série recebem estoque apenas por recebimentos", domain.ErrInvalidProductData)
+	}
+	if p.CostingMethod == domain.CostingFIFO && p.Quantity != 0 {
+		return fmt.Errorf("%w: produtos custeados por PEPS recebem estoque apenas por recebimentos", domain.ErrInvalidProductData)
+	}
+	return nil
+}
+
+// checkTrackingChange preserva a invariante de que o estoque de um produto com
+// controle de lote ou número de série é a soma dos seus lotes ou itens: a quantidade
+// não pode ser editada diretamente e o controle só pode ser ativado ou desativado
+// com estoque zerado.
+func checkTrackingChange(current, next domain.Produto) error {
+	if current.TrackLots != next.TrackLots && current.Quantity != 0 {
+		return fmt.Errorf("%w: zere o estoque antes de alterar o controle de lote", domain.ErrInvalidProductData)
+	}
+	if current.Serialized != next.Serialized && current.Quantity != 0 {
+		return fmt.Errorf("%w: zere o estoque antes de alterar o controle de número de série", domain.ErrInvalidProductData)
+	}
+	tracked := (current.TrackLots && next.TrackLots) || (current.Serialized && next.Serialized)
+	if tracked && current.Quantity != next.Quantity {
+		return fmt.Errorf("%w: use recebimentos e ajustes para alterar o estoque de produtos com controle de lote ou número de série", domain.ErrInvalidProductData)
+	}
+	if current.CostingMethod != next.CostingMethod && current.Quantity != 0 {
+		return fmt.Errorf("%w: zere o estoque antes de alterar o método de custeio", domain.ErrInvalidProductData)
+	}
+	if !strings.EqualFold(string(current.Currency), string(next.Currency)) && current.Quantity != 0 {
+		return fmt.Errorf("%w: zere o estoque antes de alterar a moeda, que é também a do custo", domain.ErrInvalidProductData)
+	}
+	if next.CostingMethod == domain.CostingFIFO && current.Quantity != next.Quantity {
+		return fmt.Errorf("%w: use recebimentos e ajustes para alterar o estoque de produtos custeados por PEPS", domain.ErrInvalidProductData)
+	}
+	return nil
+}
+
+// Limites de tamanho dos campos de texto do produto.
+const (
+	maxProductNameLength        = 200
+	maxProductSKULength         = 64
+	maxProductDescriptionLength = 5000
+)
+
+// validateProduct normaliza SKU, unidade e códigos de barras e verifica as regras
+// de integridade de um produto, acumulando os erros por campo.
+func validateProduct(p *domain.Produto) error {
+	p.SKU = strings.ToUpper(strings.TrimSpace(p.SKU))
+	p.Unit = domain.UnitOfMeasure(strings.ToUpper(strings.TrimSpace(string(p.Unit))))
+	if p.Unit == "" {
+		p.Unit = domain.UnitPiece
+	}
+
+	var v validator
+	v.check(strings.TrimSpace(p.Name) != "", "name", domain.CodeRequired, "o nome é obrigatório")
+	v.maxLength("name", p.Name, maxProductNameLength)
+	v.maxLength("sku", p.SKU, maxProductSKULength)
+	v.maxLength("description", p.Description, maxProductDescriptionLength)
+	v.check(p.PriceInCents >= 0, "price_in_cents", domain.CodeOutOfRange, "o preço não pode ser negativo")
+	v.check(p.Quantity >= 0, "quantity", domain.CodeOutOfRange, "a quantidade não pode ser negativa")
+	if p.ParentID == nil {
+		// Apenas variantes podem substituir o preço herdado.
+		p.PriceOverrideInCents = nil
+	} else if p.PriceOverrideInCents != nil {
+		v.check(*p.PriceOverrideInCents >= 0, "price_override_in_cents", domain.CodeOutOfRange, "o preço da variante não pode ser negativo")
+	}
+	v.check(p.Unit.Valid(), "unit", domain.CodeInvalid, fmt.Sprintf("unidade de medida %q não suportada", p.Unit))
+	if p.CostingMethod == "" {
+		p.CostingMethod = domain.CostingAverage
+	}
+	p.Currency = domain.Currency(strings.ToUpper(strings.TrimSpace(string(p.Currency))))
+	if p.Currency == "" {
+		p.Currency = domain.DefaultCurrency
+	}
+	v.check(p.Currency.Valid(), "currency", domain.CodeInvalid, fmt.Sprintf("moeda %q não é um código ISO 4217 válido", p.Currency))
+	v.check(p.CostingMethod.Valid(), "costing_method", domain.CodeInvalid, fmt.Sprintf("método de custeio %q não suportado; use average ou fifo", p.CostingMethod))
+
+	p.Barcodes = normalizeBarcodes(&v, p.Barcodes)
+	return v.err(domain.ErrInvalidProductData)
+}
+
+// GetProductByBarcode busca o produto associado a um código de barras.
+func (s *ProductService) GetProductByBarcode(ctx context.Context, barcode string) (domain.Produto, error) {
+	barcode = strings.TrimSpace(barcode)
+	if err := ValidateBarcode(barcode); err != nil {
+		return domain.Produto{}, err
+	}
+	return s.repo.GetProductByBarcode(ctx, canonicalBarcode(barcode))
+}
+
 // DeleteProduct remove um produto pelo ID.
 func (s *ProductService) DeleteProduct(ctx context.Context, productID uuid.UUID) error {
 	return s.repo.DeleteProduct(ctx, productID)
 }
 
 // TransferStockRequest representa os dados para transferência de estoque a um cliente.
+// Quantity é expressa na embalagem indicada em Packaging; quando omitida, usa-se a unidade base.
 type TransferStockRequest struct {
-	ClientID uuid.UUID `json:"clientId"`
-	Quantity int       `json:"quantity"`
+	ClientID  uuid.UUID `json:"clientId"`
+	Quantity  int       `json:"quantity"`
+	Packaging string    `json:"packaging,omitempty"`
+	Serials   []string  `json:"serials,omitempty"`
 }
 
 // TransferStock realiza a transferência de estoque global para o estoque de um cliente,
 // garantindo atomicidade e consistência via transação.
-func (s *ProductService) TransferStock(ctx context.Context, productID uuid.UUID, req TransferStockRequest) error {
-	if req.Quantity <= 0 {
-		return errors.New("a quantidade a ser transferida deve ser positiva")
+func (s *ProductService) TransferStock(ctx context.Context, productID uuid.UUID, req TransferStockRequest) (*domain.StockMovement, error) {
+	var v validator
+	v.check(req.Quantity > 0, "quantity", domain.CodeOutOfRange, "a quantidade a ser transferida deve ser positiva")
+	v.check(req.ClientID != uuid.Nil, "clientId", domain.CodeRequired, "o cliente de destino é obrigatório")
+	if err := v.err(domain.ErrInvalidQuantity); err != nil {
+		return nil, err
 	}
 
 	// Inicia a transação
 	tx, err := s.db.Begin(ctx)
 	if err != nil {
-		return fmt.Errorf("erro ao iniciar transação: %w", err)
+		return nil, fmt.Errorf("erro ao iniciar transação: %w", err)
 	}
 	defer func() {
 		_ = tx.Rollback(ctx) // rollback silencioso caso não tenha commit
 	}()
 
-	// 1. Bloqueia o produto para update na transação
+	// 1. Serializa as tentativas com a mesma chave de idempotência, repetindo a resposta
+	//    de uma tentativa já concluída
+	if err := s.idempotency.guard(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	// 2. Bloqueia o produto para update na transação
 	product, err := s.repo.GetProductForUpdate(ctx, tx, productID)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	if product.HasVariants() {
+		return nil, domain.ErrProductHasVariants
+	}
+
+	// 3. Confere o cliente de destino, impedindo que seja bloqueado durante a transferência
+	client, err := s.clientRepo.GetClientForShare(ctx, tx, req.ClientID)
+	if err != nil {
+		return nil, err
+	}
+	if client.Status == domain.ClientBlocked {
+		return nil, fmt.Errorf("%w: %s não pode receber transferências", domain.ErrClientBlocked, client.Name)
 	}
 
-	// 2. Verifica estoque disponível
-	if product.Quantity < req.Quantity {
-		return fmt.Errorf("estoque insuficiente: disponível %d, solicitado %d", product.Quantity, req.Quantity)
+	// 4. Converte a quantidade solicitada para a unidade base
+	packaging, baseQuantity, err := s.toBaseUnits(ctx, tx, product, req.Packaging, req.Quantity)
+	if err != nil {
+		return nil, err
 	}
 
-	// 3. Atualiza estoque global
-	newQuantity := product.Quantity - req.Quantity
+	// 5. Verifica estoque disponível
+	if product.Quantity < baseQuantity {
+		return nil, fmt.Errorf("%w: disponível %d, solicitado %d", domain.ErrInsufficientStock, product.Quantity, baseQuantity)
+	}
+
+	// 6. Atualiza estoque global
+	newQuantity := product.Quantity - baseQuantity
 	if err := s.repo.UpdateQuantity(ctx, tx, productID, newQuantity); err != nil {
-		return err
+		return nil, err
 	}
 
-	// 4. Atualiza estoque do cliente (upsert)
+	// 7. Atualiza estoque do cliente (upsert)
 	clientStock := &domain.ClientStock{
 		ClientID:  req.ClientID,
 		ProductID: productID,
-		Quantity:  req.Quantity,
+		Quantity:  baseQuantity,
 	}
 	if err := s.stockRepo.Upsert(ctx, tx, clientStock); err != nil {
-		return err
+		return nil, err
+	}
+
+	// 8. Para produtos com controle de lote, aloca os lotes em ordem FEFO e
+	//    registra no estoque do cliente quais lotes foram entregues.
+	var lots []domain.LotAllocation
+	if product.TrackLots {
+		if lots, err = s.allocateFEFO(ctx, tx, productID, baseQuantity); err != nil {
+			return nil, err
+		}
+		for _, allocation := range lots {
+			if err := s.stockRepo.UpsertLot(ctx, tx, req.ClientID, productID, allocation); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// 9. Baixa o custo do estoque global; com o preço efetivo para o cliente (o da tabela
+	//    de preços vigente ou o de cadastro), compõe a margem
+	cost, err := s.applyCost(ctx, tx, product, -baseQuantity, nil)
+	if err != nil {
+		return nil, err
+	}
+	price, currency, err := s.stockRepo.EffectivePrice(ctx, tx, req.ClientID, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 10. Registra a movimentação
+	clientID := req.ClientID
+	movement := &domain.StockMovement{
+		ProductID:         productID,
+		ClientID:          &clientID,
+		Type:              domain.MovementTransfer,
+		Packaging:         packaging.Code,
+		PackagingQuantity: req.Quantity,
+		PackagingFactor:   packaging.Factor,
+		Quantity:          -baseQuantity,
+		CostInCents:       &cost,
+		UnitPriceInCents:  &price,
+		Currency:          currency,
+		CostCurrency:      product.Currency,
+		Lots:              lots,
+	}
+	if err := s.movementRepo.Create(ctx, tx, movement); err != nil {
+		return nil, err
+	}
+
+	// 11. Para produtos serializados, marca os itens informados como entregues ao cliente
+	if err := s.applySerialMovement(ctx, tx, product, movement, req.Serials); err != nil {
+		return nil, err
 	}
 
-	// 5. Commit da transação
+	// 12. Grava a resposta para a chave de idempotência
+	if err := s.idempotency.record(ctx, tx, movement); err != nil {
+		return nil, err
+	}
+
+	// 13. Commit da transação
 	if err := tx.Commit(ctx); err != nil {
-		return fmt.Errorf("erro ao confirmar transação: %w", err)
+		return nil, fmt.Errorf("erro ao confirmar transação: %w", err)
 	}
 
-	return nil
+	return movement, nil
 }
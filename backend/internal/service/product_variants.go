@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"controle-de-estoque/backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// maxVariantsPerProduct limita o tamanho da matriz gerada a partir dos eixos.
+const maxVariantsPerProduct = 500
+
+// GenerateVariantsRequest define os eixos de variação de um produto pai.
+type GenerateVariantsRequest struct {
+	Axes []domain.VariantAxis `json:"axes"`
+}
+
+// ListVariants retorna as variantes de um produto pai.
+func (s *ProductService) ListVariants(ctx context.Context, parentID uuid.UUID) ([]domain.Produto, error) {
+	if _, err := s.repo.GetProductByID(ctx, parentID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListVariants(ctx, parentID)
+}
+
+// GenerateVariants define os eixos de variação do produto e cria uma variante para
+// cada combinação ainda inexistente. Depois que as primeiras variantes existem, os
+// eixos só podem receber novos valores, para não deixar variantes (e seu estoque) órfãs.
+// O produto pai fica bloqueado até o fim, para que nenhuma entrada de estoque ou outra
+// geração concorrente altere o saldo ou os eixos verificados.
+func (s *ProductService) GenerateVariants(ctx context.Context, parentID uuid.UUID, req GenerateVariantsRequest) ([]domain.Produto, error) {
+	axes, err := normalizeVariantAxes(req.Axes)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao iniciar transação: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	locked, err := s.repo.GetProductForUpdate(ctx, tx, parentID)
+	if err != nil {
+		return nil, err
+	}
+	if locked.ParentID != nil {
+		return nil, fmt.Errorf("%w: uma variante não pode ter variantes próprias", domain.ErrInvalidVariants)
+	}
+	if !locked.HasVariants() && locked.Quantity > 0 {
+		return nil, fmt.Errorf("%w: zere o estoque do produto antes de convertê-lo em produto com variantes", domain.ErrInvalidVariants)
+	}
+	if locked.HasVariants() {
+		if err := checkAxesExtend(locked.VariantAxes, axes); err != nil {
+			return nil, err
+		}
+	}
+
+	// Os demais dados do pai, copiados para as variantes, são lidos após o bloqueio.
+	parent, err := s.repo.GetProductByID(ctx, parentID)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := s.repo.ListVariants(ctx, parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateVariants(ctx, tx, parentID, axes, missingVariants(parent, axes, existing)); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("erro ao confirmar transação: %w", err)
+	}
+	return s.repo.ListVariants(ctx, parentID)
+}
+
+// missingVariants monta as variantes do pai para as combinações dos eixos que ainda não
+// existem entre as variantes informadas.
+func missingVariants(parent domain.Produto, axes []domain.VariantAxis, existing []domain.Produto) []domain.Produto {
+	existingKeys := make(map[string]struct{}, len(existing))
+	for _, v := range existing {
+		existingKeys[variantKey(axes, v.VariantOptions)] = struct{}{}
+	}
+
+	var newVariants []domain.Produto
+	for _, options := range variantCombinations(axes) {
+		if _, ok := existingKeys[variantKey(axes, options)]; ok {
+			continue
+		}
+		values := make([]string, len(axes))
+		for i, axis := range axes {
+			values[i] = options[axis.Name]
+		}
+		newVariants = append(newVariants, domain.Produto{
+			SKU:            variantSKU(parent.SKU, values),
+			CategoryID:     parent.CategoryID,
+			Name:           parent.Name + " - " + strings.Join(values, " / "),
+			Description:    parent.Description,
+			Unit:           parent.Unit,
+			Attributes:     parent.Attributes,
+			Tags:           parent.Tags,
+			TrackLots:      parent.TrackLots,
+			Serialized:     parent.Serialized,
+			CostingMethod:  parent.CostingMethod,
+			Currency:       parent.Currency,
+			VariantOptions: options,
+		})
+	}
+	return newVariants
+}
+
+// normalizeVariantAxes valida os eixos e remove espaços e valores duplicados.
+func normalizeVariantAxes(axes []domain.VariantAxis) ([]domain.VariantAxis, error) {
+	if len(axes) == 0 {
+		return nil, fmt.Errorf("%w: informe ao menos um eixo de variação", domain.ErrInvalidVariants)
+	}
+
+	total := 1
+	names := make(map[string]struct{}, len(axes))
+	normalized := make([]domain.VariantAxis, 0, len(axes))
+	for _, axis := range axes {
+		name := strings.ToLower(strings.TrimSpace(axis.Name))
+		if name == "" {
+			return nil, fmt.Errorf("%w: o nome do eixo é obrigatório", domain.ErrInvalidVariants)
+		}
+		if _, dup := names[name]; dup {
+			return nil, fmt.Errorf("%w: eixo %q duplicado", domain.ErrInvalidVariants, name)
+		}
+		names[name] = struct{}{}
+
+		values := make([]string, 0, len(axis.Values))
+		seen := make(map[string]struct{}, len(axis.Values))
+		for _, value := range axis.Values {
+			value = strings.TrimSpace(value)
+			if value == "" {
+				continue
+			}
+			if _, dup := seen[value]; dup {
+				continue
+			}
+			seen[value] = struct{}{}
+			values = append(values, value)
+		}
+		if len(values) == 0 {
+			return nil, fmt.Errorf("%w: o eixo %q precisa de ao menos um valor", domain.ErrInvalidVariants, name)
+		}
+
+		total *= len(values)
+		if total > maxVariantsPerProduct {
+			return nil, fmt.Errorf("%w: a matriz excede %d variantes", domain.ErrInvalidVariants, maxVariantsPerProduct)
+		}
+		normalized = append(normalized, domain.VariantAxis{Name: name, Values: values})
+	}
+	return normalized, nil
+}
+
+// checkAxesExtend garante que os novos eixos apenas acrescentam valores aos atuais.
+func checkAxesExtend(current, next []domain.VariantAxis) error {
+	if len(current) != len(next) {
+		return fmt.Errorf("%w: os eixos não podem ser alterados depois que as variantes existem", domain.ErrInvalidVariants)
+	}
+	for i := range current {
+		if current[i].Name != next[i].Name {
+			return fmt.Errorf("%w: os eixos não podem ser alterados depois que as variantes existem", domain.ErrInvalidVariants)
+		}
+		values := make(map[string]struct{}, len(next[i].Values))
+		for _, v := range next[i].Values {
+			values[v] = struct{}{}
+		}
+		for _, v := range current[i].Values {
+			if _, ok := values[v]; !ok {
+				return fmt.Errorf("%w: o valor %q do eixo %q não pode ser removido", domain.ErrInvalidVariants, v, current[i].Name)
+			}
+		}
+	}
+	return nil
+}
+
+// variantCombinations gera o produto cartesiano dos valores dos eixos.
+func variantCombinations(axes []domain.VariantAxis) []map[string]string {
+	combinations := []map[string]string{{}}
+	for _, axis := range axes {
+		next := make([]map[string]string, 0, len(combinations)*len(axis.Values))
+		for _, base := range combinations {
+			for _, value := range axis.Values {
+				options := make(map[string]string, len(base)+1)
+				for k, v := range base {
+					options[k] = v
+				}
+				options[axis.Name] = value
+				next = append(next, options)
+			}
+		}
+		combinations = next
+	}
+	return combinations
+}
+
+// variantKey gera uma chave canônica para a combinação, na ordem dos eixos.
+func variantKey(axes []domain.VariantAxis, options map[string]string) string {
+	parts := make([]string, len(axes))
+	for i, axis := range axes {
+		parts[i] = axis.Name + "=" + options[axis.Name]
+	}
+	return strings.Join(parts, "\x00")
+}
+
+// variantSKU deriva o SKU da variante a partir do SKU do pai (ex.: CAMISA-M-AZUL).
+// Sem SKU no pai, a variante também fica sem SKU.
+func variantSKU(parentSKU string, values []string) string {
+	if parentSKU == "" {
+		return ""
+	}
+	parts := []string{parentSKU}
+	for _, v := range values {
+		parts = append(parts, strings.ToUpper(strings.Join(strings.Fields(v), "_")))
+	}
+	return strings.Join(parts, "-")
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"controle-de-estoque/backend/internal/domain"
+)
+
+func TestMissingVariants(t *testing.T) {
+	parent := domain.Produto{SKU: "CAMISA", Name: "Camisa", Unit: "UN"}
+	axes, err := normalizeVariantAxes([]domain.VariantAxis{
+		{Name: "Tamanho", Values: []string{"P", "M"}},
+		{Name: "Cor", Values: []string{"azul", " azul ", "verde"}},
+	})
+	if err != nil {
+		t.Fatalf("normalizeVariantAxes erro inesperado: %v", err)
+	}
+
+	first := missingVariants(parent, axes, nil)
+	var skus []string
+	for _, v := range first {
+		skus = append(skus, v.SKU)
+	}
+	want := []string{"CAMISA-P-AZUL", "CAMISA-P-VERDE", "CAMISA-M-AZUL", "CAMISA-M-VERDE"}
+	if !slices.Equal(skus, want) {
+		t.Fatalf("primeira geração = %q, esperado %q", skus, want)
+	}
+	if first[0].Name != "Camisa - P / azul" || first[0].VariantOptions["tamanho"] != "P" || first[0].VariantOptions["cor"] != "azul" {
+		t.Errorf("variante gerada = %+v", first[0])
+	}
+
+	// Gerar de novo com as mesmas combinações não cria duplicatas.
+	if again := missingVariants(parent, axes, first); len(again) != 0 {
+		t.Errorf("regerar com as mesmas combinações criou %d variantes", len(again))
+	}
+
+	// Um valor novo cria apenas as combinações que faltam.
+	extended, err := normalizeVariantAxes([]domain.VariantAxis{
+		{Name: "tamanho", Values: []string{"P", "M", "G"}},
+		{Name: "cor", Values: []string{"azul", "verde"}},
+	})
+	if err != nil {
+		t.Fatalf("normalizeVariantAxes erro inesperado: %v", err)
+	}
+	if err := checkAxesExtend(axes, extended); err != nil {
+		t.Fatalf("checkAxesExtend erro inesperado: %v", err)
+	}
+	skus = nil
+	for _, v := range missingVariants(parent, extended, first) {
+		skus = append(skus, v.SKU)
+	}
+	if want := []string{"CAMISA-G-AZUL", "CAMISA-G-VERDE"}; !slices.Equal(skus, want) {
+		t.Errorf("geração após novo valor = %q, esperado %q", skus, want)
+	}
+}
+
+func TestCheckAxesExtend(t *testing.T) {
+	current := []domain.VariantAxis{{Name: "tamanho", Values: []string{"P", "M"}}}
+	tests := []struct {
+		name    string
+		next    []domain.VariantAxis
+		wantErr bool
+	}{
+		{"mesmos valores", []domain.VariantAxis{{Name: "tamanho", Values: []string{"M", "P"}}}, false},
+		{"valor novo", []domain.VariantAxis{{Name: "tamanho", Values: []string{"P", "M", "G"}}}, false},
+		{"valor removido", []domain.VariantAxis{{Name: "tamanho", Values: []string{"P"}}}, true},
+		{"eixo renomeado", []domain.VariantAxis{{Name: "cor", Values: []string{"P", "M"}}}, true},
+		{"eixo novo", []domain.VariantAxis{{Name: "tamanho", Values: []string{"P", "M"}}, {Name: "cor", Values: []string{"azul"}}}, true},
+	}
+	for _, tt := range tests {
+		err := checkAxesExtend(current, tt.next)
+		if tt.wantErr != errors.Is(err, domain.ErrInvalidVariants) || (!tt.wantErr && err != nil) {
+			t.Errorf("%s: erro = %v, esperado erro: %t", tt.name, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"controle-de-estoque/backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// IReportRepository define as consultas agregadas usadas pelos relatórios.
+type IReportRepository interface {
+	ValuationLines(ctx context.Context, asOf time.Time, basis domain.ValuationBasis) ([]domain.ValuationLine, error)
+	MarginLines(ctx context.Context, filter domain.MarginFilter) ([]domain.MarginLine, error)
+}
+
+// ReportService monta os relatórios gerenciais do estoque.
+type ReportService struct {
+	repo IReportRepository
+}
+
+// NewReportService cria uma nova instância de ReportService.
+func NewReportService(repo IReportRepository) *ReportService {
+	return &ReportService{repo: repo}
+}
+
+// priceValuationSkew é quanto um as_of pode estar no passado e ainda ser valorizado pelo
+// preço, que só existe no instante atual: cobre o atraso da requisição e relógios de
+// clientes levemente adiantados ou atrasados.
+const priceValuationSkew = time.Minute
+
+// Valuation valoriza o estoque global e o dos clientes no instante asOf (agora, se
+// nulo), com os totais por categoria, pelo preço (padrão) ou pelo custo. Datas futuras
+// são rejeitadas, assim como datas passadas pelo preço, cujo histórico não existe.
+func (s *ReportService) Valuation(ctx context.Context, asOf *time.Time, basis domain.ValuationBasis) (*domain.ValuationReport, error) {
+	if basis == "" {
+		basis = domain.ValuationByPrice
+	}
+	if basis != domain.ValuationByPrice && basis != domain.ValuationByCost {
+		return nil, fmt.Errorf("%w: basis deve ser price ou cost", domain.ErrInvalidFilter)
+	}
+	now := time.Now()
+	if asOf == nil {
+		asOf = &now
+	} else if asOf.After(now) {
+		return nil, fmt.Errorf("%w: as_of não pode estar no futuro", domain.ErrInvalidFilter)
+	} else if basis == domain.ValuationByPrice && now.Sub(*asOf) > priceValuationSkew {
+		return nil, fmt.Errorf("%w: os preços não têm histórico; para um as_of no passado, use basis=cost", domain.ErrInvalidFilter)
+	}
+
+	lines, err := s.repo.ValuationLines(ctx, *asOf, basis)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.ValuationReport{AsOf: asOf.UTC(), Basis: basis, Clients: []domain.ClientValuation{}}
+	for _, line := range lines {
+		if line.ClientID == nil {
+			addValuation(&report.Warehouse, line)
+		} else {
+			n := len(report.Clients)
+			if n == 0 || report.Clients[n-1].ClientID != *line.ClientID {
+				report.Clients = append(report.Clients, domain.ClientValuation{ClientID: *line.ClientID, ClientName: line.ClientName})
+				n++
+			}
+			addValuation(&report.Clients[n-1].Valuation, line)
+			addValuation(&report.ClientsTotal, line)
+		}
+		addValuation(&report.Total, line)
+	}
+	for _, v := range []*domain.Valuation{&report.Warehouse, &report.ClientsTotal, &report.Total} {
+		if v.Categories == nil {
+			v.Categories = []domain.CategoryValuation{}
+		}
+		if v.Values == nil {
+			v.Values = []domain.Amount{}
+		}
+		// Os totais juntam categorias de vários detentores; a ordem volta a ser a das
+		// linhas: por nome, com os produtos sem categoria no fim, e pela moeda.
+		slices.SortStableFunc(v.Categories, func(a, b domain.CategoryValuation) int {
+			if (a.CategoryID == nil) != (b.CategoryID == nil) {
+				if a.CategoryID == nil {
+					return 1
+				}
+				return -1
+			}
+			if c := strings.Compare(a.CategoryName, b.CategoryName); c != 0 {
+				return c
+			}
+			return strings.Compare(string(a.Currency), string(b.Currency))
+		})
+		slices.SortFunc(v.Values, func(a, b domain.Amount) int {
+			return strings.Compare(string(a.Currency), string(b.Currency))
+		})
+	}
+	return report, nil
+}
+
+// Margin calcula a receita, o CMV e a margem das transferências do período, descontadas
+// as devoluções, agrupados por produto (padrão) ou por cliente e pelas moedas da receita
+// e do custo, com um total por par de moedas. A margem só é calculada quando as duas
+// moedas coincidem.
+func (s *ReportService) Margin(ctx context.Context, filter domain.MarginFilter) (*domain.MarginReport, error) {
+	if filter.GroupBy == "" {
+		filter.GroupBy = domain.MarginByProduct
+	}
+	if filter.GroupBy != domain.MarginByProduct && filter.GroupBy != domain.MarginByClient {
+		return nil, fmt.Errorf("%w: group_by deve ser product ou client", domain.ErrInvalidFilter)
+	}
+	if !filter.From.Before(filter.To) {
+		return nil, fmt.Errorf("%w: o início do período deve ser anterior ao fim", domain.ErrInvalidFilter)
+	}
+
+	lines, err := s.repo.MarginLines(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.MarginReport{
+		From:    filter.From.UTC(),
+		To:      filter.To.UTC(),
+		GroupBy: filter.GroupBy,
+		Lines:   []domain.MarginLine{},
+		Totals:  []domain.MarginTotal{},
+	}
+	for _, line := range lines {
+		completeMargin(&line.MarginFigures, line.Currency == line.CostCurrency)
+		report.Lines = append(report.Lines, line)
+
+		i := slices.IndexFunc(report.Totals, func(t domain.MarginTotal) bool {
+			return t.Currency == line.Currency && t.CostCurrency == line.CostCurrency
+		})
+		if i < 0 {
+			report.Totals = append(report.Totals, domain.MarginTotal{Currency: line.Currency, CostCurrency: line.CostCurrency})
+			i = len(report.Totals) - 1
+		}
+		total := &report.Totals[i]
+		total.QuantitySold += line.QuantitySold
+		total.QuantityReturned += line.QuantityReturned
+		total.RevenueInCents += line.RevenueInCents
+		total.COGSInCents += line.COGSInCents
+	}
+	for i := range report.Totals {
+		total := &report.Totals[i]
+		completeMargin(&total.MarginFigures, total.Currency == total.CostCurrency)
+	}
+	slices.SortFunc(report.Totals, func(a, b domain.MarginTotal) int {
+		if c := strings.Compare(string(a.Currency), string(b.Currency)); c != 0 {
+			return c
+		}
+		return strings.Compare(string(a.CostCurrency), string(b.CostCurrency))
+	})
+	return report, nil
+}
+
+// completeMargin calcula a margem e o seu percentual sobre a receita, com duas casas,
+// quando a receita e o CMV estão na mesma moeda (sameCurrency).
+func completeMargin(f *domain.MarginFigures, sameCurrency bool) {
+	if !sameCurrency {
+		return
+	}
+	margin := f.RevenueInCents - f.COGSInCents
+	f.MarginInCents = &margin
+	if f.RevenueInCents != 0 {
+		percent := math.Round(float64(margin)*10000/float64(f.RevenueInCents)) / 100
+		f.MarginPercent = &percent
+	}
+}
+
+// addValuation soma a linha ao total da sua moeda e à sua categoria.
+func addValuation(v *domain.Valuation, line domain.ValuationLine) {
+	v.Quantity += line.Quantity
+	v.Values = addAmount(v.Values, line.Currency, line.ValueInCents)
+	for i := range v.Categories {
+		c := &v.Categories[i]
+		if sameID(c.CategoryID, line.CategoryID) && c.Currency == line.Currency {
+			c.Quantity += line.Quantity
+			c.ValueInCents += line.ValueInCents
+			return
+		}
+	}
+	v.Categories = append(v.Categories, domain.CategoryValuation{
+		CategoryID:   line.CategoryID,
+		CategoryName: line.CategoryName,
+		Currency:     line.Currency,
+		Quantity:     line.Quantity,
+		ValueInCents: line.ValueInCents,
+	})
+}
+
+// addAmount soma value ao total da moeda em amounts.
+func addAmount(amounts []domain.Amount, currency domain.Currency, value int64) []domain.Amount {
+	for i := range amounts {
+		if amounts[i].Currency == currency {
+			amounts[i].ValueInCents += value
+			return amounts
+		}
+	}
+	return append(amounts, domain.Amount{Currency: currency, ValueInCents: value})
+}
+
+func sameID(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
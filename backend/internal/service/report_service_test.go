@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"controle-de-estoque/backend/internal/domain"
+)
+
+// fixedReportRepo devolve linhas fixas para os relatórios e guarda a base de valorização pedida.
+type fixedReportRepo struct {
+	IReportRepository
+	margin []domain.MarginLine
+	basis  *domain.ValuationBasis
+}
+
+func (r fixedReportRepo) ValuationLines(_ context.Context, _ time.Time, basis domain.ValuationBasis) ([]domain.ValuationLine, error) {
+	*r.basis = basis
+	return nil, nil
+}
+
+func (r fixedReportRepo) MarginLines(context.Context, domain.MarginFilter) ([]domain.MarginLine, error) {
+	return r.margin, nil
+}
+
+func marginLine(name string, currency, costCurrency domain.Currency, sold, revenue, cogs int64) domain.MarginLine {
+	return domain.MarginLine{
+		ProductName:  name,
+		Currency:     currency,
+		CostCurrency: costCurrency,
+		MarginFigures: domain.MarginFigures{
+			QuantitySold:   sold,
+			RevenueInCents: revenue,
+			COGSInCents:    cogs,
+		},
+	}
+}
+
+func TestMarginKeepsCurrenciesApart(t *testing.T) {
+	repo := fixedReportRepo{margin: []domain.MarginLine{
+		marginLine("Camisa", "BRL", "BRL", 10, 10000, 6000),
+		marginLine("Camisa", "USD", "BRL", 4, 2000, 2400),
+		marginLine("Calça", "BRL", "BRL", 5, 5000, 2500),
+	}}
+	now := time.Now()
+	report, err := NewReportService(repo).Margin(context.Background(), domain.MarginFilter{From: now.Add(-time.Hour), To: now})
+	if err != nil {
+		t.Fatalf("Margin erro inesperado: %v", err)
+	}
+
+	same := report.Lines[0]
+	if same.MarginInCents == nil || *same.MarginInCents != 4000 || same.MarginPercent == nil || *same.MarginPercent != 40 {
+		t.Errorf("margem em BRL = %v / %v, esperado 4000 / 40", same.MarginInCents, same.MarginPercent)
+	}
+	mixed := report.Lines[1]
+	if mixed.MarginInCents != nil || mixed.MarginPercent != nil {
+		t.Errorf("receita em USD com CMV em BRL não deve ter margem, obtido %v / %v", mixed.MarginInCents, mixed.MarginPercent)
+	}
+
+	if len(report.Totals) != 2 {
+		t.Fatalf("totais = %+v, esperado um por par de moedas", report.Totals)
+	}
+	brl, usd := report.Totals[0], report.Totals[1]
+	if brl.Currency != "BRL" || brl.CostCurrency != "BRL" || brl.RevenueInCents != 15000 || brl.COGSInCents != 8500 ||
+		brl.MarginInCents == nil || *brl.MarginInCents != 6500 {
+		t.Errorf("total BRL/BRL = %+v", brl)
+	}
+	if usd.Currency != "USD" || usd.CostCurrency != "BRL" || usd.RevenueInCents != 2000 || usd.COGSInCents != 2400 ||
+		usd.MarginInCents != nil {
+		t.Errorf("total USD/BRL = %+v, esperado sem margem", usd)
+	}
+}
+
+func TestValuationBasisAndAsOf(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-48 * time.Hour)
+	recent := now.Add(-time.Second)
+	future := now.Add(time.Hour)
+	tests := []struct {
+		name      string
+		asOf      *time.Time
+		basis     domain.ValuationBasis
+		wantBasis domain.ValuationBasis
+		wantErr   bool
+	}{
+		{"padrão é o preço atual", nil, "", domain.ValuationByPrice, false},
+		{"preço no instante atual", &recent, domain.ValuationByPrice, domain.ValuationByPrice, false},
+		{"preço no passado", &past, domain.ValuationByPrice, "", true},
+		{"preço no passado sem base explícita", &past, "", "", true},
+		{"custo no passado", &past, domain.ValuationByCost, domain.ValuationByCost, false},
+		{"custo no futuro", &future, domain.ValuationByCost, "", true},
+		{"base desconhecida", nil, "market", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got domain.ValuationBasis
+			report, err := NewReportService(fixedReportRepo{basis: &got}).Valuation(context.Background(), tt.asOf, tt.basis)
+			if tt.wantErr {
+				if !errors.Is(err, domain.ErrInvalidFilter) {
+					t.Fatalf("erro = %v, esperado ErrInvalidFilter", err)
+				}
+				if got != "" {
+					t.Errorf("consultou o repositório com a base %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("erro inesperado: %v", err)
+			}
+			if got != tt.wantBasis || report.Basis != tt.wantBasis {
+				t.Errorf("base consultada %q, no relatório %q; esperado %q", got, report.Basis, tt.wantBasis)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"controle-de-estoque/backend/internal/domain"
+)
+
+// maxSortKeys limita a quantidade de critérios de ordenação de uma listagem.
+const maxSortKeys = 4
+
+// ParseSort interpreta o parâmetro sort no formato "campo[:asc|desc],..." (ex.: "price:desc,name"),
+// aceitando apenas os campos da lista allowed. A direção padrão é ascendente.
+func ParseSort(value string, allowed []string) ([]domain.SortField, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(value, ",")
+	if len(parts) > maxSortKeys {
+		return nil, fmt.Errorf("%w: no máximo %d critérios de ordenação", domain.ErrInvalidFilter, maxSortKeys)
+	}
+
+	fields := make([]domain.SortField, 0, len(parts))
+	seen := make(map[string]struct{}, len(parts))
+	for _, part := range parts {
+		name, direction, _ := strings.Cut(strings.TrimSpace(part), ":")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !slices.Contains(allowed, name) {
+			return nil, fmt.Errorf("%w: campo de ordenação %q não suportado (use %s)", domain.ErrInvalidFilter, name, strings.Join(allowed, ", "))
+		}
+		if _, dup := seen[name]; dup {
+			return nil, fmt.Errorf("%w: campo de ordenação %q repetido", domain.ErrInvalidFilter, name)
+		}
+		seen[name] = struct{}{}
+
+		field := domain.SortField{Field: name}
+		switch strings.ToLower(strings.TrimSpace(direction)) {
+		case "", "asc":
+		case "desc":
+			field.Desc = true
+		default:
+			return nil, fmt.Errorf("%w: direção %q inválida (use asc ou desc)", domain.ErrInvalidFilter, direction)
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"controle-de-estoque/backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// stockEventBuffer é quantos eventos um assinante pode acumular sem consumir antes de
+// ser desligado com domain.ErrStockEventsLagging.
+const stockEventBuffer = 256
+
+// IStockEventListener define a interface da escuta das movimentações confirmadas.
+type IStockEventListener interface {
+	Listen(ctx context.Context, handle func(domain.StockEvent)) error
+}
+
+// StockEventFilter restringe os eventos entregues a uma assinatura. Listas vazias não filtram.
+type StockEventFilter struct {
+	ProductIDs []uuid.UUID
+	ClientIDs  []uuid.UUID
+}
+
+func (f StockEventFilter) matches(e domain.StockEvent) bool {
+	if len(f.ProductIDs) > 0 && !slices.Contains(f.ProductIDs, e.ProductID) {
+		return false
+	}
+	if len(f.ClientIDs) > 0 && (e.ClientID == nil || !slices.Contains(f.ClientIDs, *e.ClientID)) {
+		return false
+	}
+	return true
+}
+
+// StockEventService distribui as movimentações de estoque confirmadas aos assinantes,
+// em tempo real. Não há histórico: cada assinatura recebe apenas o que for confirmado
+// depois dela.
+type StockEventService struct {
+	listener IStockEventListener
+
+	mu            sync.Mutex
+	subscriptions map[*StockSubscription]struct{}
+}
+
+// NewStockEventService cria uma nova instância de StockEventService.
+func NewStockEventService(listener IStockEventListener) *StockEventService {
+	return &StockEventService{listener: listener, subscriptions: make(map[*StockSubscription]struct{})}
+}
+
+// StockSubscription é uma assinatura do fluxo de movimentações.
+type StockSubscription struct {
+	service *StockEventService
+	filter  StockEventFilter
+	events  chan domain.StockEvent
+	err     error // Motivo do encerramento, definido antes de events ser fechado
+}
+
+// Events entrega os eventos da assinatura. O canal é fechado quando a assinatura é
+// encerrada pelo serviço; Err informa o motivo.
+func (sub *StockSubscription) Events() <-chan domain.StockEvent {
+	return sub.events
+}
+
+// Err informa por que a assinatura foi encerrada, depois que Events é fechado.
+func (sub *StockSubscription) Err() error {
+	sub.service.mu.Lock()
+	defer sub.service.mu.Unlock()
+	return sub.err
+}
+
+// Cancel encerra a assinatura a pedido do assinante.
+func (sub *StockSubscription) Cancel() {
+	sub.service.mu.Lock()
+	defer sub.service.mu.Unlock()
+	sub.service.remove(sub, nil)
+}
+
+// Subscribe cria uma assinatura das movimentações que atendem ao filtro. O assinante
+// deve chamar Cancel ao terminar.
+func (s *StockEventService) Subscribe(filter StockEventFilter) *StockSubscription {
+	sub := &StockSubscription{service: s, filter: filter, events: make(chan domain.StockEvent, stockEventBuffer)}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.subscriptions[sub] = struct{}{}
+	return sub
+}
+
+// Listen escuta as movimentações confirmadas e as distribui até ctx ser cancelado ou a
+// escuta falhar. Como os eventos emitidos sem escuta se perdem, uma falha encerra as
+// assinaturas com domain.ErrStockEventsInterrupted, para que os assinantes reconectem
+// e releiam os saldos. O chamador deve chamar Listen de novo para retomar a escuta.
+func (s *StockEventService) Listen(ctx context.Context) error {
+	err := s.listener.Listen(ctx, s.publish)
+	if ctx.Err() == nil {
+		s.closeAll(domain.ErrStockEventsInterrupted)
+	}
+	return err
+}
+
+// Close encerra todas as assinaturas, para que os fluxos abertos terminem no desligamento do servidor.
+func (s *StockEventService) Close() {
+	s.closeAll(domain.ErrStockEventsInterrupted)
+}
+
+func (s *StockEventService) publish(event domain.StockEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for sub := range s.subscriptions {
+		if !sub.filter.matches(event) {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			// O assinante não consome no ritmo dos eventos; é desligado em vez de
+			// atrasar os demais ou perder eventos sem aviso.
+			s.remove(sub, domain.ErrStockEventsLagging)
+		}
+	}
+}
+
+func (s *StockEventService) closeAll(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for sub := range s.subscriptions {
+		s.remove(sub, err)
+	}
+}
+
+// remove encerra a assinatura com o motivo informado. Deve ser chamada com mu travado.
+func (s *StockEventService) remove(sub *StockSubscription, err error) {
+	if _, ok := s.subscriptions[sub]; !ok {
+		return
+	}
+	delete(s.subscriptions, sub)
+	sub.err = err
+	close(sub.events)
+}
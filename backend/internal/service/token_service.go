@@ -136,7 +136,7 @@ func (s *TokenService) SetTokenCookies(w http.ResponseWriter, tokenPair *TokenPa
 		Expires:  time.Now().Add(refreshDuration),
 		HttpOnly: true,
 		Secure:   os.Getenv("ENV") == "production",
-		Path:     "/api/refresh", // Escopo mais restrito para o refresh token
+		Path:     "/api/v1/refresh", // Escopo mais restrito para o refresh token
 		SameSite: http.SameSiteStrictMode,
 	}
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 	"unicode"
 
@@ -74,18 +75,23 @@ type (
 
 // Erros customizados do serviço
 var (
-	ErrInvalidCredentials = errors.New("credenciais inválidas")
-	ErrEmailInUse         = errors.New("email já está em uso")
-	ErrWeakPassword       = errors.New("a senha não atende aos requisitos de segurança")
-	ErrPasswordsDontMatch = errors.New("as senhas não coincidem")
+	ErrInvalidCredentials = domain.ErrInvalidCredentials
+	ErrEmailInUse         = domain.ErrEmailAlreadyExists
+	ErrWeakPassword       = domain.NewError(domain.KindValidation, "weak_password", "a senha não atende aos requisitos de segurança")
+	ErrPasswordsDontMatch = domain.NewError(domain.KindValidation, "passwords_dont_match", "as senhas não coincidem")
 )
 
 // Register cria um novo usuário
 func (s *UserService) Register(ctx context.Context, req RegisterRequest) (*AuthResponse, error) {
-	if req.Password != req.PasswordConfirm {
-		return nil, ErrPasswordsDontMatch
-	}
+	req.Email = strings.TrimSpace(req.Email)
+	var v validator
+	v.check(req.Email != "", "email", domain.CodeRequired, "o email é obrigatório")
+	v.email("email", req.Email)
 	if err := validatePassword(req.Password); err != nil {
+		v.addErr("password", domain.CodeInvalid, err)
+	}
+	v.check(req.Password == req.PasswordConfirm, "passwordConfirm", domain.CodeMismatch, ErrPasswordsDontMatch.Error())
+	if err := v.err(domain.ErrInvalidUserData); err != nil {
 		return nil, err
 	}
 	exists, err := s.repo.UserExists(ctx, req.Email)
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+
+	"controle-de-estoque/backend/internal/domain"
+)
+
+// validator acumula os erros de campo de uma requisição, para que todos sejam
+// devolvidos de uma vez em vez de apenas o primeiro.
+type validator struct {
+	fields []domain.FieldError
+}
+
+// add registra um erro no campo informado.
+func (v *validator) add(field, code, message string) {
+	v.fields = append(v.fields, domain.FieldError{Field: field, Code: code, Message: message})
+}
+
+// check registra o erro quando ok é falso.
+func (v *validator) check(ok bool, field, code, message string) {
+	if !ok {
+		v.add(field, code, message)
+	}
+}
+
+// addErr registra um erro de domínio (ex.: de NormalizeDocument) no campo informado,
+// usando como mensagem apenas o detalhe após o erro de domínio.
+func (v *validator) addErr(field, code string, err error) {
+	message := err.Error()
+	if wrapped := errors.Unwrap(err); wrapped != nil {
+		message = strings.TrimPrefix(message, wrapped.Error()+": ")
+	}
+	v.add(field, code, message)
+}
+
+// email valida um email opcional.
+func (v *validator) email(field, value string) {
+	if value == "" {
+		return
+	}
+	if _, err := mail.ParseAddress(value); err != nil {
+		v.add(field, domain.CodeInvalid, "email inválido")
+	}
+}
+
+// maxLength valida o tamanho máximo, em caracteres, de um texto.
+func (v *validator) maxLength(field, value string, limit int) {
+	if len([]rune(value)) > limit {
+		v.add(field, domain.CodeTooLong, fmt.Sprintf("deve ter no máximo %d caracteres", limit))
+	}
+}
+
+// err retorna um *domain.ValidationError envolvendo kind, ou nil se não houver erros.
+func (v *validator) err(kind error) error {
+	if len(v.fields) == 0 {
+		return nil
+	}
+	return &domain.ValidationError{Err: kind, Fields: v.fields}
+}
+
+// indexed monta o caminho de um campo dentro de uma lista, ex.: indexed("addresses", 1, "cep").
+func indexed(list string, i int, field string) string {
+	return fmt.Sprintf("%s[%d].%s", list, i, field)
+}
@@ -0,0 +1,14 @@
+-- SKU único, unidade de medida e códigos de barras dos produtos.
+ALTER TABLE products
+    ADD COLUMN sku  TEXT,
+    ADD COLUMN unit TEXT NOT NULL DEFAULT 'UN';
+
+CREATE UNIQUE INDEX products_sku_key ON products (sku) WHERE sku IS NOT NULL;
+
+CREATE TABLE product_barcodes (
+    barcode    TEXT PRIMARY KEY,
+    product_id UUID NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX product_barcodes_product_id_idx ON product_barcodes (product_id);
@@ -0,0 +1,26 @@
+-- Níveis de embalagem por produto (ex.: caixa com 12 unidades, palete com 480).
+CREATE TABLE product_packagings (
+    product_id UUID NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    code       TEXT NOT NULL,
+    name       TEXT NOT NULL DEFAULT '',
+    factor     INTEGER NOT NULL CHECK (factor > 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (product_id, code)
+);
+
+-- Histórico de movimentações do estoque global. A quantidade é sempre expressa
+-- na unidade base do produto: positiva para entradas e negativa para saídas.
+CREATE TABLE stock_movements (
+    id                 UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    product_id         UUID NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    client_id          UUID REFERENCES clients (id) ON DELETE SET NULL,
+    type               TEXT NOT NULL CHECK (type IN ('receipt', 'adjustment', 'transfer')),
+    packaging_code     TEXT NOT NULL,
+    packaging_quantity INTEGER NOT NULL,
+    packaging_factor   INTEGER NOT NULL CHECK (packaging_factor > 0),
+    quantity           INTEGER NOT NULL,
+    reason             TEXT NOT NULL DEFAULT '',
+    created_at         TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX stock_movements_product_id_created_at_idx ON stock_movements (product_id, created_at);
@@ -0,0 +1,20 @@
+-- Árvore de categorias com caminho materializado. O caminho é formado pelos IDs
+-- dos ancestrais e da própria categoria (ex.: "/<raiz>/<filha>/"), o que permite
+-- buscar todos os descendentes com um simples prefixo.
+CREATE TABLE categories (
+    id         UUID PRIMARY KEY,
+    parent_id  UUID REFERENCES categories (id) ON DELETE RESTRICT,
+    name       TEXT NOT NULL,
+    path       TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX categories_path_key ON categories (path text_pattern_ops);
+CREATE UNIQUE INDEX categories_parent_name_key
+    ON categories (COALESCE(parent_id, '00000000-0000-0000-0000-000000000000'), lower(name));
+
+ALTER TABLE products
+    ADD COLUMN category_id UUID REFERENCES categories (id) ON DELETE SET NULL;
+
+CREATE INDEX products_category_id_idx ON products (category_id);
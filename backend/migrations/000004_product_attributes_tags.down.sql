@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS products_tags_idx;
+DROP INDEX IF EXISTS products_attributes_idx;
+ALTER TABLE products
+    DROP COLUMN IF EXISTS tags,
+    DROP COLUMN IF EXISTS attributes;
+DROP TABLE IF EXISTS category_attributes;
@@ -0,0 +1,18 @@
+-- Definições tipadas de atributos por categoria. Subcategorias herdam as
+-- definições de seus ancestrais.
+CREATE TABLE category_attributes (
+    category_id UUID NOT NULL REFERENCES categories (id) ON DELETE CASCADE,
+    key         TEXT NOT NULL CHECK (key ~ '^[a-z][a-z0-9_]*$'),
+    label       TEXT NOT NULL DEFAULT '',
+    type        TEXT NOT NULL CHECK (type IN ('string', 'number', 'boolean', 'enum')),
+    required    BOOLEAN NOT NULL DEFAULT FALSE,
+    options     TEXT[] NOT NULL DEFAULT '{}',
+    PRIMARY KEY (category_id, key)
+);
+
+ALTER TABLE products
+    ADD COLUMN attributes JSONB NOT NULL DEFAULT '{}',
+    ADD COLUMN tags       TEXT[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX products_attributes_idx ON products USING GIN (attributes jsonb_path_ops);
+CREATE INDEX products_tags_idx ON products USING GIN (tags);
@@ -0,0 +1,8 @@
+DELETE FROM products WHERE parent_id IS NOT NULL;
+DROP INDEX IF EXISTS products_parent_variant_options_key;
+DROP INDEX IF EXISTS products_parent_id_idx;
+ALTER TABLE products
+    DROP COLUMN IF EXISTS price_override_in_cents,
+    DROP COLUMN IF EXISTS variant_options,
+    DROP COLUMN IF EXISTS variant_axes,
+    DROP COLUMN IF EXISTS parent_id;
@@ -0,0 +1,13 @@
+-- Variantes de produto (matriz tamanho/cor). O produto pai declara os eixos em
+-- variant_axes e não possui estoque próprio; cada variante é uma linha de products
+-- com parent_id, sua combinação de valores em variant_options, SKU e estoque próprios
+-- e, opcionalmente, um preço que substitui o do pai.
+ALTER TABLE products
+    ADD COLUMN parent_id               UUID REFERENCES products (id) ON DELETE CASCADE,
+    ADD COLUMN variant_axes            JSONB NOT NULL DEFAULT '[]',
+    ADD COLUMN variant_options         JSONB NOT NULL DEFAULT '{}',
+    ADD COLUMN price_override_in_cents BIGINT CHECK (price_override_in_cents >= 0);
+
+CREATE INDEX products_parent_id_idx ON products (parent_id);
+CREATE UNIQUE INDEX products_parent_variant_options_key
+    ON products (parent_id, variant_options) WHERE parent_id IS NOT NULL;
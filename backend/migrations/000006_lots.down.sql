@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS client_stock_lots;
+DROP TABLE IF EXISTS lots;
+ALTER TABLE products DROP COLUMN IF EXISTS track_lots;
@@ -0,0 +1,28 @@
+-- Controle de lotes e validade. Para produtos com track_lots, products.quantity
+-- é sempre igual à soma de lots.quantity.
+ALTER TABLE products ADD COLUMN track_lots BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE lots (
+    id                UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    product_id        UUID NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    batch_number      TEXT NOT NULL,
+    expiry_date       DATE,
+    quantity          INTEGER NOT NULL CHECK (quantity >= 0),
+    received_quantity INTEGER NOT NULL CHECK (received_quantity >= 0),
+    created_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (product_id, batch_number)
+);
+
+CREATE INDEX lots_fefo_idx ON lots (product_id, expiry_date) WHERE quantity > 0;
+CREATE INDEX lots_expiry_date_idx ON lots (expiry_date) WHERE quantity > 0;
+
+-- Lotes entregues a cada cliente, detalhando client_stocks.
+CREATE TABLE client_stock_lots (
+    client_id  UUID NOT NULL,
+    product_id UUID NOT NULL,
+    lot_id     UUID NOT NULL REFERENCES lots (id) ON DELETE CASCADE,
+    quantity   INTEGER NOT NULL CHECK (quantity >= 0),
+    PRIMARY KEY (client_id, product_id, lot_id),
+    FOREIGN KEY (client_id, product_id) REFERENCES client_stocks (client_id, product_id) ON DELETE CASCADE
+);
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS serial_number_events;
+DROP TABLE IF EXISTS serial_numbers;
+DELETE FROM stock_movements WHERE type = 'return';
+ALTER TABLE stock_movements DROP CONSTRAINT stock_movements_type_check;
+ALTER TABLE stock_movements ADD CONSTRAINT stock_movements_type_check
+    CHECK (type IN ('receipt', 'adjustment', 'transfer'));
+ALTER TABLE products DROP COLUMN IF EXISTS serialized;
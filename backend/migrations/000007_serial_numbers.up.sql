@@ -0,0 +1,31 @@
+-- Rastreamento unitário por número de série. Para produtos com serialized, a
+-- quantidade de números de série "in_stock"/"returned" é igual a products.quantity.
+ALTER TABLE products ADD COLUMN serialized BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE stock_movements DROP CONSTRAINT stock_movements_type_check;
+ALTER TABLE stock_movements ADD CONSTRAINT stock_movements_type_check
+    CHECK (type IN ('receipt', 'adjustment', 'transfer', 'return'));
+
+CREATE TABLE serial_numbers (
+    id            UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    product_id    UUID NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    serial_number TEXT NOT NULL UNIQUE,
+    status        TEXT NOT NULL CHECK (status IN ('in_stock', 'at_client', 'returned', 'scrapped')),
+    client_id     UUID REFERENCES clients (id) ON DELETE SET NULL,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX serial_numbers_product_status_idx ON serial_numbers (product_id, status);
+
+-- Histórico de localização de cada número de série.
+CREATE TABLE serial_number_events (
+    id          BIGSERIAL PRIMARY KEY,
+    serial_id   UUID NOT NULL REFERENCES serial_numbers (id) ON DELETE CASCADE,
+    status      TEXT NOT NULL,
+    client_id   UUID REFERENCES clients (id) ON DELETE SET NULL,
+    movement_id UUID REFERENCES stock_movements (id) ON DELETE SET NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX serial_number_events_serial_id_idx ON serial_number_events (serial_id, created_at);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS products_sku_trgm_idx;
+DROP INDEX IF EXISTS products_name_trgm_idx;
+DROP INDEX IF EXISTS products_search_vector_idx;
+ALTER TABLE products DROP COLUMN IF EXISTS search_vector;
+DROP TEXT SEARCH CONFIGURATION IF EXISTS portuguese_unaccent;
+DROP FUNCTION IF EXISTS immutable_unaccent(text);
@@ -0,0 +1,24 @@
+-- Busca textual de produtos: full-text em português (com stemming e sem acentos)
+-- sobre SKU, nome e descrição, e similaridade por trigramas para tolerar erros de digitação.
+CREATE EXTENSION IF NOT EXISTS unaccent;
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- unaccent() é STABLE; o wrapper IMMUTABLE permite usá-lo em índices.
+CREATE OR REPLACE FUNCTION immutable_unaccent(text) RETURNS text
+    LANGUAGE sql IMMUTABLE PARALLEL SAFE STRICT
+    AS $$ SELECT public.unaccent('public.unaccent'::regdictionary, $1) $$;
+
+CREATE TEXT SEARCH CONFIGURATION portuguese_unaccent (COPY = portuguese);
+ALTER TEXT SEARCH CONFIGURATION portuguese_unaccent
+    ALTER MAPPING FOR hword, hword_part, word WITH unaccent, portuguese_stem;
+
+-- SKU e nome pesam mais (A) que a descrição (B) no ranking.
+ALTER TABLE products ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('portuguese_unaccent'::regconfig, COALESCE(sku, '')), 'A') ||
+    setweight(to_tsvector('portuguese_unaccent'::regconfig, name), 'A') ||
+    setweight(to_tsvector('portuguese_unaccent'::regconfig, COALESCE(description, '')), 'B')
+) STORED;
+
+CREATE INDEX products_search_vector_idx ON products USING GIN (search_vector);
+CREATE INDEX products_name_trgm_idx ON products USING GIN (immutable_unaccent(lower(name)) gin_trgm_ops);
+CREATE INDEX products_sku_trgm_idx ON products USING GIN (lower(sku) gin_trgm_ops);
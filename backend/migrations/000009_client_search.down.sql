@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS client_stocks_product_id_idx;
+DROP INDEX IF EXISTS clients_phone_digits_trgm_idx;
+DROP INDEX IF EXISTS clients_email_trgm_idx;
+DROP INDEX IF EXISTS clients_name_trgm_idx;
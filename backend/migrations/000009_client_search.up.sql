@@ -0,0 +1,9 @@
+-- Busca de clientes por trecho do nome, email ou telefone (ILIKE '%termo%').
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX clients_name_trgm_idx ON clients USING GIN (name gin_trgm_ops);
+CREATE INDEX clients_email_trgm_idx ON clients USING GIN (email gin_trgm_ops);
+CREATE INDEX clients_phone_digits_trgm_idx ON clients USING GIN ((regexp_replace(phone, '\D', '', 'g')) gin_trgm_ops);
+
+-- Filtro "clientes com saldo do produto X".
+CREATE INDEX client_stocks_product_id_idx ON client_stocks (product_id) WHERE quantity > 0;
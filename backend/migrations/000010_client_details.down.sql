@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS client_contacts;
+DROP TABLE IF EXISTS client_addresses;
+DROP INDEX IF EXISTS clients_document_key;
+ALTER TABLE clients DROP COLUMN IF EXISTS status, DROP COLUMN IF EXISTS document;
@@ -0,0 +1,34 @@
+-- Documento (CPF/CNPJ), situação, endereços e contatos dos clientes.
+ALTER TABLE clients
+    ADD COLUMN document TEXT,
+    ADD COLUMN status TEXT NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'blocked'));
+
+CREATE UNIQUE INDEX clients_document_key ON clients (document) WHERE document IS NOT NULL;
+
+CREATE TABLE client_addresses (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    client_id  UUID NOT NULL REFERENCES clients (id) ON DELETE CASCADE,
+    type       TEXT NOT NULL CHECK (type IN ('billing', 'delivery')),
+    street     TEXT NOT NULL,
+    number     TEXT NOT NULL DEFAULT '',
+    complement TEXT NOT NULL DEFAULT '',
+    district   TEXT NOT NULL DEFAULT '',
+    city       TEXT NOT NULL,
+    state      CHAR(2) NOT NULL,
+    cep        CHAR(8) NOT NULL CHECK (cep ~ '^[0-9]{8}$'),
+    position   INTEGER NOT NULL
+);
+
+CREATE INDEX client_addresses_client_id_idx ON client_addresses (client_id, position);
+
+CREATE TABLE client_contacts (
+    id        UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    client_id UUID NOT NULL REFERENCES clients (id) ON DELETE CASCADE,
+    name      TEXT NOT NULL,
+    email     TEXT NOT NULL DEFAULT '',
+    phone     TEXT NOT NULL DEFAULT '',
+    role      TEXT NOT NULL DEFAULT '',
+    position  INTEGER NOT NULL
+);
+
+CREATE INDEX client_contacts_client_id_idx ON client_contacts (client_id, position);
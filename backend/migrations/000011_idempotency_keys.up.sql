@@ -0,0 +1,14 @@
+-- Chaves de idempotência das requisições que alteram estoque ou criam recursos.
+-- A resposta é gravada na mesma transação da operação e repetida em novas tentativas.
+CREATE TABLE idempotency_keys (
+    scope         TEXT NOT NULL,  -- Usuário autenticado que enviou a chave
+    key           TEXT NOT NULL,
+    request_hash  TEXT NOT NULL,  -- SHA-256 de método, rota e corpo da requisição
+    status_code   INTEGER NOT NULL,
+    response_body BYTEA NOT NULL,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at    TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (scope, key)
+);
+
+CREATE INDEX idempotency_keys_expires_at_idx ON idempotency_keys (expires_at);
@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS stock_movements_notify ON stock_movements;
+DROP FUNCTION IF EXISTS notify_stock_movement();
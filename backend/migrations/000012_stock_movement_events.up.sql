@@ -0,0 +1,20 @@
+-- Publica cada movimentação de estoque no canal stock_movements, consumido pelo fluxo
+-- de eventos do servidor gRPC. O pg_notify só entrega a mensagem no COMMIT, então os
+-- assinantes nunca recebem movimentações desfeitas.
+CREATE FUNCTION notify_stock_movement() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_notify('stock_movements', json_build_object(
+        'id',         NEW.id,
+        'product_id', NEW.product_id,
+        'client_id',  NEW.client_id,
+        'type',       NEW.type,
+        'quantity',   NEW.quantity,
+        'created_at', NEW.created_at
+    )::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER stock_movements_notify
+    AFTER INSERT ON stock_movements
+    FOR EACH ROW EXECUTE FUNCTION notify_stock_movement();
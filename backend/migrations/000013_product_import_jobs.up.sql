@@ -0,0 +1,38 @@
+-- Importações de produtos em lote (CSV/XLSX). O arquivo fica guardado no próprio job
+-- até o fim do processamento, para que qualquer instância da API possa retomá-lo.
+CREATE TABLE import_jobs (
+    id             UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_by     UUID NOT NULL,
+    status         TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'running', 'completed', 'failed')),
+    dry_run        BOOLEAN NOT NULL DEFAULT FALSE,
+    file_name      TEXT NOT NULL,
+    format         TEXT NOT NULL CHECK (format IN ('csv', 'xlsx')),
+    mapping        JSONB NOT NULL DEFAULT '{}',  -- Campo do produto -> coluna do arquivo
+    file           BYTEA,                        -- Removido ao concluir o job
+    total_rows     INTEGER NOT NULL DEFAULT 0,
+    processed_rows INTEGER NOT NULL DEFAULT 0,
+    created_rows   INTEGER NOT NULL DEFAULT 0,
+    updated_rows   INTEGER NOT NULL DEFAULT 0,
+    unchanged_rows INTEGER NOT NULL DEFAULT 0,
+    error_rows     INTEGER NOT NULL DEFAULT 0,
+    failure        TEXT NOT NULL DEFAULT '',
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    started_at     TIMESTAMPTZ,
+    heartbeat_at   TIMESTAMPTZ,                  -- Atualizado a cada lote de linhas processadas
+    finished_at    TIMESTAMPTZ
+);
+
+CREATE INDEX import_jobs_queue_idx ON import_jobs (created_at) WHERE status IN ('pending', 'running');
+
+-- Resultado de cada linha do arquivo: a ação realizada (ou prevista, no dry-run),
+-- os campos alterados e os erros de validação.
+CREATE TABLE import_job_rows (
+    job_id     UUID NOT NULL REFERENCES import_jobs (id) ON DELETE CASCADE,
+    row_number INTEGER NOT NULL,  -- Linha do arquivo, contando o cabeçalho
+    sku        TEXT NOT NULL,
+    action     TEXT NOT NULL CHECK (action IN ('create', 'update', 'unchanged', 'error')),
+    product_id UUID,
+    changes    JSONB NOT NULL DEFAULT '[]',
+    errors     JSONB NOT NULL DEFAULT '[]',
+    PRIMARY KEY (job_id, row_number)
+);
@@ -0,0 +1,12 @@
+DROP TABLE IF EXISTS cost_layers;
+
+DROP INDEX IF EXISTS stock_movements_created_at_idx;
+
+ALTER TABLE stock_movements
+    DROP COLUMN IF EXISTS unit_price_in_cents,
+    DROP COLUMN IF EXISTS cost_in_cents;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS average_cost_in_cents,
+    DROP COLUMN IF EXISTS stock_value_in_cents,
+    DROP COLUMN IF EXISTS costing_method;
@@ -0,0 +1,30 @@
+-- Custeio do estoque. stock_value_in_cents é o custo do estoque global do produto e
+-- average_cost_in_cents, o custo médio unitário resultante (mantido quando o estoque
+-- zera, como custo padrão das próximas entradas sem custo informado). O estoque que
+-- já existia entra sem custo.
+ALTER TABLE products
+    ADD COLUMN costing_method        TEXT NOT NULL DEFAULT 'average' CHECK (costing_method IN ('average', 'fifo')),
+    ADD COLUMN stock_value_in_cents  BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN average_cost_in_cents BIGINT NOT NULL DEFAULT 0 CHECK (average_cost_in_cents >= 0);
+
+-- Custo total de cada movimentação, com o sinal da quantidade, e o preço de venda
+-- unitário nas transferências e devoluções, para o cálculo da margem. Movimentações
+-- anteriores ao custeio ficam sem custo.
+ALTER TABLE stock_movements
+    ADD COLUMN cost_in_cents       BIGINT,
+    ADD COLUMN unit_price_in_cents BIGINT;
+
+CREATE INDEX stock_movements_created_at_idx ON stock_movements (created_at) WHERE type IN ('transfer', 'return');
+
+-- Camadas de custo dos produtos com custeio PEPS (FIFO): cada entrada cria uma camada,
+-- e as saídas consomem as mais antigas. A soma de remaining é igual a products.quantity.
+CREATE TABLE cost_layers (
+    id                 UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    product_id         UUID NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    unit_cost_in_cents BIGINT NOT NULL CHECK (unit_cost_in_cents >= 0),
+    quantity           INTEGER NOT NULL CHECK (quantity > 0),
+    remaining          INTEGER NOT NULL CHECK (remaining >= 0 AND remaining <= quantity),
+    created_at         TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX cost_layers_open_idx ON cost_layers (product_id, created_at) WHERE remaining > 0;
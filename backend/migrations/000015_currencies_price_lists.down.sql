@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS client_price_lists;
+DROP TABLE IF EXISTS price_list_items;
+DROP TABLE IF EXISTS price_lists;
+
+ALTER TABLE stock_movements
+    DROP COLUMN IF EXISTS currency;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS currency;
@@ -0,0 +1,42 @@
+-- Moeda (ISO 4217) do preço e do custo de cada produto. Variantes seguem a moeda do pai,
+-- como o preço. Os valores continuam inteiros, na menor unidade da moeda.
+ALTER TABLE products
+    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'BRL' CHECK (currency ~ '^[A-Z]{3}$');
+
+-- Moeda do custo e do preço de venda registrados na movimentação (a do produto).
+ALTER TABLE stock_movements
+    ADD COLUMN currency CHAR(3);
+
+UPDATE stock_movements SET currency = 'BRL' WHERE cost_in_cents IS NOT NULL OR unit_price_in_cents IS NOT NULL;
+
+-- Tabelas de preços, vigentes em [valid_from, valid_to); limites nulos deixam o período aberto.
+CREATE TABLE price_lists (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name       TEXT NOT NULL UNIQUE,
+    currency   CHAR(3) NOT NULL CHECK (currency ~ '^[A-Z]{3}$'),
+    valid_from TIMESTAMPTZ,
+    valid_to   TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (valid_to > valid_from)
+);
+
+CREATE TABLE price_list_items (
+    price_list_id  UUID NOT NULL REFERENCES price_lists (id) ON DELETE CASCADE,
+    product_id     UUID NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    price_in_cents BIGINT NOT NULL CHECK (price_in_cents >= 0),
+    PRIMARY KEY (price_list_id, product_id)
+);
+
+CREATE INDEX price_list_items_product_idx ON price_list_items (product_id);
+
+-- Tabelas atribuídas a cada cliente. Entre as vigentes que listam o produto, prevalece
+-- a de início mais recente.
+CREATE TABLE client_price_lists (
+    client_id     UUID NOT NULL REFERENCES clients (id) ON DELETE CASCADE,
+    price_list_id UUID NOT NULL REFERENCES price_lists (id) ON DELETE CASCADE,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (client_id, price_list_id)
+);
+
+CREATE INDEX client_price_lists_price_list_idx ON client_price_lists (price_list_id);
@@ -0,0 +1,10 @@
+-- Moeda do custo registrado na movimentação (a do produto), separada da moeda do preço,
+-- que nas transferências e devoluções segue a tabela de preços do cliente. Nas demais
+-- movimentações as duas sempre coincidiram; nas de cliente, vale a moeda atual do produto.
+ALTER TABLE stock_movements
+    ADD COLUMN cost_currency CHAR(3);
+
+UPDATE stock_movements m
+SET cost_currency = CASE WHEN m.type IN ('transfer', 'return') THEN p.currency ELSE COALESCE(m.currency, p.currency) END
+FROM products p
+WHERE p.id = m.product_id AND m.cost_in_cents IS NOT NULL;
@@ -0,0 +1,5 @@
+-- Escapa &, <, > e aspas, como html.EscapeString do Go. Aplicado aos textos antes de
+-- ts_headline, para que os trechos destacados da busca só tenham as marcações <mark>.
+CREATE OR REPLACE FUNCTION html_escape(text) RETURNS text
+    LANGUAGE sql IMMUTABLE PARALLEL SAFE STRICT
+    AS $$ SELECT replace(replace(replace(replace(replace($1, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&#34;'), '''', '&#39;') $$;
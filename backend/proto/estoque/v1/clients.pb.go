@@ -0,0 +1,1144 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: estoque/v1/clients.proto
+
+package estoquev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ClientStatus int32
+
+const (
+	ClientStatus_CLIENT_STATUS_UNSPECIFIED ClientStatus = 0
+	ClientStatus_CLIENT_STATUS_ACTIVE      ClientStatus = 1
+	// Não recebe novas transferências.
+	ClientStatus_CLIENT_STATUS_BLOCKED ClientStatus = 2
+)
+
+// Enum value maps for ClientStatus.
+var (
+	ClientStatus_name = map[int32]string{
+		0: "CLIENT_STATUS_UNSPECIFIED",
+		1: "CLIENT_STATUS_ACTIVE",
+		2: "CLIENT_STATUS_BLOCKED",
+	}
+	ClientStatus_value = map[string]int32{
+		"CLIENT_STATUS_UNSPECIFIED": 0,
+		"CLIENT_STATUS_ACTIVE":      1,
+		"CLIENT_STATUS_BLOCKED":     2,
+	}
+)
+
+func (x ClientStatus) Enum() *ClientStatus {
+	p := new(ClientStatus)
+	*p = x
+	return p
+}
+
+func (x ClientStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ClientStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_estoque_v1_clients_proto_enumTypes[0].Descriptor()
+}
+
+func (ClientStatus) Type() protoreflect.EnumType {
+	return &file_estoque_v1_clients_proto_enumTypes[0]
+}
+
+func (x ClientStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ClientStatus.Descriptor instead.
+func (ClientStatus) EnumDescriptor() ([]byte, []int) {
+	return file_estoque_v1_clients_proto_rawDescGZIP(), []int{0}
+}
+
+type AddressType int32
+
+const (
+	AddressType_ADDRESS_TYPE_UNSPECIFIED AddressType = 0
+	AddressType_ADDRESS_TYPE_BILLING     AddressType = 1
+	AddressType_ADDRESS_TYPE_DELIVERY    AddressType = 2
+)
+
+// Enum value maps for AddressType.
+var (
+	AddressType_name = map[int32]string{
+		0: "ADDRESS_TYPE_UNSPECIFIED",
+		1: "ADDRESS_TYPE_BILLING",
+		2: "ADDRESS_TYPE_DELIVERY",
+	}
+	AddressType_value = map[string]int32{
+		"ADDRESS_TYPE_UNSPECIFIED": 0,
+		"ADDRESS_TYPE_BILLING":     1,
+		"ADDRESS_TYPE_DELIVERY":    2,
+	}
+)
+
+func (x AddressType) Enum() *AddressType {
+	p := new(AddressType)
+	*p = x
+	return p
+}
+
+func (x AddressType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AddressType) Descriptor() protoreflect.EnumDescriptor {
+	return file_estoque_v1_clients_proto_enumTypes[1].Descriptor()
+}
+
+func (AddressType) Type() protoreflect.EnumType {
+	return &file_estoque_v1_clients_proto_enumTypes[1]
+}
+
+func (x AddressType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AddressType.Descriptor instead.
+func (AddressType) EnumDescriptor() ([]byte, []int) {
+	return file_estoque_v1_clients_proto_rawDescGZIP(), []int{1}
+}
+
+type Client struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Campos somente leitura: ignorados na criação e na atualização.
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Name      string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Email     string                 `protobuf:"bytes,5,opt,name=email,proto3" json:"email,omitempty"`
+	Phone     string                 `protobuf:"bytes,6,opt,name=phone,proto3" json:"phone,omitempty"`
+	// CPF (11 dígitos) ou CNPJ (14 dígitos).
+	Document      string       `protobuf:"bytes,7,opt,name=document,proto3" json:"document,omitempty"`
+	Status        ClientStatus `protobuf:"varint,8,opt,name=status,proto3,enum=estoque.v1.ClientStatus" json:"status,omitempty"`
+	Addresses     []*Address   `protobuf:"bytes,9,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	Contacts      []*Contact   `protobuf:"bytes,10,rep,name=contacts,proto3" json:"contacts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Client) Reset() {
+	*x = Client{}
+	mi := &file_estoque_v1_clients_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Client) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Client) ProtoMessage() {}
+
+func (x *Client) ProtoReflect() protoreflect.Message {
+	mi := &file_estoque_v1_clients_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Client.ProtoReflect.Descriptor instead.
+func (*Client) Descriptor() ([]byte, []int) {
+	return file_estoque_v1_clients_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Client) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Client) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Client) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Client) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Client) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Client) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *Client) GetDocument() string {
+	if x != nil {
+		return x.Document
+	}
+	return ""
+}
+
+func (x *Client) GetStatus() ClientStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ClientStatus_CLIENT_STATUS_UNSPECIFIED
+}
+
+func (x *Client) GetAddresses() []*Address {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+func (x *Client) GetContacts() []*Contact {
+	if x != nil {
+		return x.Contacts
+	}
+	return nil
+}
+
+type Address struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type       AddressType            `protobuf:"varint,2,opt,name=type,proto3,enum=estoque.v1.AddressType" json:"type,omitempty"`
+	Street     string                 `protobuf:"bytes,3,opt,name=street,proto3" json:"street,omitempty"`
+	Number     string                 `protobuf:"bytes,4,opt,name=number,proto3" json:"number,omitempty"`
+	Complement string                 `protobuf:"bytes,5,opt,name=complement,proto3" json:"complement,omitempty"`
+	District   string                 `protobuf:"bytes,6,opt,name=district,proto3" json:"district,omitempty"`
+	City       string                 `protobuf:"bytes,7,opt,name=city,proto3" json:"city,omitempty"`
+	// UF, ex.: SP.
+	State         string `protobuf:"bytes,8,opt,name=state,proto3" json:"state,omitempty"`
+	Cep           string `protobuf:"bytes,9,opt,name=cep,proto3" json:"cep,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Address) Reset() {
+	*x = Address{}
+	mi := &file_estoque_v1_clients_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Address) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Address) ProtoMessage() {}
+
+func (x *Address) ProtoReflect() protoreflect.Message {
+	mi := &file_estoque_v1_clients_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Address.ProtoReflect.Descriptor instead.
+func (*Address) Descriptor() ([]byte, []int) {
+	return file_estoque_v1_clients_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Address) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Address) GetType() AddressType {
+	if x != nil {
+		return x.Type
+	}
+	return AddressType_ADDRESS_TYPE_UNSPECIFIED
+}
+
+func (x *Address) GetStreet() string {
+	if x != nil {
+		return x.Street
+	}
+	return ""
+}
+
+func (x *Address) GetNumber() string {
+	if x != nil {
+		return x.Number
+	}
+	return ""
+}
+
+func (x *Address) GetComplement() string {
+	if x != nil {
+		return x.Complement
+	}
+	return ""
+}
+
+func (x *Address) GetDistrict() string {
+	if x != nil {
+		return x.District
+	}
+	return ""
+}
+
+func (x *Address) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *Address) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *Address) GetCep() string {
+	if x != nil {
+		return x.Cep
+	}
+	return ""
+}
+
+type Contact struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Email string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Phone string                 `protobuf:"bytes,4,opt,name=phone,proto3" json:"phone,omitempty"`
+	// Cargo ou função, ex.: Compras.
+	Role          string `protobuf:"bytes,5,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Contact) Reset() {
+	*x = Contact{}
+	mi := &file_estoque_v1_clients_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Contact) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Contact) ProtoMessage() {}
+
+func (x *Contact) ProtoReflect() protoreflect.Message {
+	mi := &file_estoque_v1_clients_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Contact.ProtoReflect.Descriptor instead.
+func (*Contact) Descriptor() ([]byte, []int) {
+	return file_estoque_v1_clients_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Contact) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Contact) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Contact) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Contact) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *Contact) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type CreateClientRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Client        *Client                `protobuf:"bytes,1,opt,name=client,proto3" json:"client,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateClientRequest) Reset() {
+	*x = CreateClientRequest{}
+	mi := &file_estoque_v1_clients_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateClientRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateClientRequest) ProtoMessage() {}
+
+func (x *CreateClientRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_estoque_v1_clients_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateClientRequest.ProtoReflect.Descriptor instead.
+func (*CreateClientRequest) Descriptor() ([]byte, []int) {
+	return file_estoque_v1_clients_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateClientRequest) GetClient() *Client {
+	if x != nil {
+		return x.Client
+	}
+	return nil
+}
+
+type GetClientRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetClientRequest) Reset() {
+	*x = GetClientRequest{}
+	mi := &file_estoque_v1_clients_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetClientRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetClientRequest) ProtoMessage() {}
+
+func (x *GetClientRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_estoque_v1_clients_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetClientRequest.ProtoReflect.Descriptor instead.
+func (*GetClientRequest) Descriptor() ([]byte, []int) {
+	return file_estoque_v1_clients_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetClientRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListClientsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Itens por página: padrão 10, máximo 100.
+	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token da página anterior; vazio na primeira página.
+	PageToken string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Trecho do nome, email, telefone ou documento.
+	Search string       `protobuf:"bytes,3,opt,name=search,proto3" json:"search,omitempty"`
+	Status ClientStatus `protobuf:"varint,4,opt,name=status,proto3,enum=estoque.v1.ClientStatus" json:"status,omitempty"`
+	// Apenas clientes com saldo deste produto.
+	HoldingProductId string `protobuf:"bytes,5,opt,name=holding_product_id,json=holdingProductId,proto3" json:"holding_product_id,omitempty"`
+	// Apenas clientes com saldo de qualquer produto.
+	WithStock     bool `protobuf:"varint,6,opt,name=with_stock,json=withStock,proto3" json:"with_stock,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListClientsRequest) Reset() {
+	*x = ListClientsRequest{}
+	mi := &file_estoque_v1_clients_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListClientsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListClientsRequest) ProtoMessage() {}
+
+func (x *ListClientsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_estoque_v1_clients_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListClientsRequest.ProtoReflect.Descriptor instead.
+func (*ListClientsRequest) Descriptor() ([]byte, []int) {
+	return file_estoque_v1_clients_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListClientsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListClientsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListClientsRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+func (x *ListClientsRequest) GetStatus() ClientStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ClientStatus_CLIENT_STATUS_UNSPECIFIED
+}
+
+func (x *ListClientsRequest) GetHoldingProductId() string {
+	if x != nil {
+		return x.HoldingProductId
+	}
+	return ""
+}
+
+func (x *ListClientsRequest) GetWithStock() bool {
+	if x != nil {
+		return x.WithStock
+	}
+	return false
+}
+
+type ListClientsResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Clients []*Client              `protobuf:"bytes,1,rep,name=clients,proto3" json:"clients,omitempty"`
+	// Vazio na última página.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// Total de clientes; calculado apenas na primeira página.
+	TotalSize     *int32 `protobuf:"varint,3,opt,name=total_size,json=totalSize,proto3,oneof" json:"total_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListClientsResponse) Reset() {
+	*x = ListClientsResponse{}
+	mi := &file_estoque_v1_clients_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListClientsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListClientsResponse) ProtoMessage() {}
+
+func (x *ListClientsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_estoque_v1_clients_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListClientsResponse.ProtoReflect.Descriptor instead.
+func (*ListClientsResponse) Descriptor() ([]byte, []int) {
+	return file_estoque_v1_clients_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListClientsResponse) GetClients() []*Client {
+	if x != nil {
+		return x.Clients
+	}
+	return nil
+}
+
+func (x *ListClientsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListClientsResponse) GetTotalSize() int32 {
+	if x != nil && x.TotalSize != nil {
+		return *x.TotalSize
+	}
+	return 0
+}
+
+type UpdateClientRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Client        *Client                `protobuf:"bytes,2,opt,name=client,proto3" json:"client,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateClientRequest) Reset() {
+	*x = UpdateClientRequest{}
+	mi := &file_estoque_v1_clients_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateClientRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateClientRequest) ProtoMessage() {}
+
+func (x *UpdateClientRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_estoque_v1_clients_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateClientRequest.ProtoReflect.Descriptor instead.
+func (*UpdateClientRequest) Descriptor() ([]byte, []int) {
+	return file_estoque_v1_clients_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UpdateClientRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateClientRequest) GetClient() *Client {
+	if x != nil {
+		return x.Client
+	}
+	return nil
+}
+
+type DeleteClientRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteClientRequest) Reset() {
+	*x = DeleteClientRequest{}
+	mi := &file_estoque_v1_clients_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteClientRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteClientRequest) ProtoMessage() {}
+
+func (x *DeleteClientRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_estoque_v1_clients_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteClientRequest.ProtoReflect.Descriptor instead.
+func (*DeleteClientRequest) Descriptor() ([]byte, []int) {
+	return file_estoque_v1_clients_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeleteClientRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListClientStockRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	ClientId string                 `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	// Itens por página: padrão 10, máximo 100.
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token da página anterior; vazio na primeira página.
+	PageToken     string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListClientStockRequest) Reset() {
+	*x = ListClientStockRequest{}
+	mi := &file_estoque_v1_clients_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListClientStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListClientStockRequest) ProtoMessage() {}
+
+func (x *ListClientStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_estoque_v1_clients_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListClientStockRequest.ProtoReflect.Descriptor instead.
+func (*ListClientStockRequest) Descriptor() ([]byte, []int) {
+	return file_estoque_v1_clients_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListClientStockRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *ListClientStockRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListClientStockRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListClientStockResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Items []*ClientStockItem     `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	// Vazio na última página.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// Total de itens; calculado apenas na primeira página.
+	TotalSize     *int32 `protobuf:"varint,3,opt,name=total_size,json=totalSize,proto3,oneof" json:"total_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListClientStockResponse) Reset() {
+	*x = ListClientStockResponse{}
+	mi := &file_estoque_v1_clients_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListClientStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListClientStockResponse) ProtoMessage() {}
+
+func (x *ListClientStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_estoque_v1_clients_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListClientStockResponse.ProtoReflect.Descriptor instead.
+func (*ListClientStockResponse) Descriptor() ([]byte, []int) {
+	return file_estoque_v1_clients_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListClientStockResponse) GetItems() []*ClientStockItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ListClientStockResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListClientStockResponse) GetTotalSize() int32 {
+	if x != nil && x.TotalSize != nil {
+		return *x.TotalSize
+	}
+	return 0
+}
+
+// ClientStockItem é o saldo de um produto em poder do cliente.
+type ClientStockItem struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	ProductId   string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	ProductName string                 `protobuf:"bytes,2,opt,name=product_name,json=productName,proto3" json:"product_name,omitempty"`
+	Quantity    int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// Lotes recebidos, para produtos com controle de lote.
+	Lots          []*LotAllocation `protobuf:"bytes,4,rep,name=lots,proto3" json:"lots,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClientStockItem) Reset() {
+	*x = ClientStockItem{}
+	mi := &file_estoque_v1_clients_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClientStockItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClientStockItem) ProtoMessage() {}
+
+func (x *ClientStockItem) ProtoReflect() protoreflect.Message {
+	mi := &file_estoque_v1_clients_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClientStockItem.ProtoReflect.Descriptor instead.
+func (*ClientStockItem) Descriptor() ([]byte, []int) {
+	return file_estoque_v1_clients_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ClientStockItem) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ClientStockItem) GetProductName() string {
+	if x != nil {
+		return x.ProductName
+	}
+	return ""
+}
+
+func (x *ClientStockItem) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *ClientStockItem) GetLots() []*LotAllocation {
+	if x != nil {
+		return x.Lots
+	}
+	return nil
+}
+
+var File_estoque_v1_clients_proto protoreflect.FileDescriptor
+
+const file_estoque_v1_clients_proto_rawDesc = "" +
+	"\n" +
+	"\x18estoque/v1/clients.proto\x12\n" +
+	"estoque.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x16estoque/v1/stock.proto\"\x80\x03\n" +
+	"\x06Client\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x129\n" +
+	"\n" +
+	"created_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x12\x14\n" +
+	"\x05email\x18\x05 \x01(\tR\x05email\x12\x14\n" +
+	"\x05phone\x18\x06 \x01(\tR\x05phone\x12\x1a\n" +
+	"\bdocument\x18\a \x01(\tR\bdocument\x120\n" +
+	"\x06status\x18\b \x01(\x0e2\x18.estoque.v1.ClientStatusR\x06status\x121\n" +
+	"\taddresses\x18\t \x03(\v2\x13.estoque.v1.AddressR\taddresses\x12/\n" +
+	"\bcontacts\x18\n" +
+	" \x03(\v2\x13.estoque.v1.ContactR\bcontacts\"\xee\x01\n" +
+	"\aAddress\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12+\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x17.estoque.v1.AddressTypeR\x04type\x12\x16\n" +
+	"\x06street\x18\x03 \x01(\tR\x06street\x12\x16\n" +
+	"\x06number\x18\x04 \x01(\tR\x06number\x12\x1e\n" +
+	"\n" +
+	"complement\x18\x05 \x01(\tR\n" +
+	"complement\x12\x1a\n" +
+	"\bdistrict\x18\x06 \x01(\tR\bdistrict\x12\x12\n" +
+	"\x04city\x18\a \x01(\tR\x04city\x12\x14\n" +
+	"\x05state\x18\b \x01(\tR\x05state\x12\x10\n" +
+	"\x03cep\x18\t \x01(\tR\x03cep\"m\n" +
+	"\aContact\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12\x14\n" +
+	"\x05phone\x18\x04 \x01(\tR\x05phone\x12\x12\n" +
+	"\x04role\x18\x05 \x01(\tR\x04role\"A\n" +
+	"\x13CreateClientRequest\x12*\n" +
+	"\x06client\x18\x01 \x01(\v2\x12.estoque.v1.ClientR\x06client\"\"\n" +
+	"\x10GetClientRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xe7\x01\n" +
+	"\x12ListClientsRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12\x16\n" +
+	"\x06search\x18\x03 \x01(\tR\x06search\x120\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x18.estoque.v1.ClientStatusR\x06status\x12,\n" +
+	"\x12holding_product_id\x18\x05 \x01(\tR\x10holdingProductId\x12\x1d\n" +
+	"\n" +
+	"with_stock\x18\x06 \x01(\bR\twithStock\"\x9e\x01\n" +
+	"\x13ListClientsResponse\x12,\n" +
+	"\aclients\x18\x01 \x03(\v2\x12.estoque.v1.ClientR\aclients\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\"\n" +
+	"\n" +
+	"total_size\x18\x03 \x01(\x05H\x00R\ttotalSize\x88\x01\x01B\r\n" +
+	"\v_total_size\"Q\n" +
+	"\x13UpdateClientRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12*\n" +
+	"\x06client\x18\x02 \x01(\v2\x12.estoque.v1.ClientR\x06client\"%\n" +
+	"\x13DeleteClientRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"q\n" +
+	"\x16ListClientStockRequest\x12\x1b\n" +
+	"\tclient_id\x18\x01 \x01(\tR\bclientId\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"\xa7\x01\n" +
+	"\x17ListClientStockResponse\x121\n" +
+	"\x05items\x18\x01 \x03(\v2\x1b.estoque.v1.ClientStockItemR\x05items\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\"\n" +
+	"\n" +
+	"total_size\x18\x03 \x01(\x05H\x00R\ttotalSize\x88\x01\x01B\r\n" +
+	"\v_total_size\"\x9e\x01\n" +
+	"\x0fClientStockItem\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12!\n" +
+	"\fproduct_name\x18\x02 \x01(\tR\vproductName\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\x12-\n" +
+	"\x04lots\x18\x04 \x03(\v2\x19.estoque.v1.LotAllocationR\x04lots*b\n" +
+	"\fClientStatus\x12\x1d\n" +
+	"\x19CLIENT_STATUS_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14CLIENT_STATUS_ACTIVE\x10\x01\x12\x19\n" +
+	"\x15CLIENT_STATUS_BLOCKED\x10\x02*`\n" +
+	"\vAddressType\x12\x1c\n" +
+	"\x18ADDRESS_TYPE_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14ADDRESS_TYPE_BILLING\x10\x01\x12\x19\n" +
+	"\x15ADDRESS_TYPE_DELIVERY\x10\x022\xcd\x03\n" +
+	"\rClientService\x12C\n" +
+	"\fCreateClient\x12\x1f.estoque.v1.CreateClientRequest\x1a\x12.estoque.v1.Client\x12=\n" +
+	"\tGetClient\x12\x1c.estoque.v1.GetClientRequest\x1a\x12.estoque.v1.Client\x12N\n" +
+	"\vListClients\x12\x1e.estoque.v1.ListClientsRequest\x1a\x1f.estoque.v1.ListClientsResponse\x12C\n" +
+	"\fUpdateClient\x12\x1f.estoque.v1.UpdateClientRequest\x1a\x12.estoque.v1.Client\x12G\n" +
+	"\fDeleteClient\x12\x1f.estoque.v1.DeleteClientRequest\x1a\x16.google.protobuf.Empty\x12Z\n" +
+	"\x0fListClientStock\x12\".estoque.v1.ListClientStockRequest\x1a#.estoque.v1.ListClientStockResponseB8Z6controle-de-estoque/backend/proto/estoque/v1;estoquev1b\x06proto3"
+
+var (
+	file_estoque_v1_clients_proto_rawDescOnce sync.Once
+	file_estoque_v1_clients_proto_rawDescData []byte
+)
+
+func file_estoque_v1_clients_proto_rawDescGZIP() []byte {
+	file_estoque_v1_clients_proto_rawDescOnce.Do(func() {
+		file_estoque_v1_clients_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_estoque_v1_clients_proto_rawDesc), len(file_estoque_v1_clients_proto_rawDesc)))
+	})
+	return file_estoque_v1_clients_proto_rawDescData
+}
+
+var file_estoque_v1_clients_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_estoque_v1_clients_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_estoque_v1_clients_proto_goTypes = []any{
+	(ClientStatus)(0),               // 0: estoque.v1.ClientStatus
+	(AddressType)(0),                // 1: estoque.v1.AddressType
+	(*Client)(nil),                  // 2: estoque.v1.Client
+	(*Address)(nil),                 // 3: estoque.v1.Address
+	(*Contact)(nil),                 // 4: estoque.v1.Contact
+	(*CreateClientRequest)(nil),     // 5: estoque.v1.CreateClientRequest
+	(*GetClientRequest)(nil),        // 6: estoque.v1.GetClientRequest
+	(*ListClientsRequest)(nil),      // 7: estoque.v1.ListClientsRequest
+	(*ListClientsResponse)(nil),     // 8: estoque.v1.ListClientsResponse
+	(*UpdateClientRequest)(nil),     // 9: estoque.v1.UpdateClientRequest
+	(*DeleteClientRequest)(nil),     // 10: estoque.v1.DeleteClientRequest
+	(*ListClientStockRequest)(nil),  // 11: estoque.v1.ListClientStockRequest
+	(*ListClientStockResponse)(nil), // 12: estoque.v1.ListClientStockResponse
+	(*ClientStockItem)(nil),         // 13: estoque.v1.ClientStockItem
+	(*timestamppb.Timestamp)(nil),   // 14: google.protobuf.Timestamp
+	(*LotAllocation)(nil),           // 15: estoque.v1.LotAllocation
+	(*emptypb.Empty)(nil),           // 16: google.protobuf.Empty
+}
+var file_estoque_v1_clients_proto_depIdxs = []int32{
+	14, // 0: estoque.v1.Client.created_at:type_name -> google.protobuf.Timestamp
+	14, // 1: estoque.v1.Client.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 2: estoque.v1.Client.status:type_name -> estoque.v1.ClientStatus
+	3,  // 3: estoque.v1.Client.addresses:type_name -> estoque.v1.Address
+	4,  // 4: estoque.v1.Client.contacts:type_name -> estoque.v1.Contact
+	1,  // 5: estoque.v1.Address.type:type_name -> estoque.v1.AddressType
+	2,  // 6: estoque.v1.CreateClientRequest.client:type_name -> estoque.v1.Client
+	0,  // 7: estoque.v1.ListClientsRequest.status:type_name -> estoque.v1.ClientStatus
+	2,  // 8: estoque.v1.ListClientsResponse.clients:type_name -> estoque.v1.Client
+	2,  // 9: estoque.v1.UpdateClientRequest.client:type_name -> estoque.v1.Client
+	13, // 10: estoque.v1.ListClientStockResponse.items:type_name -> estoque.v1.ClientStockItem
+	15, // 11: estoque.v1.ClientStockItem.lots:type_name -> estoque.v1.LotAllocation
+	5,  // 12: estoque.v1.ClientService.CreateClient:input_type -> estoque.v1.CreateClientRequest
+	6,  // 13: estoque.v1.ClientService.GetClient:input_type -> estoque.v1.GetClientRequest
+	7,  // 14: estoque.v1.ClientService.ListClients:input_type -> estoque.v1.ListClientsRequest
+	9,  // 15: estoque.v1.ClientService.UpdateClient:input_type -> estoque.v1.UpdateClientRequest
+	10, // 16: estoque.v1.ClientService.DeleteClient:input_type -> estoque.v1.DeleteClientRequest
+	11, // 17: estoque.v1.ClientService.ListClientStock:input_type -> estoque.v1.ListClientStockRequest
+	2,  // 18: estoque.v1.ClientService.CreateClient:output_type -> estoque.v1.Client
+	2,  // 19: estoque.v1.ClientService.GetClient:output_type -> estoque.v1.Client
+	8,  // 20: estoque.v1.ClientService.ListClients:output_type -> estoque.v1.ListClientsResponse
+	2,  // 21: estoque.v1.ClientService.UpdateClient:output_type -> estoque.v1.Client
+	16, // 22: estoque.v1.ClientService.DeleteClient:output_type -> google.protobuf.Empty
+	12, // 23: estoque.v1.ClientService.ListClientStock:output_type -> estoque.v1.ListClientStockResponse
+	18, // [18:24] is the sub-list for method output_type
+	12, // [12:18] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
+}
+
+func init() { file_estoque_v1_clients_proto_init() }
+func file_estoque_v1_clients_proto_init() {
+	if File_estoque_v1_clients_proto != nil {
+		return
+	}
+	file_estoque_v1_stock_proto_init()
+	file_estoque_v1_clients_proto_msgTypes[6].OneofWrappers = []any{}
+	file_estoque_v1_clients_proto_msgTypes[10].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_estoque_v1_clients_proto_rawDesc), len(file_estoque_v1_clients_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_estoque_v1_clients_proto_goTypes,
+		DependencyIndexes: file_estoque_v1_clients_proto_depIdxs,
+		EnumInfos:         file_estoque_v1_clients_proto_enumTypes,
+		MessageInfos:      file_estoque_v1_clients_proto_msgTypes,
+	}.Build()
+	File_estoque_v1_clients_proto = out.File
+	file_estoque_v1_clients_proto_goTypes = nil
+	file_estoque_v1_clients_proto_depIdxs = nil
+}
@@ -0,0 +1,853 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: estoque/v1/products.proto
+
+package estoquev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CostingMethod int32
+
+const (
+	CostingMethod_COSTING_METHOD_UNSPECIFIED CostingMethod = 0
+	// Custo médio ponderado.
+	CostingMethod_COSTING_METHOD_AVERAGE CostingMethod = 1
+	// PEPS: as saídas consomem as entradas mais antigas.
+	CostingMethod_COSTING_METHOD_FIFO CostingMethod = 2
+)
+
+// Enum value maps for CostingMethod.
+var (
+	CostingMethod_name = map[int32]string{
+		0: "COSTING_METHOD_UNSPECIFIED",
+		1: "COSTING_METHOD_AVERAGE",
+		2: "COSTING_METHOD_FIFO",
+	}
+	CostingMethod_value = map[string]int32{
+		"COSTING_METHOD_UNSPECIFIED": 0,
+		"COSTING_METHOD_AVERAGE":     1,
+		"COSTING_METHOD_FIFO":        2,
+	}
+)
+
+func (x CostingMethod) Enum() *CostingMethod {
+	p := new(CostingMethod)
+	*p = x
+	return p
+}
+
+func (x CostingMethod) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CostingMethod) Descriptor() protoreflect.EnumDescriptor {
+	return file_estoque_v1_products_proto_enumTypes[0].Descriptor()
+}
+
+func (CostingMethod) Type() protoreflect.EnumType {
+	return &file_estoque_v1_products_proto_enumTypes[0]
+}
+
+func (x CostingMethod) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CostingMethod.Descriptor instead.
+func (CostingMethod) EnumDescriptor() ([]byte, []int) {
+	return file_estoque_v1_products_proto_rawDescGZIP(), []int{0}
+}
+
+type Product struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Campos somente leitura: ignorados na criação e na atualização.
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ParentId       *string                `protobuf:"bytes,2,opt,name=parent_id,json=parentId,proto3,oneof" json:"parent_id,omitempty"`
+	VariantOptions map[string]string      `protobuf:"bytes,3,rep,name=variant_options,json=variantOptions,proto3" json:"variant_options,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Variants       []*Product             `protobuf:"bytes,4,rep,name=variants,proto3" json:"variants,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Sku            string                 `protobuf:"bytes,7,opt,name=sku,proto3" json:"sku,omitempty"`
+	CategoryId     *string                `protobuf:"bytes,8,opt,name=category_id,json=categoryId,proto3,oneof" json:"category_id,omitempty"`
+	Name           string                 `protobuf:"bytes,9,opt,name=name,proto3" json:"name,omitempty"`
+	Description    string                 `protobuf:"bytes,10,opt,name=description,proto3" json:"description,omitempty"`
+	PriceInCents   int64                  `protobuf:"varint,11,opt,name=price_in_cents,json=priceInCents,proto3" json:"price_in_cents,omitempty"`
+	// Saldo do estoque global na unidade base.
+	Quantity int32 `protobuf:"varint,12,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// Unidade de medida: UN, CX, PCT, KG, G, L, ML ou M.
+	Unit       string           `protobuf:"bytes,13,opt,name=unit,proto3" json:"unit,omitempty"`
+	Barcodes   []string         `protobuf:"bytes,14,rep,name=barcodes,proto3" json:"barcodes,omitempty"`
+	Tags       []string         `protobuf:"bytes,15,rep,name=tags,proto3" json:"tags,omitempty"`
+	Attributes *structpb.Struct `protobuf:"bytes,16,opt,name=attributes,proto3" json:"attributes,omitempty"`
+	TrackLots  bool             `protobuf:"varint,17,opt,name=track_lots,json=trackLots,proto3" json:"track_lots,omitempty"`
+	Serialized bool             `protobuf:"varint,18,opt,name=serialized,proto3" json:"serialized,omitempty"`
+	// Padrão: custo médio.
+	CostingMethod CostingMethod `protobuf:"varint,19,opt,name=costing_method,json=costingMethod,proto3,enum=estoque.v1.CostingMethod" json:"costing_method,omitempty"`
+	// Moeda (ISO 4217) do preço e do custo; padrão BRL. Variantes seguem a do pai.
+	Currency string `protobuf:"bytes,20,opt,name=currency,proto3" json:"currency,omitempty"`
+	// Preço da variante; sem ele, vale o do pai.
+	PriceOverrideInCents *int64 `protobuf:"varint,21,opt,name=price_override_in_cents,json=priceOverrideInCents,proto3,oneof" json:"price_override_in_cents,omitempty"`
+	// Somente leitura: valor do estoque global e custo médio por unidade base.
+	StockValueInCents  int64 `protobuf:"varint,22,opt,name=stock_value_in_cents,json=stockValueInCents,proto3" json:"stock_value_in_cents,omitempty"`
+	AverageCostInCents int64 `protobuf:"varint,23,opt,name=average_cost_in_cents,json=averageCostInCents,proto3" json:"average_cost_in_cents,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *Product) Reset() {
+	*x = Product{}
+	mi := &file_estoque_v1_products_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Product) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Product) ProtoMessage() {}
+
+func (x *Product) ProtoReflect() protoreflect.Message {
+	mi := &file_estoque_v1_products_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Product.ProtoReflect.Descriptor instead.
+func (*Product) Descriptor() ([]byte, []int) {
+	return file_estoque_v1_products_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Product) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Product) GetParentId() string {
+	if x != nil && x.ParentId != nil {
+		return *x.ParentId
+	}
+	return ""
+}
+
+func (x *Product) GetVariantOptions() map[string]string {
+	if x != nil {
+		return x.VariantOptions
+	}
+	return nil
+}
+
+func (x *Product) GetVariants() []*Product {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+func (x *Product) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Product) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Product) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *Product) GetCategoryId() string {
+	if x != nil && x.CategoryId != nil {
+		return *x.CategoryId
+	}
+	return ""
+}
+
+func (x *Product) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Product) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Product) GetPriceInCents() int64 {
+	if x != nil {
+		return x.PriceInCents
+	}
+	return 0
+}
+
+func (x *Product) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *Product) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+func (x *Product) GetBarcodes() []string {
+	if x != nil {
+		return x.Barcodes
+	}
+	return nil
+}
+
+func (x *Product) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Product) GetAttributes() *structpb.Struct {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *Product) GetTrackLots() bool {
+	if x != nil {
+		return x.TrackLots
+	}
+	return false
+}
+
+func (x *Product) GetSerialized() bool {
+	if x != nil {
+		return x.Serialized
+	}
+	return false
+}
+
+func (x *Product) GetCostingMethod() CostingMethod {
+	if x != nil {
+		return x.CostingMethod
+	}
+	return CostingMethod_COSTING_METHOD_UNSPECIFIED
+}
+
+func (x *Product) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Product) GetPriceOverrideInCents() int64 {
+	if x != nil && x.PriceOverrideInCents != nil {
+		return *x.PriceOverrideInCents
+	}
+	return 0
+}
+
+func (x *Product) GetStockValueInCents() int64 {
+	if x != nil {
+		return x.StockValueInCents
+	}
+	return 0
+}
+
+func (x *Product) GetAverageCostInCents() int64 {
+	if x != nil {
+		return x.AverageCostInCents
+	}
+	return 0
+}
+
+type CreateProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateProductRequest) Reset() {
+	*x = CreateProductRequest{}
+	mi := &file_estoque_v1_products_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateProductRequest) ProtoMessage() {}
+
+func (x *CreateProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_estoque_v1_products_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateProductRequest.ProtoReflect.Descriptor instead.
+func (*CreateProductRequest) Descriptor() ([]byte, []int) {
+	return file_estoque_v1_products_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateProductRequest) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+type GetProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductRequest) Reset() {
+	*x = GetProductRequest{}
+	mi := &file_estoque_v1_products_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductRequest) ProtoMessage() {}
+
+func (x *GetProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_estoque_v1_products_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductRequest.ProtoReflect.Descriptor instead.
+func (*GetProductRequest) Descriptor() ([]byte, []int) {
+	return file_estoque_v1_products_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetProductRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type LookupProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Barcode       string                 `protobuf:"bytes,1,opt,name=barcode,proto3" json:"barcode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LookupProductRequest) Reset() {
+	*x = LookupProductRequest{}
+	mi := &file_estoque_v1_products_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LookupProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookupProductRequest) ProtoMessage() {}
+
+func (x *LookupProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_estoque_v1_products_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookupProductRequest.ProtoReflect.Descriptor instead.
+func (*LookupProductRequest) Descriptor() ([]byte, []int) {
+	return file_estoque_v1_products_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *LookupProductRequest) GetBarcode() string {
+	if x != nil {
+		return x.Barcode
+	}
+	return ""
+}
+
+type ListProductsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Itens por página: padrão 10, máximo 100.
+	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token da página anterior; vazio na primeira página.
+	PageToken string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Busca textual em SKU, nome e descrição.
+	Search string `protobuf:"bytes,3,opt,name=search,proto3" json:"search,omitempty"`
+	// Categoria, incluindo as descendentes.
+	CategoryId string `protobuf:"bytes,4,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	// Tags que o produto deve possuir (todas).
+	Tags []string `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Lista também as variantes, além dos produtos pai e simples.
+	IncludeVariants bool `protobuf:"varint,6,opt,name=include_variants,json=includeVariants,proto3" json:"include_variants,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListProductsRequest) Reset() {
+	*x = ListProductsRequest{}
+	mi := &file_estoque_v1_products_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductsRequest) ProtoMessage() {}
+
+func (x *ListProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_estoque_v1_products_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductsRequest.ProtoReflect.Descriptor instead.
+func (*ListProductsRequest) Descriptor() ([]byte, []int) {
+	return file_estoque_v1_products_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListProductsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListProductsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ListProductsRequest) GetIncludeVariants() bool {
+	if x != nil {
+		return x.IncludeVariants
+	}
+	return false
+}
+
+type ListProductsResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Products []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	// Vazio na última página.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// Total de produtos; calculado apenas na primeira página.
+	TotalSize     *int32 `protobuf:"varint,3,opt,name=total_size,json=totalSize,proto3,oneof" json:"total_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProductsResponse) Reset() {
+	*x = ListProductsResponse{}
+	mi := &file_estoque_v1_products_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductsResponse) ProtoMessage() {}
+
+func (x *ListProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_estoque_v1_products_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductsResponse.ProtoReflect.Descriptor instead.
+func (*ListProductsResponse) Descriptor() ([]byte, []int) {
+	return file_estoque_v1_products_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListProductsResponse) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+func (x *ListProductsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListProductsResponse) GetTotalSize() int32 {
+	if x != nil && x.TotalSize != nil {
+		return *x.TotalSize
+	}
+	return 0
+}
+
+type UpdateProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Product       *Product               `protobuf:"bytes,2,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateProductRequest) Reset() {
+	*x = UpdateProductRequest{}
+	mi := &file_estoque_v1_products_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProductRequest) ProtoMessage() {}
+
+func (x *UpdateProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_estoque_v1_products_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProductRequest.ProtoReflect.Descriptor instead.
+func (*UpdateProductRequest) Descriptor() ([]byte, []int) {
+	return file_estoque_v1_products_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdateProductRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateProductRequest) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+type DeleteProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProductRequest) Reset() {
+	*x = DeleteProductRequest{}
+	mi := &file_estoque_v1_products_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProductRequest) ProtoMessage() {}
+
+func (x *DeleteProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_estoque_v1_products_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProductRequest.ProtoReflect.Descriptor instead.
+func (*DeleteProductRequest) Descriptor() ([]byte, []int) {
+	return file_estoque_v1_products_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeleteProductRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+var File_estoque_v1_products_proto protoreflect.FileDescriptor
+
+const file_estoque_v1_products_proto_rawDesc = "" +
+	"\n" +
+	"\x19estoque/v1/products.proto\x12\n" +
+	"estoque.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x9b\b\n" +
+	"\aProduct\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12 \n" +
+	"\tparent_id\x18\x02 \x01(\tH\x00R\bparentId\x88\x01\x01\x12P\n" +
+	"\x0fvariant_options\x18\x03 \x03(\v2'.estoque.v1.Product.VariantOptionsEntryR\x0evariantOptions\x12/\n" +
+	"\bvariants\x18\x04 \x03(\v2\x13.estoque.v1.ProductR\bvariants\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x10\n" +
+	"\x03sku\x18\a \x01(\tR\x03sku\x12$\n" +
+	"\vcategory_id\x18\b \x01(\tH\x01R\n" +
+	"categoryId\x88\x01\x01\x12\x12\n" +
+	"\x04name\x18\t \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\n" +
+	" \x01(\tR\vdescription\x12$\n" +
+	"\x0eprice_in_cents\x18\v \x01(\x03R\fpriceInCents\x12\x1a\n" +
+	"\bquantity\x18\f \x01(\x05R\bquantity\x12\x12\n" +
+	"\x04unit\x18\r \x01(\tR\x04unit\x12\x1a\n" +
+	"\bbarcodes\x18\x0e \x03(\tR\bbarcodes\x12\x12\n" +
+	"\x04tags\x18\x0f \x03(\tR\x04tags\x127\n" +
+	"\n" +
+	"attributes\x18\x10 \x01(\v2\x17.google.protobuf.StructR\n" +
+	"attributes\x12\x1d\n" +
+	"\n" +
+	"track_lots\x18\x11 \x01(\bR\ttrackLots\x12\x1e\n" +
+	"\n" +
+	"serialized\x18\x12 \x01(\bR\n" +
+	"serialized\x12@\n" +
+	"\x0ecosting_method\x18\x13 \x01(\x0e2\x19.estoque.v1.CostingMethodR\rcostingMethod\x12\x1a\n" +
+	"\bcurrency\x18\x14 \x01(\tR\bcurrency\x12:\n" +
+	"\x17price_override_in_cents\x18\x15 \x01(\x03H\x02R\x14priceOverrideInCents\x88\x01\x01\x12/\n" +
+	"\x14stock_value_in_cents\x18\x16 \x01(\x03R\x11stockValueInCents\x121\n" +
+	"\x15average_cost_in_cents\x18\x17 \x01(\x03R\x12averageCostInCents\x1aA\n" +
+	"\x13VariantOptionsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\f\n" +
+	"\n" +
+	"_parent_idB\x0e\n" +
+	"\f_category_idB\x1a\n" +
+	"\x18_price_override_in_cents\"E\n" +
+	"\x14CreateProductRequest\x12-\n" +
+	"\aproduct\x18\x01 \x01(\v2\x13.estoque.v1.ProductR\aproduct\"#\n" +
+	"\x11GetProductRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"0\n" +
+	"\x14LookupProductRequest\x12\x18\n" +
+	"\abarcode\x18\x01 \x01(\tR\abarcode\"\xc9\x01\n" +
+	"\x13ListProductsRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12\x16\n" +
+	"\x06search\x18\x03 \x01(\tR\x06search\x12\x1f\n" +
+	"\vcategory_id\x18\x04 \x01(\tR\n" +
+	"categoryId\x12\x12\n" +
+	"\x04tags\x18\x05 \x03(\tR\x04tags\x12)\n" +
+	"\x10include_variants\x18\x06 \x01(\bR\x0fincludeVariants\"\xa2\x01\n" +
+	"\x14ListProductsResponse\x12/\n" +
+	"\bproducts\x18\x01 \x03(\v2\x13.estoque.v1.ProductR\bproducts\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\"\n" +
+	"\n" +
+	"total_size\x18\x03 \x01(\x05H\x00R\ttotalSize\x88\x01\x01B\r\n" +
+	"\v_total_size\"U\n" +
+	"\x14UpdateProductRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12-\n" +
+	"\aproduct\x18\x02 \x01(\v2\x13.estoque.v1.ProductR\aproduct\"&\n" +
+	"\x14DeleteProductRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id*d\n" +
+	"\rCostingMethod\x12\x1e\n" +
+	"\x1aCOSTING_METHOD_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16COSTING_METHOD_AVERAGE\x10\x01\x12\x17\n" +
+	"\x13COSTING_METHOD_FIFO\x10\x022\xc8\x03\n" +
+	"\x0eProductService\x12F\n" +
+	"\rCreateProduct\x12 .estoque.v1.CreateProductRequest\x1a\x13.estoque.v1.Product\x12@\n" +
+	"\n" +
+	"GetProduct\x12\x1d.estoque.v1.GetProductRequest\x1a\x13.estoque.v1.Product\x12F\n" +
+	"\rLookupProduct\x12 .estoque.v1.LookupProductRequest\x1a\x13.estoque.v1.Product\x12Q\n" +
+	"\fListProducts\x12\x1f.estoque.v1.ListProductsRequest\x1a .estoque.v1.ListProductsResponse\x12F\n" +
+	"\rUpdateProduct\x12 .estoque.v1.UpdateProductRequest\x1a\x13.estoque.v1.Product\x12I\n" +
+	"\rDeleteProduct\x12 .estoque.v1.DeleteProductRequest\x1a\x16.google.protobuf.EmptyB8Z6controle-de-estoque/backend/proto/estoque/v1;estoquev1b\x06proto3"
+
+var (
+	file_estoque_v1_products_proto_rawDescOnce sync.Once
+	file_estoque_v1_products_proto_rawDescData []byte
+)
+
+func file_estoque_v1_products_proto_rawDescGZIP() []byte {
+	file_estoque_v1_products_proto_rawDescOnce.Do(func() {
+		file_estoque_v1_products_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_estoque_v1_products_proto_rawDesc), len(file_estoque_v1_products_proto_rawDesc)))
+	})
+	return file_estoque_v1_products_proto_rawDescData
+}
+
+var file_estoque_v1_products_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_estoque_v1_products_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_estoque_v1_products_proto_goTypes = []any{
+	(CostingMethod)(0),            // 0: estoque.v1.CostingMethod
+	(*Product)(nil),               // 1: estoque.v1.Product
+	(*CreateProductRequest)(nil),  // 2: estoque.v1.CreateProductRequest
+	(*GetProductRequest)(nil),     // 3: estoque.v1.GetProductRequest
+	(*LookupProductRequest)(nil),  // 4: estoque.v1.LookupProductRequest
+	(*ListProductsRequest)(nil),   // 5: estoque.v1.ListProductsRequest
+	(*ListProductsResponse)(nil),  // 6: estoque.v1.ListProductsResponse
+	(*UpdateProductRequest)(nil),  // 7: estoque.v1.UpdateProductRequest
+	(*DeleteProductRequest)(nil),  // 8: estoque.v1.DeleteProductRequest
+	nil,                           // 9: estoque.v1.Product.VariantOptionsEntry
+	(*timestamppb.Timestamp)(nil), // 10: google.protobuf.Timestamp
+	(*structpb.Struct)(nil),       // 11: google.protobuf.Struct
+	(*emptypb.Empty)(nil),         // 12: google.protobuf.Empty
+}
+var file_estoque_v1_products_proto_depIdxs = []int32{
+	9,  // 0: estoque.v1.Product.variant_options:type_name -> estoque.v1.Product.VariantOptionsEntry
+	1,  // 1: estoque.v1.Product.variants:type_name -> estoque.v1.Product
+	10, // 2: estoque.v1.Product.created_at:type_name -> google.protobuf.Timestamp
+	10, // 3: estoque.v1.Product.updated_at:type_name -> google.protobuf.Timestamp
+	11, // 4: estoque.v1.Product.attributes:type_name -> google.protobuf.Struct
+	0,  // 5: estoque.v1.Product.costing_method:type_name -> estoque.v1.CostingMethod
+	1,  // 6: estoque.v1.CreateProductRequest.product:type_name -> estoque.v1.Product
+	1,  // 7: estoque.v1.ListProductsResponse.products:type_name -> estoque.v1.Product
+	1,  // 8: estoque.v1.UpdateProductRequest.product:type_name -> estoque.v1.Product
+	2,  // 9: estoque.v1.ProductService.CreateProduct:input_type -> estoque.v1.CreateProductRequest
+	3,  // 10: estoque.v1.ProductService.GetProduct:input_type -> estoque.v1.GetProductRequest
+	4,  // 11: estoque.v1.ProductService.LookupProduct:input_type -> estoque.v1.LookupProductRequest
+	5,  // 12: estoque.v1.ProductService.ListProducts:input_type -> estoque.v1.ListProductsRequest
+	7,  // 13: estoque.v1.ProductService.UpdateProduct:input_type -> estoque.v1.UpdateProductRequest
+	8,  // 14: estoque.v1.ProductService.DeleteProduct:input_type -> estoque.v1.DeleteProductRequest
+	1,  // 15: estoque.v1.ProductService.CreateProduct:output_type -> estoque.v1.Product
+	1,  // 16: estoque.v1.ProductService.GetProduct:output_type -> estoque.v1.Product
+	1,  // 17: estoque.v1.ProductService.LookupProduct:output_type -> estoque.v1.Product
+	6,  // 18: estoque.v1.ProductService.ListProducts:output_type -> estoque.v1.ListProductsResponse
+	1,  // 19: estoque.v1.ProductService.UpdateProduct:output_type -> estoque.v1.Product
+	12, // 20: estoque.v1.ProductService.DeleteProduct:output_type -> google.protobuf.Empty
+	15, // [15:21] is the sub-list for method output_type
+	9,  // [9:15] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_estoque_v1_products_proto_init() }
+func file_estoque_v1_products_proto_init() {
+	if File_estoque_v1_products_proto != nil {
+		return
+	}
+	file_estoque_v1_products_proto_msgTypes[0].OneofWrappers = []any{}
+	file_estoque_v1_products_proto_msgTypes[5].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_estoque_v1_products_proto_rawDesc), len(file_estoque_v1_products_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_estoque_v1_products_proto_goTypes,
+		DependencyIndexes: file_estoque_v1_products_proto_depIdxs,
+		EnumInfos:         file_estoque_v1_products_proto_enumTypes,
+		MessageInfos:      file_estoque_v1_products_proto_msgTypes,
+	}.Build()
+	File_estoque_v1_products_proto = out.File
+	file_estoque_v1_products_proto_goTypes = nil
+	file_estoque_v1_products_proto_depIdxs = nil
+}
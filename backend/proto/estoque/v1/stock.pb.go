@@ -0,0 +1,1010 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: estoque/v1/stock.proto
+
+package estoquev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type MovementType int32
+
+const (
+	MovementType_MOVEMENT_TYPE_UNSPECIFIED MovementType = 0
+	// Entrada de mercadoria no estoque global.
+	MovementType_MOVEMENT_TYPE_RECEIPT MovementType = 1
+	// Correção manual (inventário, avaria, etc.).
+	MovementType_MOVEMENT_TYPE_ADJUSTMENT MovementType = 2
+	// Saída do estoque global para um cliente.
+	MovementType_MOVEMENT_TYPE_TRANSFER MovementType = 3
+	// Devolução de um cliente para o estoque global.
+	MovementType_MOVEMENT_TYPE_RETURN MovementType = 4
+)
+
+// Enum value maps for MovementType.
+var (
+	MovementType_name = map[int32]string{
+		0: "MOVEMENT_TYPE_UNSPECIFIED",
+		1: "MOVEMENT_TYPE_RECEIPT",
+		2: "MOVEMENT_TYPE_ADJUSTMENT",
+		3: "MOVEMENT_TYPE_TRANSFER",
+		4: "MOVEMENT_TYPE_RETURN",
+	}
+	MovementType_value = map[string]int32{
+		"MOVEMENT_TYPE_UNSPECIFIED": 0,
+		"MOVEMENT_TYPE_RECEIPT":     1,
+		"MOVEMENT_TYPE_ADJUSTMENT":  2,
+		"MOVEMENT_TYPE_TRANSFER":    3,
+		"MOVEMENT_TYPE_RETURN":      4,
+	}
+)
+
+func (x MovementType) Enum() *MovementType {
+	p := new(MovementType)
+	*p = x
+	return p
+}
+
+func (x MovementType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MovementType) Descriptor() protoreflect.EnumDescriptor {
+	return file_estoque_v1_stock_proto_enumTypes[0].Descriptor()
+}
+
+func (MovementType) Type() protoreflect.EnumType {
+	return &file_estoque_v1_stock_proto_enumTypes[0]
+}
+
+func (x MovementType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MovementType.Descriptor instead.
+func (MovementType) EnumDescriptor() ([]byte, []int) {
+	return file_estoque_v1_stock_proto_rawDescGZIP(), []int{0}
+}
+
+type StockMovement struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProductId         string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	ClientId          *string                `protobuf:"bytes,3,opt,name=client_id,json=clientId,proto3,oneof" json:"client_id,omitempty"`
+	Type              MovementType           `protobuf:"varint,4,opt,name=type,proto3,enum=estoque.v1.MovementType" json:"type,omitempty"`
+	Packaging         string                 `protobuf:"bytes,5,opt,name=packaging,proto3" json:"packaging,omitempty"`
+	PackagingQuantity int32                  `protobuf:"varint,6,opt,name=packaging_quantity,json=packagingQuantity,proto3" json:"packaging_quantity,omitempty"`
+	PackagingFactor   int32                  `protobuf:"varint,7,opt,name=packaging_factor,json=packagingFactor,proto3" json:"packaging_factor,omitempty"`
+	// Variação do estoque global na unidade base (negativa nas saídas).
+	Quantity  int32                  `protobuf:"varint,8,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Reason    string                 `protobuf:"bytes,9,opt,name=reason,proto3" json:"reason,omitempty"`
+	Lots      []*LotAllocation       `protobuf:"bytes,10,rep,name=lots,proto3" json:"lots,omitempty"`
+	Serials   []string               `protobuf:"bytes,11,rep,name=serials,proto3" json:"serials,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Custo total da movimentação, com o sinal da quantidade.
+	CostInCents *int64 `protobuf:"varint,13,opt,name=cost_in_cents,json=costInCents,proto3,oneof" json:"cost_in_cents,omitempty"`
+	// Preço de venda por unidade base, em transferências e devoluções.
+	UnitPriceInCents *int64 `protobuf:"varint,14,opt,name=unit_price_in_cents,json=unitPriceInCents,proto3,oneof" json:"unit_price_in_cents,omitempty"`
+	// Moeda do preço: a da tabela de preços do cliente ou a do produto.
+	Currency string `protobuf:"bytes,15,opt,name=currency,proto3" json:"currency,omitempty"`
+	// Moeda do custo: a do produto.
+	CostCurrency  string `protobuf:"bytes,16,opt,name=cost_currency,json=costCurrency,proto3" json:"cost_currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockMovement) Reset() {
+	*x = StockMovement{}
+	mi := &file_estoque_v1_stock_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockMovement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockMovement) ProtoMessage() {}
+
+func (x *StockMovement) ProtoReflect() protoreflect.Message {
+	mi := &file_estoque_v1_stock_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockMovement.ProtoReflect.Descriptor instead.
+func (*StockMovement) Descriptor() ([]byte, []int) {
+	return file_estoque_v1_stock_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *StockMovement) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *StockMovement) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *StockMovement) GetClientId() string {
+	if x != nil && x.ClientId != nil {
+		return *x.ClientId
+	}
+	return ""
+}
+
+func (x *StockMovement) GetType() MovementType {
+	if x != nil {
+		return x.Type
+	}
+	return MovementType_MOVEMENT_TYPE_UNSPECIFIED
+}
+
+func (x *StockMovement) GetPackaging() string {
+	if x != nil {
+		return x.Packaging
+	}
+	return ""
+}
+
+func (x *StockMovement) GetPackagingQuantity() int32 {
+	if x != nil {
+		return x.PackagingQuantity
+	}
+	return 0
+}
+
+func (x *StockMovement) GetPackagingFactor() int32 {
+	if x != nil {
+		return x.PackagingFactor
+	}
+	return 0
+}
+
+func (x *StockMovement) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *StockMovement) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *StockMovement) GetLots() []*LotAllocation {
+	if x != nil {
+		return x.Lots
+	}
+	return nil
+}
+
+func (x *StockMovement) GetSerials() []string {
+	if x != nil {
+		return x.Serials
+	}
+	return nil
+}
+
+func (x *StockMovement) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *StockMovement) GetCostInCents() int64 {
+	if x != nil && x.CostInCents != nil {
+		return *x.CostInCents
+	}
+	return 0
+}
+
+func (x *StockMovement) GetUnitPriceInCents() int64 {
+	if x != nil && x.UnitPriceInCents != nil {
+		return *x.UnitPriceInCents
+	}
+	return 0
+}
+
+func (x *StockMovement) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *StockMovement) GetCostCurrency() string {
+	if x != nil {
+		return x.CostCurrency
+	}
+	return ""
+}
+
+// LotAllocation é a parte de uma quantidade atribuída a um lote.
+type LotAllocation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LotId         string                 `protobuf:"bytes,1,opt,name=lot_id,json=lotId,proto3" json:"lot_id,omitempty"`
+	BatchNumber   string                 `protobuf:"bytes,2,opt,name=batch_number,json=batchNumber,proto3" json:"batch_number,omitempty"`
+	ExpiryDate    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expiry_date,json=expiryDate,proto3" json:"expiry_date,omitempty"`
+	Quantity      int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LotAllocation) Reset() {
+	*x = LotAllocation{}
+	mi := &file_estoque_v1_stock_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LotAllocation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LotAllocation) ProtoMessage() {}
+
+func (x *LotAllocation) ProtoReflect() protoreflect.Message {
+	mi := &file_estoque_v1_stock_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LotAllocation.ProtoReflect.Descriptor instead.
+func (*LotAllocation) Descriptor() ([]byte, []int) {
+	return file_estoque_v1_stock_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *LotAllocation) GetLotId() string {
+	if x != nil {
+		return x.LotId
+	}
+	return ""
+}
+
+func (x *LotAllocation) GetBatchNumber() string {
+	if x != nil {
+		return x.BatchNumber
+	}
+	return ""
+}
+
+func (x *LotAllocation) GetExpiryDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiryDate
+	}
+	return nil
+}
+
+func (x *LotAllocation) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type TransferStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	ClientId      string                 `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Packaging     string                 `protobuf:"bytes,4,opt,name=packaging,proto3" json:"packaging,omitempty"`
+	Serials       []string               `protobuf:"bytes,5,rep,name=serials,proto3" json:"serials,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferStockRequest) Reset() {
+	*x = TransferStockRequest{}
+	mi := &file_estoque_v1_stock_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferStockRequest) ProtoMessage() {}
+
+func (x *TransferStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_estoque_v1_stock_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferStockRequest.ProtoReflect.Descriptor instead.
+func (*TransferStockRequest) Descriptor() ([]byte, []int) {
+	return file_estoque_v1_stock_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TransferStockRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *TransferStockRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *TransferStockRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *TransferStockRequest) GetPackaging() string {
+	if x != nil {
+		return x.Packaging
+	}
+	return ""
+}
+
+func (x *TransferStockRequest) GetSerials() []string {
+	if x != nil {
+		return x.Serials
+	}
+	return nil
+}
+
+type ReceiveStockRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity  int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Packaging string                 `protobuf:"bytes,3,opt,name=packaging,proto3" json:"packaging,omitempty"`
+	Reason    string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	// Obrigatório para produtos com controle de lote.
+	BatchNumber string `protobuf:"bytes,5,opt,name=batch_number,json=batchNumber,proto3" json:"batch_number,omitempty"`
+	// Validade do lote, no formato AAAA-MM-DD.
+	ExpiryDate string   `protobuf:"bytes,6,opt,name=expiry_date,json=expiryDate,proto3" json:"expiry_date,omitempty"`
+	Serials    []string `protobuf:"bytes,7,rep,name=serials,proto3" json:"serials,omitempty"`
+	// Custo por unidade base; sem ele, vale o custo médio atual.
+	UnitCostInCents *int64 `protobuf:"varint,8,opt,name=unit_cost_in_cents,json=unitCostInCents,proto3,oneof" json:"unit_cost_in_cents,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ReceiveStockRequest) Reset() {
+	*x = ReceiveStockRequest{}
+	mi := &file_estoque_v1_stock_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReceiveStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReceiveStockRequest) ProtoMessage() {}
+
+func (x *ReceiveStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_estoque_v1_stock_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReceiveStockRequest.ProtoReflect.Descriptor instead.
+func (*ReceiveStockRequest) Descriptor() ([]byte, []int) {
+	return file_estoque_v1_stock_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ReceiveStockRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ReceiveStockRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *ReceiveStockRequest) GetPackaging() string {
+	if x != nil {
+		return x.Packaging
+	}
+	return ""
+}
+
+func (x *ReceiveStockRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ReceiveStockRequest) GetBatchNumber() string {
+	if x != nil {
+		return x.BatchNumber
+	}
+	return ""
+}
+
+func (x *ReceiveStockRequest) GetExpiryDate() string {
+	if x != nil {
+		return x.ExpiryDate
+	}
+	return ""
+}
+
+func (x *ReceiveStockRequest) GetSerials() []string {
+	if x != nil {
+		return x.Serials
+	}
+	return nil
+}
+
+func (x *ReceiveStockRequest) GetUnitCostInCents() int64 {
+	if x != nil && x.UnitCostInCents != nil {
+		return *x.UnitCostInCents
+	}
+	return 0
+}
+
+type AdjustStockRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	// Positiva para entradas e negativa para saídas.
+	Quantity  int32  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Packaging string `protobuf:"bytes,3,opt,name=packaging,proto3" json:"packaging,omitempty"`
+	// Obrigatório, para auditoria.
+	Reason      string   `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	BatchNumber string   `protobuf:"bytes,5,opt,name=batch_number,json=batchNumber,proto3" json:"batch_number,omitempty"`
+	Serials     []string `protobuf:"bytes,6,rep,name=serials,proto3" json:"serials,omitempty"`
+	// Custo por unidade base dos ajustes positivos; sem ele, vale o custo médio atual.
+	UnitCostInCents *int64 `protobuf:"varint,7,opt,name=unit_cost_in_cents,json=unitCostInCents,proto3,oneof" json:"unit_cost_in_cents,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *AdjustStockRequest) Reset() {
+	*x = AdjustStockRequest{}
+	mi := &file_estoque_v1_stock_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdjustStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdjustStockRequest) ProtoMessage() {}
+
+func (x *AdjustStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_estoque_v1_stock_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdjustStockRequest.ProtoReflect.Descriptor instead.
+func (*AdjustStockRequest) Descriptor() ([]byte, []int) {
+	return file_estoque_v1_stock_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *AdjustStockRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *AdjustStockRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *AdjustStockRequest) GetPackaging() string {
+	if x != nil {
+		return x.Packaging
+	}
+	return ""
+}
+
+func (x *AdjustStockRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *AdjustStockRequest) GetBatchNumber() string {
+	if x != nil {
+		return x.BatchNumber
+	}
+	return ""
+}
+
+func (x *AdjustStockRequest) GetSerials() []string {
+	if x != nil {
+		return x.Serials
+	}
+	return nil
+}
+
+func (x *AdjustStockRequest) GetUnitCostInCents() int64 {
+	if x != nil && x.UnitCostInCents != nil {
+		return *x.UnitCostInCents
+	}
+	return 0
+}
+
+type ReturnStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	ClientId      string                 `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Packaging     string                 `protobuf:"bytes,4,opt,name=packaging,proto3" json:"packaging,omitempty"`
+	Reason        string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	BatchNumber   string                 `protobuf:"bytes,6,opt,name=batch_number,json=batchNumber,proto3" json:"batch_number,omitempty"`
+	Serials       []string               `protobuf:"bytes,7,rep,name=serials,proto3" json:"serials,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReturnStockRequest) Reset() {
+	*x = ReturnStockRequest{}
+	mi := &file_estoque_v1_stock_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReturnStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReturnStockRequest) ProtoMessage() {}
+
+func (x *ReturnStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_estoque_v1_stock_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReturnStockRequest.ProtoReflect.Descriptor instead.
+func (*ReturnStockRequest) Descriptor() ([]byte, []int) {
+	return file_estoque_v1_stock_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ReturnStockRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ReturnStockRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *ReturnStockRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *ReturnStockRequest) GetPackaging() string {
+	if x != nil {
+		return x.Packaging
+	}
+	return ""
+}
+
+func (x *ReturnStockRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ReturnStockRequest) GetBatchNumber() string {
+	if x != nil {
+		return x.BatchNumber
+	}
+	return ""
+}
+
+func (x *ReturnStockRequest) GetSerials() []string {
+	if x != nil {
+		return x.Serials
+	}
+	return nil
+}
+
+type WatchStockEventsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Apenas movimentações destes produtos; vazio para todos.
+	ProductIds []string `protobuf:"bytes,1,rep,name=product_ids,json=productIds,proto3" json:"product_ids,omitempty"`
+	// Apenas movimentações destes clientes; vazio para todos.
+	ClientIds     []string `protobuf:"bytes,2,rep,name=client_ids,json=clientIds,proto3" json:"client_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchStockEventsRequest) Reset() {
+	*x = WatchStockEventsRequest{}
+	mi := &file_estoque_v1_stock_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchStockEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchStockEventsRequest) ProtoMessage() {}
+
+func (x *WatchStockEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_estoque_v1_stock_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchStockEventsRequest.ProtoReflect.Descriptor instead.
+func (*WatchStockEventsRequest) Descriptor() ([]byte, []int) {
+	return file_estoque_v1_stock_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *WatchStockEventsRequest) GetProductIds() []string {
+	if x != nil {
+		return x.ProductIds
+	}
+	return nil
+}
+
+func (x *WatchStockEventsRequest) GetClientIds() []string {
+	if x != nil {
+		return x.ClientIds
+	}
+	return nil
+}
+
+// StockEvent é uma movimentação de estoque confirmada.
+type StockEvent struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	MovementId string                 `protobuf:"bytes,1,opt,name=movement_id,json=movementId,proto3" json:"movement_id,omitempty"`
+	ProductId  string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	ClientId   *string                `protobuf:"bytes,3,opt,name=client_id,json=clientId,proto3,oneof" json:"client_id,omitempty"`
+	Type       MovementType           `protobuf:"varint,4,opt,name=type,proto3,enum=estoque.v1.MovementType" json:"type,omitempty"`
+	// Variação do estoque global na unidade base (negativa nas saídas).
+	Quantity      int32                  `protobuf:"varint,5,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockEvent) Reset() {
+	*x = StockEvent{}
+	mi := &file_estoque_v1_stock_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockEvent) ProtoMessage() {}
+
+func (x *StockEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_estoque_v1_stock_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockEvent.ProtoReflect.Descriptor instead.
+func (*StockEvent) Descriptor() ([]byte, []int) {
+	return file_estoque_v1_stock_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *StockEvent) GetMovementId() string {
+	if x != nil {
+		return x.MovementId
+	}
+	return ""
+}
+
+func (x *StockEvent) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *StockEvent) GetClientId() string {
+	if x != nil && x.ClientId != nil {
+		return *x.ClientId
+	}
+	return ""
+}
+
+func (x *StockEvent) GetType() MovementType {
+	if x != nil {
+		return x.Type
+	}
+	return MovementType_MOVEMENT_TYPE_UNSPECIFIED
+}
+
+func (x *StockEvent) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *StockEvent) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+var File_estoque_v1_stock_proto protoreflect.FileDescriptor
+
+const file_estoque_v1_stock_proto_rawDesc = "" +
+	"\n" +
+	"\x16estoque/v1/stock.proto\x12\n" +
+	"estoque.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x94\x05\n" +
+	"\rStockMovement\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12 \n" +
+	"\tclient_id\x18\x03 \x01(\tH\x00R\bclientId\x88\x01\x01\x12,\n" +
+	"\x04type\x18\x04 \x01(\x0e2\x18.estoque.v1.MovementTypeR\x04type\x12\x1c\n" +
+	"\tpackaging\x18\x05 \x01(\tR\tpackaging\x12-\n" +
+	"\x12packaging_quantity\x18\x06 \x01(\x05R\x11packagingQuantity\x12)\n" +
+	"\x10packaging_factor\x18\a \x01(\x05R\x0fpackagingFactor\x12\x1a\n" +
+	"\bquantity\x18\b \x01(\x05R\bquantity\x12\x16\n" +
+	"\x06reason\x18\t \x01(\tR\x06reason\x12-\n" +
+	"\x04lots\x18\n" +
+	" \x03(\v2\x19.estoque.v1.LotAllocationR\x04lots\x12\x18\n" +
+	"\aserials\x18\v \x03(\tR\aserials\x129\n" +
+	"\n" +
+	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12'\n" +
+	"\rcost_in_cents\x18\r \x01(\x03H\x01R\vcostInCents\x88\x01\x01\x122\n" +
+	"\x13unit_price_in_cents\x18\x0e \x01(\x03H\x02R\x10unitPriceInCents\x88\x01\x01\x12\x1a\n" +
+	"\bcurrency\x18\x0f \x01(\tR\bcurrency\x12#\n" +
+	"\rcost_currency\x18\x10 \x01(\tR\fcostCurrencyB\f\n" +
+	"\n" +
+	"_client_idB\x10\n" +
+	"\x0e_cost_in_centsB\x16\n" +
+	"\x14_unit_price_in_cents\"\xa2\x01\n" +
+	"\rLotAllocation\x12\x15\n" +
+	"\x06lot_id\x18\x01 \x01(\tR\x05lotId\x12!\n" +
+	"\fbatch_number\x18\x02 \x01(\tR\vbatchNumber\x12;\n" +
+	"\vexpiry_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"expiryDate\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x05R\bquantity\"\xa6\x01\n" +
+	"\x14TransferStockRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1b\n" +
+	"\tclient_id\x18\x02 \x01(\tR\bclientId\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\x12\x1c\n" +
+	"\tpackaging\x18\x04 \x01(\tR\tpackaging\x12\x18\n" +
+	"\aserials\x18\x05 \x03(\tR\aserials\"\xad\x02\n" +
+	"\x13ReceiveStockRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\x1c\n" +
+	"\tpackaging\x18\x03 \x01(\tR\tpackaging\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12!\n" +
+	"\fbatch_number\x18\x05 \x01(\tR\vbatchNumber\x12\x1f\n" +
+	"\vexpiry_date\x18\x06 \x01(\tR\n" +
+	"expiryDate\x12\x18\n" +
+	"\aserials\x18\a \x03(\tR\aserials\x120\n" +
+	"\x12unit_cost_in_cents\x18\b \x01(\x03H\x00R\x0funitCostInCents\x88\x01\x01B\x15\n" +
+	"\x13_unit_cost_in_cents\"\x8b\x02\n" +
+	"\x12AdjustStockRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\x1c\n" +
+	"\tpackaging\x18\x03 \x01(\tR\tpackaging\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12!\n" +
+	"\fbatch_number\x18\x05 \x01(\tR\vbatchNumber\x12\x18\n" +
+	"\aserials\x18\x06 \x03(\tR\aserials\x120\n" +
+	"\x12unit_cost_in_cents\x18\a \x01(\x03H\x00R\x0funitCostInCents\x88\x01\x01B\x15\n" +
+	"\x13_unit_cost_in_cents\"\xdf\x01\n" +
+	"\x12ReturnStockRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1b\n" +
+	"\tclient_id\x18\x02 \x01(\tR\bclientId\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\x12\x1c\n" +
+	"\tpackaging\x18\x04 \x01(\tR\tpackaging\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x12!\n" +
+	"\fbatch_number\x18\x06 \x01(\tR\vbatchNumber\x12\x18\n" +
+	"\aserials\x18\a \x03(\tR\aserials\"Y\n" +
+	"\x17WatchStockEventsRequest\x12\x1f\n" +
+	"\vproduct_ids\x18\x01 \x03(\tR\n" +
+	"productIds\x12\x1d\n" +
+	"\n" +
+	"client_ids\x18\x02 \x03(\tR\tclientIds\"\x81\x02\n" +
+	"\n" +
+	"StockEvent\x12\x1f\n" +
+	"\vmovement_id\x18\x01 \x01(\tR\n" +
+	"movementId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12 \n" +
+	"\tclient_id\x18\x03 \x01(\tH\x00R\bclientId\x88\x01\x01\x12,\n" +
+	"\x04type\x18\x04 \x01(\x0e2\x18.estoque.v1.MovementTypeR\x04type\x12\x1a\n" +
+	"\bquantity\x18\x05 \x01(\x05R\bquantity\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAtB\f\n" +
+	"\n" +
+	"_client_id*\x9c\x01\n" +
+	"\fMovementType\x12\x1d\n" +
+	"\x19MOVEMENT_TYPE_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15MOVEMENT_TYPE_RECEIPT\x10\x01\x12\x1c\n" +
+	"\x18MOVEMENT_TYPE_ADJUSTMENT\x10\x02\x12\x1a\n" +
+	"\x16MOVEMENT_TYPE_TRANSFER\x10\x03\x12\x18\n" +
+	"\x14MOVEMENT_TYPE_RETURN\x10\x042\x8f\x03\n" +
+	"\fStockService\x12L\n" +
+	"\rTransferStock\x12 .estoque.v1.TransferStockRequest\x1a\x19.estoque.v1.StockMovement\x12J\n" +
+	"\fReceiveStock\x12\x1f.estoque.v1.ReceiveStockRequest\x1a\x19.estoque.v1.StockMovement\x12H\n" +
+	"\vAdjustStock\x12\x1e.estoque.v1.AdjustStockRequest\x1a\x19.estoque.v1.StockMovement\x12H\n" +
+	"\vReturnStock\x12\x1e.estoque.v1.ReturnStockRequest\x1a\x19.estoque.v1.StockMovement\x12Q\n" +
+	"\x10WatchStockEvents\x12#.estoque.v1.WatchStockEventsRequest\x1a\x16.estoque.v1.StockEvent0\x01B8Z6controle-de-estoque/backend/proto/estoque/v1;estoquev1b\x06proto3"
+
+var (
+	file_estoque_v1_stock_proto_rawDescOnce sync.Once
+	file_estoque_v1_stock_proto_rawDescData []byte
+)
+
+func file_estoque_v1_stock_proto_rawDescGZIP() []byte {
+	file_estoque_v1_stock_proto_rawDescOnce.Do(func() {
+		file_estoque_v1_stock_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_estoque_v1_stock_proto_rawDesc), len(file_estoque_v1_stock_proto_rawDesc)))
+	})
+	return file_estoque_v1_stock_proto_rawDescData
+}
+
+var file_estoque_v1_stock_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_estoque_v1_stock_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_estoque_v1_stock_proto_goTypes = []any{
+	(MovementType)(0),               // 0: estoque.v1.MovementType
+	(*StockMovement)(nil),           // 1: estoque.v1.StockMovement
+	(*LotAllocation)(nil),           // 2: estoque.v1.LotAllocation
+	(*TransferStockRequest)(nil),    // 3: estoque.v1.TransferStockRequest
+	(*ReceiveStockRequest)(nil),     // 4: estoque.v1.ReceiveStockRequest
+	(*AdjustStockRequest)(nil),      // 5: estoque.v1.AdjustStockRequest
+	(*ReturnStockRequest)(nil),      // 6: estoque.v1.ReturnStockRequest
+	(*WatchStockEventsRequest)(nil), // 7: estoque.v1.WatchStockEventsRequest
+	(*StockEvent)(nil),              // 8: estoque.v1.StockEvent
+	(*timestamppb.Timestamp)(nil),   // 9: google.protobuf.Timestamp
+}
+var file_estoque_v1_stock_proto_depIdxs = []int32{
+	0,  // 0: estoque.v1.StockMovement.type:type_name -> estoque.v1.MovementType
+	2,  // 1: estoque.v1.StockMovement.lots:type_name -> estoque.v1.LotAllocation
+	9,  // 2: estoque.v1.StockMovement.created_at:type_name -> google.protobuf.Timestamp
+	9,  // 3: estoque.v1.LotAllocation.expiry_date:type_name -> google.protobuf.Timestamp
+	0,  // 4: estoque.v1.StockEvent.type:type_name -> estoque.v1.MovementType
+	9,  // 5: estoque.v1.StockEvent.created_at:type_name -> google.protobuf.Timestamp
+	3,  // 6: estoque.v1.StockService.TransferStock:input_type -> estoque.v1.TransferStockRequest
+	4,  // 7: estoque.v1.StockService.ReceiveStock:input_type -> estoque.v1.ReceiveStockRequest
+	5,  // 8: estoque.v1.StockService.AdjustStock:input_type -> estoque.v1.AdjustStockRequest
+	6,  // 9: estoque.v1.StockService.ReturnStock:input_type -> estoque.v1.ReturnStockRequest
+	7,  // 10: estoque.v1.StockService.WatchStockEvents:input_type -> estoque.v1.WatchStockEventsRequest
+	1,  // 11: estoque.v1.StockService.TransferStock:output_type -> estoque.v1.StockMovement
+	1,  // 12: estoque.v1.StockService.ReceiveStock:output_type -> estoque.v1.StockMovement
+	1,  // 13: estoque.v1.StockService.AdjustStock:output_type -> estoque.v1.StockMovement
+	1,  // 14: estoque.v1.StockService.ReturnStock:output_type -> estoque.v1.StockMovement
+	8,  // 15: estoque.v1.StockService.WatchStockEvents:output_type -> estoque.v1.StockEvent
+	11, // [11:16] is the sub-list for method output_type
+	6,  // [6:11] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_estoque_v1_stock_proto_init() }
+func file_estoque_v1_stock_proto_init() {
+	if File_estoque_v1_stock_proto != nil {
+		return
+	}
+	file_estoque_v1_stock_proto_msgTypes[0].OneofWrappers = []any{}
+	file_estoque_v1_stock_proto_msgTypes[3].OneofWrappers = []any{}
+	file_estoque_v1_stock_proto_msgTypes[4].OneofWrappers = []any{}
+	file_estoque_v1_stock_proto_msgTypes[7].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_estoque_v1_stock_proto_rawDesc), len(file_estoque_v1_stock_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_estoque_v1_stock_proto_goTypes,
+		DependencyIndexes: file_estoque_v1_stock_proto_depIdxs,
+		EnumInfos:         file_estoque_v1_stock_proto_enumTypes,
+		MessageInfos:      file_estoque_v1_stock_proto_msgTypes,
+	}.Build()
+	File_estoque_v1_stock_proto = out.File
+	file_estoque_v1_stock_proto_goTypes = nil
+	file_estoque_v1_stock_proto_depIdxs = nil
+}
@@ -0,0 +1,303 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: estoque/v1/stock.proto
+
+package estoquev1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	StockService_TransferStock_FullMethodName    = "/estoque.v1.StockService/TransferStock"
+	StockService_ReceiveStock_FullMethodName     = "/estoque.v1.StockService/ReceiveStock"
+	StockService_AdjustStock_FullMethodName      = "/estoque.v1.StockService/AdjustStock"
+	StockService_ReturnStock_FullMethodName      = "/estoque.v1.StockService/ReturnStock"
+	StockService_WatchStockEvents_FullMethodName = "/estoque.v1.StockService/WatchStockEvents"
+)
+
+// StockServiceClient is the client API for StockService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// StockService registra as movimentações do estoque global e transmite as movimentações
+// confirmadas em tempo real. As quantidades das requisições são expressas na embalagem
+// informada em packaging; sem ela, na unidade base do produto.
+type StockServiceClient interface {
+	// TransferStock transfere estoque global para um cliente.
+	TransferStock(ctx context.Context, in *TransferStockRequest, opts ...grpc.CallOption) (*StockMovement, error)
+	// ReceiveStock registra um recebimento de mercadoria.
+	ReceiveStock(ctx context.Context, in *ReceiveStockRequest, opts ...grpc.CallOption) (*StockMovement, error)
+	// AdjustStock aplica um ajuste manual ao estoque global.
+	AdjustStock(ctx context.Context, in *AdjustStockRequest, opts ...grpc.CallOption) (*StockMovement, error)
+	// ReturnStock registra a devolução de um cliente ao estoque global.
+	ReturnStock(ctx context.Context, in *ReturnStockRequest, opts ...grpc.CallOption) (*StockMovement, error)
+	// WatchStockEvents envia cada movimentação confirmada a partir da assinatura, até o
+	// cliente cancelar a chamada. Não há repetição de eventos perdidos: ao reconectar, o
+	// cliente deve reler os saldos que acompanha. A chamada termina com RESOURCE_EXHAUSTED
+	// se o cliente não acompanhar o ritmo dos eventos e com UNAVAILABLE se a escuta no
+	// servidor for interrompida.
+	WatchStockEvents(ctx context.Context, in *WatchStockEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StockEvent], error)
+}
+
+type stockServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStockServiceClient(cc grpc.ClientConnInterface) StockServiceClient {
+	return &stockServiceClient{cc}
+}
+
+func (c *stockServiceClient) TransferStock(ctx context.Context, in *TransferStockRequest, opts ...grpc.CallOption) (*StockMovement, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StockMovement)
+	err := c.cc.Invoke(ctx, StockService_TransferStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockServiceClient) ReceiveStock(ctx context.Context, in *ReceiveStockRequest, opts ...grpc.CallOption) (*StockMovement, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StockMovement)
+	err := c.cc.Invoke(ctx, StockService_ReceiveStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockServiceClient) AdjustStock(ctx context.Context, in *AdjustStockRequest, opts ...grpc.CallOption) (*StockMovement, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StockMovement)
+	err := c.cc.Invoke(ctx, StockService_AdjustStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockServiceClient) ReturnStock(ctx context.Context, in *ReturnStockRequest, opts ...grpc.CallOption) (*StockMovement, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StockMovement)
+	err := c.cc.Invoke(ctx, StockService_ReturnStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockServiceClient) WatchStockEvents(ctx context.Context, in *WatchStockEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StockEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &StockService_ServiceDesc.Streams[0], StockService_WatchStockEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchStockEventsRequest, StockEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StockService_WatchStockEventsClient = grpc.ServerStreamingClient[StockEvent]
+
+// StockServiceServer is the server API for StockService service.
+// All implementations must embed UnimplementedStockServiceServer
+// for forward compatibility.
+//
+// StockService registra as movimentações do estoque global e transmite as movimentações
+// confirmadas em tempo real. As quantidades das requisições são expressas na embalagem
+// informada em packaging; sem ela, na unidade base do produto.
+type StockServiceServer interface {
+	// TransferStock transfere estoque global para um cliente.
+	TransferStock(context.Context, *TransferStockRequest) (*StockMovement, error)
+	// ReceiveStock registra um recebimento de mercadoria.
+	ReceiveStock(context.Context, *ReceiveStockRequest) (*StockMovement, error)
+	// AdjustStock aplica um ajuste manual ao estoque global.
+	AdjustStock(context.Context, *AdjustStockRequest) (*StockMovement, error)
+	// ReturnStock registra a devolução de um cliente ao estoque global.
+	ReturnStock(context.Context, *ReturnStockRequest) (*StockMovement, error)
+	// WatchStockEvents envia cada movimentação confirmada a partir da assinatura, até o
+	// cliente cancelar a chamada. Não há repetição de eventos perdidos: ao reconectar, o
+	// cliente deve reler os saldos que acompanha. A chamada termina com RESOURCE_EXHAUSTED
+	// se o cliente não acompanhar o ritmo dos eventos e com UNAVAILABLE se a escuta no
+	// servidor for interrompida.
+	WatchStockEvents(*WatchStockEventsRequest, grpc.ServerStreamingServer[StockEvent]) error
+	mustEmbedUnimplementedStockServiceServer()
+}
+
+// UnimplementedStockServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedStockServiceServer struct{}
+
+func (UnimplementedStockServiceServer) TransferStock(context.Context, *TransferStockRequest) (*StockMovement, error) {
+	return nil, status.Error(codes.Unimplemented, "method TransferStock not implemented")
+}
+func (UnimplementedStockServiceServer) ReceiveStock(context.Context, *ReceiveStockRequest) (*StockMovement, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReceiveStock not implemented")
+}
+func (UnimplementedStockServiceServer) AdjustStock(context.Context, *AdjustStockRequest) (*StockMovement, error) {
+	return nil, status.Error(codes.Unimplemented, "method AdjustStock not implemented")
+}
+func (UnimplementedStockServiceServer) ReturnStock(context.Context, *ReturnStockRequest) (*StockMovement, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReturnStock not implemented")
+}
+func (UnimplementedStockServiceServer) WatchStockEvents(*WatchStockEventsRequest, grpc.ServerStreamingServer[StockEvent]) error {
+	return status.Error(codes.Unimplemented, "method WatchStockEvents not implemented")
+}
+func (UnimplementedStockServiceServer) mustEmbedUnimplementedStockServiceServer() {}
+func (UnimplementedStockServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeStockServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to StockServiceServer will
+// result in compilation errors.
+type UnsafeStockServiceServer interface {
+	mustEmbedUnimplementedStockServiceServer()
+}
+
+func RegisterStockServiceServer(s grpc.ServiceRegistrar, srv StockServiceServer) {
+	// If the following call panics, it indicates UnimplementedStockServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&StockService_ServiceDesc, srv)
+}
+
+func _StockService_TransferStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServiceServer).TransferStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockService_TransferStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServiceServer).TransferStock(ctx, req.(*TransferStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockService_ReceiveStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReceiveStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServiceServer).ReceiveStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockService_ReceiveStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServiceServer).ReceiveStock(ctx, req.(*ReceiveStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockService_AdjustStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdjustStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServiceServer).AdjustStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockService_AdjustStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServiceServer).AdjustStock(ctx, req.(*AdjustStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockService_ReturnStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReturnStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServiceServer).ReturnStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockService_ReturnStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServiceServer).ReturnStock(ctx, req.(*ReturnStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockService_WatchStockEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchStockEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StockServiceServer).WatchStockEvents(m, &grpc.GenericServerStream[WatchStockEventsRequest, StockEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StockService_WatchStockEventsServer = grpc.ServerStreamingServer[StockEvent]
+
+// StockService_ServiceDesc is the grpc.ServiceDesc for StockService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var StockService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "estoque.v1.StockService",
+	HandlerType: (*StockServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "TransferStock",
+			Handler:    _StockService_TransferStock_Handler,
+		},
+		{
+			MethodName: "ReceiveStock",
+			Handler:    _StockService_ReceiveStock_Handler,
+		},
+		{
+			MethodName: "AdjustStock",
+			Handler:    _StockService_AdjustStock_Handler,
+		},
+		{
+			MethodName: "ReturnStock",
+			Handler:    _StockService_ReturnStock_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchStockEvents",
+			Handler:       _StockService_WatchStockEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "estoque/v1/stock.proto",
+}
@@ -1,7 +1,8 @@
 import { useState, useEffect } from 'react';
 import toast from 'react-hot-toast';
 import api from '@/services/api';
-import { Client } from '@/types/Client';
+import { Client, ClientStatus } from '@/types/Client';
+import { getFieldErrors } from '@/services/validation';
 import formStyles from '@/styles/Form.module.css';
 
 interface EditClientFormProps {
@@ -14,12 +15,17 @@ export default function EditClientForm({ client, onSuccess, onCancel }: EditClie
     const [name, setName] = useState(client.name);
     const [email, setEmail] = useState(client.email);
     const [phone, setPhone] = useState(client.phone);
+    const [document, setDocument] = useState(client.document);
+    const [status, setStatus] = useState<ClientStatus>(client.status);
     const [isLoading, setIsLoading] = useState(false);
+    const [fieldErrors, setFieldErrors] = useState<Record<string, string>>({});
 
     useEffect(() => {
         setName(client.name);
         setEmail(client.email);
         setPhone(client.phone);
+        setDocument(client.document);
+        setStatus(client.status);
     }, [client]);
 
     async function handleSubmit(event: React.FormEvent) {
@@ -29,12 +35,23 @@ export default function EditClientForm({ client, onSuccess, onCancel }: EditClie
             return;
         }
         setIsLoading(true);
+        setFieldErrors({});
         try {
-            const response = await api.put(`/clients/${client.id}`, { name, email, phone });
+            // PATCH (JSON Merge Patch) preserva os campos que o formulário não edita, como endereços e contatos.
+            const response = await api.patch(`/clients/${client.id}`, { name, email, phone, document, status }, {
+                headers: { 'Content-Type': 'application/merge-patch+json' },
+            });
             toast.success('Cliente atualizado com sucesso!');
             onSuccess(response.data);
         } catch (error) {
-            toast.error('Ocorreu um erro ao atualizar o cliente.');
+            // Erros de validação (422) são exibidos junto de cada campo.
+            const fields = getFieldErrors(error);
+            if (fields) {
+                setFieldErrors(fields);
+                toast.error('Verifique os campos destacados.');
+            } else {
+                toast.error('Ocorreu um erro ao atualizar o cliente.');
+            }
             console.error(error);
         } finally {
             setIsLoading(false);
@@ -43,9 +60,16 @@ export default function EditClientForm({ client, onSuccess, onCancel }: EditClie
 
     return (
         <form onSubmit={handleSubmit} className={formStyles.form}>
-            <label>Nome do Cliente:<input type="text" value={name} onChange={(e) => setName(e.target.value)} className={formStyles.input} /></label>
-            <label>Email:<input type="email" value={email} onChange={(e) => setEmail(e.target.value)} className={formStyles.input} /></label>
-            <label>Telefone:<input type="tel" value={phone} onChange={(e) => setPhone(e.target.value)} className={formStyles.input} /></label>
+            <label>Nome do Cliente:<input type="text" value={name} onChange={(e) => setName(e.target.value)} className={formStyles.input} />{fieldErrors.name && <span className={formStyles.error}>{fieldErrors.name}</span>}</label>
+            <label>Email:<input type="email" value={email} onChange={(e) => setEmail(e.target.value)} className={formStyles.input} />{fieldErrors.email && <span className={formStyles.error}>{fieldErrors.email}</span>}</label>
+            <label>Telefone:<input type="tel" value={phone} onChange={(e) => setPhone(e.target.value)} className={formStyles.input} />{fieldErrors.phone && <span className={formStyles.error}>{fieldErrors.phone}</span>}</label>
+            <label>CPF/CNPJ:<input type="text" value={document} onChange={(e) => setDocument(e.target.value)} className={formStyles.input} />{fieldErrors.document && <span className={formStyles.error}>{fieldErrors.document}</span>}</label>
+            <label>Situação:
+                <select value={status} onChange={(e) => setStatus(e.target.value as ClientStatus)} className={formStyles.input}>
+                    <option value="active">Ativo</option>
+                    <option value="blocked">Bloqueado</option>
+                </select>
+            </label>
             <div style={{ display: 'flex', gap: '1rem', justifyContent: 'flex-end', marginTop: '1rem' }}>
                 <button type="button" onClick={onCancel} className={formStyles.button} style={{backgroundColor: '#6c757d'}}>Cancelar</button>
                 <button type="submit" disabled={isLoading} className={formStyles.button}>{isLoading ? 'Salvando...' : 'Salvar Alterações'}</button>
@@ -2,6 +2,7 @@ import { useState, useEffect } from 'react';
 import api from '@/services/api';
 import toast from 'react-hot-toast';
 import { Product } from '@/types/Product';
+import { getFieldErrors } from '@/services/validation';
 import formStyles from '@/styles/Form.module.css';
 
 interface EditProductFormProps {
@@ -20,6 +21,7 @@ export default function EditProductForm({
   const [price, setPrice] = useState((product.price_in_cents / 100).toFixed(2));
   const [quantity, setQuantity] = useState(product.quantity.toString());
   const [isLoading, setIsLoading] = useState(false);
+  const [fieldErrors, setFieldErrors] = useState<Record<string, string>>({});
 
   useEffect(() => {
     setName(product.name);
@@ -41,12 +43,23 @@ export default function EditProductForm({
       quantity: parseInt(quantity, 10),
     };
     setIsLoading(true);
+    setFieldErrors({});
     try {
-      const response = await api.put(`/products/${product.id}`, payload);
+      // PATCH (JSON Merge Patch) preserva os campos que o formulário não edita, como SKU e códigos de barras.
+      const response = await api.patch(`/products/${product.id}`, payload, {
+        headers: { 'Content-Type': 'application/merge-patch+json' },
+      });
       toast.success('Produto atualizado com sucesso!');
       onSuccess(response.data);
     } catch (err) {
-      toast.error('Ocorreu um erro ao atualizar o produto.');
+      // Erros de validação (422) são exibidos junto de cada campo.
+      const fields = getFieldErrors(err);
+      if (fields) {
+        setFieldErrors(fields);
+        toast.error('Verifique os campos destacados.');
+      } else {
+        toast.error('Ocorreu um erro ao atualizar o produto.');
+      }
       console.error(err);
     } finally {
       setIsLoading(false);
@@ -63,6 +76,7 @@ export default function EditProductForm({
           onChange={(e) => setName(e.target.value)}
           className={formStyles.input}
         />
+        {fieldErrors.name && <span className={formStyles.error}>{fieldErrors.name}</span>}
       </label>
       <label>
         Descrição:
@@ -71,6 +85,7 @@ export default function EditProductForm({
           onChange={(e) => setDescription(e.target.value)}
           className={formStyles.textarea}
         />
+        {fieldErrors.description && <span className={formStyles.error}>{fieldErrors.description}</span>}
       </label>
       <label>
         Preço (ex: 29.99):
@@ -81,6 +96,7 @@ export default function EditProductForm({
           onChange={(e) => setPrice(e.target.value)}
           className={formStyles.input}
         />
+        {fieldErrors.price_in_cents && <span className={formStyles.error}>{fieldErrors.price_in_cents}</span>}
       </label>
       <label>
         Quantidade em Estoque:
@@ -90,6 +106,7 @@ export default function EditProductForm({
           onChange={(e) => setQuantity(e.target.value)}
           className={formStyles.input}
         />
+        {fieldErrors.quantity && <span className={formStyles.error}>{fieldErrors.quantity}</span>}
       </label>
 
       <div
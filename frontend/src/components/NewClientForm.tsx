@@ -2,6 +2,7 @@ import { useState } from 'react';
 import toast from 'react-hot-toast';
 import api from '@/services/api';
 import { Client } from '@/types/Client';
+import { getFieldErrors } from '@/services/validation';
 import formStyles from '@/styles/Form.module.css';
 
 interface NewClientFormProps {
@@ -13,7 +14,9 @@ export default function NewClientForm({ onSuccess, onCancel }: NewClientFormProp
     const [name, setName] = useState('');
     const [email, setEmail] = useState('');
     const [phone, setPhone] = useState('');
+    const [document, setDocument] = useState('');
     const [isLoading, setIsLoading] = useState(false);
+    const [fieldErrors, setFieldErrors] = useState<Record<string, string>>({});
 
     async function handleSubmit(event: React.FormEvent) {
         event.preventDefault();
@@ -22,12 +25,20 @@ export default function NewClientForm({ onSuccess, onCancel }: NewClientFormProp
             return;
         }
         setIsLoading(true);
+        setFieldErrors({});
         try {
-            const response = await api.post('/clients', { name, email, phone });
+            const response = await api.post('/clients', { name, email, phone, document });
             toast.success('Cliente criado com sucesso!');
             onSuccess(response.data);
         } catch (error) {
-            toast.error('Ocorreu um erro ao criar o cliente.');
+            // Erros de validação (422) são exibidos junto de cada campo.
+            const fields = getFieldErrors(error);
+            if (fields) {
+                setFieldErrors(fields);
+                toast.error('Verifique os campos destacados.');
+            } else {
+                toast.error('Ocorreu um erro ao criar o cliente.');
+            }
             console.error(error);
         } finally {
             setIsLoading(false);
@@ -36,9 +47,10 @@ export default function NewClientForm({ onSuccess, onCancel }: NewClientFormProp
 
     return (
         <form onSubmit={handleSubmit} className={formStyles.form}>
-            <label>Nome do Cliente:<input type="text" value={name} onChange={(e) => setName(e.target.value)} className={formStyles.input} /></label>
-            <label>Email:<input type="email" value={email} onChange={(e) => setEmail(e.target.value)} className={formStyles.input} /></label>
-            <label>Telefone:<input type="tel" value={phone} onChange={(e) => setPhone(e.target.value)} className={formStyles.input} /></label>
+            <label>Nome do Cliente:<input type="text" value={name} onChange={(e) => setName(e.target.value)} className={formStyles.input} />{fieldErrors.name && <span className={formStyles.error}>{fieldErrors.name}</span>}</label>
+            <label>Email:<input type="email" value={email} onChange={(e) => setEmail(e.target.value)} className={formStyles.input} />{fieldErrors.email && <span className={formStyles.error}>{fieldErrors.email}</span>}</label>
+            <label>Telefone:<input type="tel" value={phone} onChange={(e) => setPhone(e.target.value)} className={formStyles.input} />{fieldErrors.phone && <span className={formStyles.error}>{fieldErrors.phone}</span>}</label>
+            <label>CPF/CNPJ:<input type="text" value={document} onChange={(e) => setDocument(e.target.value)} className={formStyles.input} />{fieldErrors.document && <span className={formStyles.error}>{fieldErrors.document}</span>}</label>
             <div style={{ display: 'flex', gap: '1rem', justifyContent: 'flex-end', marginTop: '1rem' }}>
                 <button type="button" onClick={onCancel} className={formStyles.button} style={{backgroundColor: '#6c757d'}}>Cancelar</button>
                 <button type="submit" disabled={isLoading} className={formStyles.button}>{isLoading ? 'Salvando...' : 'Salvar Cliente'}</button>
@@ -2,6 +2,7 @@ import { useState } from 'react';
 import toast from 'react-hot-toast'; // Importando o toast
 import api from '@/services/api';
 import { Product } from '@/types/Product';
+import { getFieldErrors } from '@/services/validation';
 import formStyles from '@/styles/Form.module.css';
 
 interface NewProductFormProps {
@@ -18,6 +19,7 @@ export default function NewProductForm({
   const [price, setPrice] = useState('');
   const [quantity, setQuantity] = useState('');
   const [isLoading, setIsLoading] = useState(false);
+  const [fieldErrors, setFieldErrors] = useState<Record<string, string>>({});
 
   // O estado de erro local não é mais necessário, o toast cuidará disso.
 
@@ -35,13 +37,21 @@ export default function NewProductForm({
       quantity: parseInt(quantity, 10),
     };
     setIsLoading(true);
+    setFieldErrors({});
 
     try {
       const response = await api.post('/products', payload);
       toast.success('Produto criado com sucesso!');
       onSuccess(response.data);
     } catch (err) {
-      toast.error('Ocorreu um erro ao criar o produto.');
+      // Erros de validação (422) são exibidos junto de cada campo.
+      const fields = getFieldErrors(err);
+      if (fields) {
+        setFieldErrors(fields);
+        toast.error('Verifique os campos destacados.');
+      } else {
+        toast.error('Ocorreu um erro ao criar o produto.');
+      }
       console.error(err);
     } finally {
       setIsLoading(false);
@@ -58,6 +68,7 @@ export default function NewProductForm({
           onChange={(e) => setName(e.target.value)}
           className={formStyles.input}
         />
+        {fieldErrors.name && <span className={formStyles.error}>{fieldErrors.name}</span>}
       </label>
       <label>
         Descrição:
@@ -66,6 +77,7 @@ export default function NewProductForm({
           onChange={(e) => setDescription(e.target.value)}
           className={formStyles.textarea}
         />
+        {fieldErrors.description && <span className={formStyles.error}>{fieldErrors.description}</span>}
       </label>
       <label>
         Preço (ex: 29.99):
@@ -76,6 +88,7 @@ export default function NewProductForm({
           onChange={(e) => setPrice(e.target.value)}
           className={formStyles.input}
         />
+        {fieldErrors.price_in_cents && <span className={formStyles.error}>{fieldErrors.price_in_cents}</span>}
       </label>
       <label>
         Quantidade em Estoque:
@@ -85,6 +98,7 @@ export default function NewProductForm({
           onChange={(e) => setQuantity(e.target.value)}
           className={formStyles.input}
         />
+        {fieldErrors.quantity && <span className={formStyles.error}>{fieldErrors.quantity}</span>}
       </label>
 
       <div
@@ -1,8 +1,9 @@
-import { useState, useEffect } from 'react';
+import { useState, useEffect, useRef } from 'react';
 import toast from 'react-hot-toast';
 import api from '@/services/api';
+import { problemMessage } from '@/services/problem';
 import { Product } from '@/types/Product'; // Reutilizamos o tipo Product
-import { PaginatedResponse } from '@/types/Api'; // Para buscar a lista completa
+import { fetchAllPages } from '@/services/pagination'; // Para buscar a lista completa
 import formStyles from '@/styles/Form.module.css';
 
 interface TransferStockFormProps {
@@ -15,17 +16,22 @@ export default function TransferStockForm({ clientId, onSuccess, onCancel }: Tra
     const [products, setProducts] = useState<Product[]>([]);
     const [selectedProductId, setSelectedProductId] = useState('');
     const [quantity, setQuantity] = useState('');
+    const [serials, setSerials] = useState('');
     const [isLoading, setIsLoading] = useState(false);
+    // Chave de idempotência: repetir o envio dos mesmos dados (ex.: após uma falha de rede)
+    // não duplica a transferência. Uma nova chave é gerada quando os dados mudam.
+    const idempotencyKey = useRef(crypto.randomUUID());
+
+    useEffect(() => {
+        idempotencyKey.current = crypto.randomUUID();
+    }, [selectedProductId, quantity, serials]);
 
     // Busca todos os produtos para preencher o <select>
     useEffect(() => {
         async function fetchAllProducts() {
             try {
-                // Pedimos um limite alto para buscar todos os produtos de uma vez
-                const response = await api.get<PaginatedResponse<Product>>('/products', {
-                    params: { limit: 1000 },
-                });
-                setProducts(response.data.data);
+                // Percorre todas as páginas do catálogo
+                setProducts(await fetchAllPages<Product>('/products'));
             } catch (error) {
                 toast.error('Não foi possível carregar o catálogo de produtos.');
             }
@@ -33,6 +39,8 @@ export default function TransferStockForm({ clientId, onSuccess, onCancel }: Tra
         fetchAllProducts();
     }, []);
 
+    const selectedProduct = products.find(p => p.id === selectedProductId);
+
     async function handleSubmit(event: React.FormEvent) {
         event.preventDefault();
         if (!selectedProductId || !quantity || parseInt(quantity, 10) <= 0) {
@@ -40,24 +48,31 @@ export default function TransferStockForm({ clientId, onSuccess, onCancel }: Tra
             return;
         }
 
+        // Um número de série por linha, apenas para produtos serializados
+        const serialList = serials.split('\n').map(s => s.trim()).filter(Boolean);
+        if (selectedProduct?.serialized && serialList.length !== parseInt(quantity, 10)) {
+            toast.error('Informe um número de série para cada unidade transferida.');
+            return;
+        }
+
         setIsLoading(true);
         try {
             await api.post(`/products/${selectedProductId}/transfer`, {
                 clientId: clientId,
                 quantity: parseInt(quantity, 10),
+                ...(selectedProduct?.serialized && { serials: serialList }),
+            }, {
+                headers: { 'Idempotency-Key': idempotencyKey.current },
             });
             toast.success('Estoque transferido com sucesso!');
             onSuccess();
-        } catch (error: any) {
-            const message = error.response?.data?.message || 'Erro ao transferir estoque.';
-            toast.error(message);
+        } catch (error) {
+            toast.error(problemMessage(error, 'Erro ao transferir estoque.'));
         } finally {
             setIsLoading(false);
         }
     }
 
-    const selectedProduct = products.find(p => p.id === selectedProductId);
-
     return (
         <form onSubmit={handleSubmit} className={formStyles.form}>
             <label>
@@ -90,6 +105,19 @@ export default function TransferStockForm({ clientId, onSuccess, onCancel }: Tra
                 />
             </label>
 
+            {selectedProduct?.serialized && (
+                <label>
+                    Números de Série (um por linha):
+                    <textarea
+                        value={serials}
+                        onChange={(e) => setSerials(e.target.value)}
+                        className={formStyles.input}
+                        rows={4}
+                        required
+                    />
+                </label>
+            )}
+
             <div style={{ display: 'flex', gap: '1rem', justifyContent: 'flex-end', marginTop: '1rem' }}>
                 <button type="button" onClick={onCancel} className={formStyles.button} style={{backgroundColor: '#6c757d'}}>Cancelar</button>
                 <button type="submit" disabled={isLoading || !selectedProductId} className={formStyles.button}>
@@ -1,6 +1,7 @@
 import { useState, useEffect, lazy, Suspense, useCallback } from 'react';
 import { useParams, Link } from 'react-router-dom';
 import api from '@/services/api';
+import { fetchAllPages } from '@/services/pagination';
 import { Client } from '@/types/Client';
 import { ClientStock } from '@/types/ClientStock';
 import styles from '@/styles/pages/DetailPage.module.css';
@@ -26,12 +27,12 @@ export function ClientDetailPage() {
         setLoading(true);
         setError(null);
         try {
-            const [clientResponse, stockResponse] = await Promise.all([
+            const [clientResponse, stockItems] = await Promise.all([
                 api.get<Client>(`/clients/${clientID}`),
-                api.get<ClientStock[]>(`/clients/${clientID}/stock`),
+                fetchAllPages<ClientStock>(`/clients/${clientID}/stock`),
             ]);
             setClient(clientResponse.data);
-            setStock(stockResponse.data);
+            setStock(stockItems);
         } catch (err) {
             setError('Não foi possível carregar os dados do cliente.');
             console.error(err);
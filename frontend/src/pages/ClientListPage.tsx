@@ -1,12 +1,14 @@
-import { useState, useEffect, useMemo, lazy, Suspense } from 'react';
+import { useState, useEffect, useCallback, lazy, Suspense } from 'react';
 import { Link } from 'react-router-dom'; // Importando Link para navegação
 import toast from 'react-hot-toast';
 import api from '@/services/api';
 import { Client } from '@/types/Client';
+import { Metadata, PaginatedResponse } from '@/types/Api';
 import styles from '@/styles/pages/ListPage.module.css';
 import tableStyles from '@/styles/Table.module.css';
 import formStyles from '@/styles/Form.module.css';
 import { Modal } from '@/components/Modal';
+import { Pagination } from '@/components/Pagination';
 import { FiEdit, FiTrash2 } from 'react-icons/fi';
 
 const NewClientForm = lazy(() => import('@/components/NewClientForm'));
@@ -17,6 +19,10 @@ export function ClientListPage() {
     const [loading, setLoading] = useState(true);
     const [error, setError] = useState<string | null>(null);
     const [searchTerm, setSearchTerm] = useState('');
+    const [debouncedSearchTerm, setDebouncedSearchTerm] = useState('');
+    const [metadata, setMetadata] = useState<Metadata | null>(null);
+    const [currentPage, setCurrentPage] = useState(1);
+    const PAGE_LIMIT = 20;
     const [isCreateModalOpen, setIsCreateModalOpen] = useState(false);
     const [isEditModalOpen, setIsEditModalOpen] = useState(false);
     const [clientToEdit, setClientToEdit] = useState<Client | null>(null);
@@ -24,29 +30,42 @@ export function ClientListPage() {
     const [clientToDelete, setClientToDelete] = useState<Client | null>(null);
 
     useEffect(() => {
-        async function fetchClients() {
-            setLoading(true);
-            try {
-                const response = await api.get<Client[]>('/clients');
-                setClients(response.data);
-            } catch (err) {
-                setError('Não foi possível carregar os clientes.');
-            } finally {
-                setLoading(false);
-            }
+        const timerId = setTimeout(() => {
+            setDebouncedSearchTerm(searchTerm);
+            setCurrentPage(1);
+        }, 500);
+        return () => {
+            clearTimeout(timerId);
+        };
+    }, [searchTerm]);
+
+    // A busca (nome, email ou telefone) e a paginação são feitas no servidor.
+    const fetchClients = useCallback(async () => {
+        setLoading(true);
+        setError(null);
+        try {
+            const response = await api.get<PaginatedResponse<Client>>('/clients', {
+                params: {
+                    page: currentPage,
+                    limit: PAGE_LIMIT,
+                    search: debouncedSearchTerm,
+                },
+            });
+            setClients(response.data.data);
+            setMetadata(response.data.metadata);
+        } catch (err) {
+            setError('Não foi possível carregar os clientes.');
+        } finally {
+            setLoading(false);
         }
-        fetchClients();
-    }, []);
+    }, [currentPage, debouncedSearchTerm]);
 
-    const filteredClients = useMemo(() => {
-        if (!searchTerm) return clients;
-        return clients.filter(client =>
-            client.name.toLowerCase().includes(searchTerm.toLowerCase())
-        );
-    }, [clients, searchTerm]);
+    useEffect(() => {
+        fetchClients();
+    }, [fetchClients]);
 
-    function handleCreateSuccess(newClient: Client) {
-        setClients(current => [newClient, ...current]);
+    function handleCreateSuccess() {
+        fetchClients();
         setIsCreateModalOpen(false);
     }
 
@@ -69,7 +88,7 @@ export function ClientListPage() {
         if (!clientToDelete) return;
         try {
             await api.delete(`/clients/${clientToDelete.id}`);
-            setClients(current => current.filter(c => c.id !== clientToDelete.id));
+            fetchClients();
             toast.success('Cliente deletado com sucesso!');
         } catch (err) {
             toast.error('Erro ao deletar o cliente.');
@@ -79,7 +98,6 @@ export function ClientListPage() {
         }
     }
 
-    if (loading) return <div>Carregando clientes...</div>;
     if (error) return <div>{error}</div>;
 
     return (
@@ -94,7 +112,7 @@ export function ClientListPage() {
             <div className={styles.filterContainer}>
                 <input
                     type="text"
-                    placeholder="Buscar por nome..."
+                    placeholder="Buscar por nome, email ou telefone..."
                     className={formStyles.input}
                     value={searchTerm}
                     onChange={(e) => setSearchTerm(e.target.value)}
@@ -112,8 +130,12 @@ export function ClientListPage() {
                     </tr>
                     </thead>
                     <tbody>
-                    {filteredClients.length > 0 ? (
-                        filteredClients.map(client => (
+                    {loading ? (
+                        <tr>
+                            <td colSpan={4} className={tableStyles.emptyState}>Carregando clientes...</td>
+                        </tr>
+                    ) : clients.length > 0 ? (
+                        clients.map(client => (
                             <tr key={client.id}>
                                 <td>
                                     <Link to={`/clients/${client.id}`} className={tableStyles.tableLink}>
@@ -151,6 +173,14 @@ export function ClientListPage() {
                 </table>
             </div>
 
+            {metadata && (
+                <Pagination
+                    currentPage={metadata.current_page ?? 1}
+                    totalPages={metadata.total_pages ?? 1}
+                    onPageChange={setCurrentPage}
+                />
+            )}
+
             {/* Modais */}
             <Modal open={isCreateModalOpen} onOpenChange={setIsCreateModalOpen} title="Criar Novo Cliente">
                 <Suspense fallback={<div>Carregando...</div>}>
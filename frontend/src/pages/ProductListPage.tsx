@@ -183,8 +183,8 @@ export function ProductListPage() {
 
           {metadata && (
             <Pagination
-              currentPage={metadata.current_page}
-              totalPages={metadata.total_pages}
+              currentPage={metadata.current_page ?? 1}
+              totalPages={metadata.total_pages ?? 1}
               onPageChange={setCurrentPage}
             />
           )}
@@ -2,6 +2,7 @@ import { useState } from 'react';
 import { useNavigate, Link } from 'react-router-dom';
 import toast from 'react-hot-toast';
 import api from '@/services/api';
+import { problemMessage } from '@/services/problem';
 import formStyles from '@/styles/Form.module.css';
 import styles from '@/styles/pages/AuthPages.module.css';
 
@@ -19,9 +20,8 @@ export function RegisterPage() {
             await api.post('/register', { email, password, passwordConfirm });
             toast.success('Usuário registrado com sucesso! Faça o login.');
             navigate('/login');
-        } catch (error: any) {
-            const message = error.response?.data?.message || 'Erro ao registrar. Tente novamente.';
-            toast.error(message);
+        } catch (error) {
+            toast.error(problemMessage(error, 'Erro ao registrar. Tente novamente.'));
         } finally {
             setIsLoading(false);
         }
@@ -1,9 +1,10 @@
 import axios from 'axios';
 
 // Cria uma instância do axios com a URL base da nossa API Go.
-// Todas as requisições feitas com esta instância irão para http://localhost:8080
+// Todas as requisições feitas com esta instância irão para a versão 1 da API,
+// em http://localhost:8080/api/v1 (as rotas sem prefixo estão descontinuadas).
 const api = axios.create({
-  baseURL: 'http://localhost:8080',
+  baseURL: 'http://localhost:8080/api/v1',
 });
 
 export default api;
@@ -0,0 +1,17 @@
+import api from '@/services/api';
+import { PaginatedResponse } from '@/types/Api';
+
+// Busca todas as páginas de uma listagem seguindo o `next_cursor` devolvido pela API.
+// Útil para telas que precisam da lista completa (ex.: selects); listas grandes devem paginar.
+export async function fetchAllPages<T>(url: string, params: Record<string, unknown> = {}): Promise<T[]> {
+    const items: T[] = [];
+    let cursor: string | undefined;
+    do {
+        const response = await api.get<PaginatedResponse<T>>(url, {
+            params: { ...params, limit: 100, include_total: false, ...(cursor && { cursor }) },
+        });
+        items.push(...response.data.data);
+        cursor = response.data.metadata.next_cursor;
+    } while (cursor);
+    return items;
+}
@@ -0,0 +1,17 @@
+import axios from 'axios';
+import { Problem } from '@/types/Api';
+
+// Retorna o corpo application/problem+json de um erro da API, ou null se o erro não
+// veio da API (ex.: falha de rede).
+export function getProblem(error: unknown): Problem | null {
+    if (!axios.isAxiosError(error)) {
+        return null;
+    }
+    const body = error.response?.data as Partial<Problem> | undefined;
+    return body && typeof body === 'object' && typeof body.code === 'string' ? (body as Problem) : null;
+}
+
+// Mensagem legível de um erro da API, com um texto padrão para os demais erros.
+export function problemMessage(error: unknown, fallback: string): string {
+    return getProblem(error)?.detail || fallback;
+}
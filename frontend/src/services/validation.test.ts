@@ -0,0 +1,37 @@
+import { AxiosError, AxiosHeaders } from 'axios';
+import { getFieldErrors } from './validation';
+
+function axiosError(status: number, data: unknown) {
+    const headers = new AxiosHeaders();
+    return new AxiosError('erro', undefined, { headers }, undefined, {
+        status, statusText: '', headers, config: { headers }, data,
+    });
+}
+
+describe('getFieldErrors', () => {
+    it('should index the first message of each field', () => {
+        const error = axiosError(422, {
+            type: 'urn:controle-de-estoque:problem:invalid_product_data',
+            title: 'Unprocessable Entity',
+            status: 422,
+            code: 'invalid_product_data',
+            errors: [
+                { field: 'name', code: 'required', message: 'o nome é obrigatório' },
+                { field: 'name', code: 'too_long', message: 'deve ter no máximo 200 caracteres' },
+                { field: 'price_in_cents', code: 'out_of_range', message: 'o preço não pode ser negativo' },
+            ],
+        });
+
+        expect(getFieldErrors(error)).toEqual({
+            name: 'o nome é obrigatório',
+            price_in_cents: 'o preço não pode ser negativo',
+        });
+    });
+
+    it('should return null for errors that are not validation errors', () => {
+        expect(getFieldErrors(axiosError(409, { status: 409, code: 'sku_in_use', detail: 'SKU já está em uso' }))).toBeNull();
+        expect(getFieldErrors(axiosError(500, 'Erro ao criar o produto'))).toBeNull();
+        expect(getFieldErrors(axiosError(422, 'texto'))).toBeNull();
+        expect(getFieldErrors(new Error('rede'))).toBeNull();
+    });
+});
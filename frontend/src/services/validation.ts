@@ -0,0 +1,16 @@
+import { getProblem } from '@/services/problem';
+
+// Extrai os erros por campo de uma resposta 422 da API, indexados pelo caminho do
+// campo (ex.: "name"), para exibição junto de cada input. Retorna null para outros erros.
+export function getFieldErrors(error: unknown): Record<string, string> | null {
+    const problem = getProblem(error);
+    if (problem?.status !== 422 || !Array.isArray(problem.errors)) {
+        return null;
+    }
+    const fields: Record<string, string> = {};
+    for (const { field, message } of problem.errors) {
+        // Mantém a primeira mensagem de cada campo.
+        fields[field] ??= message;
+    }
+    return fields;
+}
@@ -1,9 +1,12 @@
 // Esta interface espelha a struct `domain.Metadata` do nosso backend.
+// Os totais só vêm quando calculados (paginação por página ou include_total=true).
 export interface Metadata {
-  total_records: number;
-  current_page: number;
+  total_records?: number;
+  current_page?: number;
   page_size: number;
-  total_pages: number;
+  total_pages?: number;
+  next_cursor?: string; // Ausente na última página
+  applied_filters?: Record<string, unknown>; // Filtros e ordenação efetivamente aplicados
 }
 
 // Esta interface genérica espelha a struct `domain.PaginatedResponse`.
@@ -11,3 +14,23 @@ export interface PaginatedResponse<T> {
   data: T[];
   metadata: Metadata;
 }
+
+// Espelha `domain.FieldError`: um erro de validação de um campo do corpo da requisição.
+export interface FieldError {
+  field: string; // Caminho JSON do campo, ex.: "name" ou "addresses[0].cep"
+  code: string;
+  message: string;
+}
+
+// Espelha o corpo das respostas de erro da API (RFC 7807, application/problem+json).
+// `code` é estável e deve ser usado para tratar cada caso; `detail` é uma mensagem legível.
+export interface Problem {
+  type: string;
+  title: string;
+  status: number;
+  detail?: string;
+  instance?: string;
+  code: string; // Ex.: "insufficient_stock", "client_blocked", "product_not_found"
+  request_id?: string;
+  errors?: FieldError[]; // Apenas em erros de validação (422)
+}
@@ -1,8 +1,36 @@
+export type ClientStatus = 'active' | 'blocked';
+
+// Espelha a struct `domain.Address`; o CEP vem apenas com os 8 dígitos.
+export interface Address {
+    id?: string;
+    type: 'billing' | 'delivery';
+    street: string;
+    number: string;
+    complement: string;
+    district: string;
+    city: string;
+    state: string;
+    cep: string;
+}
+
+// Espelha a struct `domain.Contact`.
+export interface Contact {
+    id?: string;
+    name: string;
+    email: string;
+    phone: string;
+    role: string;
+}
+
 export interface Client {
     id: string;
     name: string;
     email: string;
     phone: string;
+    document: string; // CPF ou CNPJ, apenas dígitos
+    status: ClientStatus; // Clientes bloqueados não recebem transferências
+    addresses?: Address[];
+    contacts?: Contact[];
     created_at: string;
     updated_at: string;
-}
\ No newline at end of file
+}
@@ -3,4 +3,10 @@ export interface ClientStock {
     productId: string;
     productName: string;
     quantity: number;
+    lots?: {
+        lot_id: string;
+        batch_number: string;
+        expiry_date: string | null;
+        quantity: number;
+    }[];
 }
\ No newline at end of file
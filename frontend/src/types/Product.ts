@@ -1,10 +1,26 @@
 // Esta interface deve espelhar a struct `domain.Produto` do nosso backend em Go.
 export interface Product {
   id: string; // Em Go é uuid.UUID, mas em JSON/TS se torna uma string
+  sku: string;
+  category_id: string | null;
   name: string;
   description: string;
   price_in_cents: number;
   quantity: number;
+  unit: string; // Unidade de medida (UN, CX, KG, ...)
+  barcodes: string[];
+  attributes: Record<string, string | number | boolean>;
+  tags: string[];
+  track_lots: boolean;
+  serialized: boolean; // Exige números de série em recebimentos, transferências e devoluções
+  // Presentes apenas em produtos com variantes (pai) ou nas próprias variantes.
+  parent_id?: string;
+  variant_axes?: { name: string; values: string[] }[];
+  variant_options?: Record<string, string>;
+  price_override_in_cents?: number;
+  variants?: Product[];
+  // Presente apenas em listagens com `search`: relevância e trechos com os termos em <mark>.
+  search?: { rank: number; name: string; description: string };
   created_at: string; // Em Go é time.Time, em JSON/TS vira uma string no formato ISO 8601
   updated_at: string;
 }